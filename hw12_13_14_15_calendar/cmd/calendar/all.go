@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/event"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/notification"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/health"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
+)
+
+// newScheduler returns the scheduler component, the broker client is created on start
+// since the rabbitmq client blocks until it's connected.
+func newScheduler(readiness *health.Checker) supervisor.Component {
+	broker := &event.ClientsCheck{}
+	readiness.Add("scheduler broker", broker.Check)
+	return supervisor.RunFunc(func(ctx context.Context) error {
+		client := event.NewClient()
+		broker.Set(client)
+		supervisor.Ready(ctx)
+		application.NewEventSchedulerProcessor(
+			repository.GetEventRepository(),
+			repository.GetIdempotencyRepository(),
+			repository.GetNotificationOutbox(),
+			client,
+		).Schedule(ctx)
+		return nil
+	})
+}
+
+// newSender returns the sender component.
+func newSender(readiness *health.Checker) supervisor.Component {
+	broker := &event.ClientsCheck{}
+	readiness.Add("sender broker", broker.Check)
+	return supervisor.RunFunc(func(ctx context.Context) error {
+		channels, err := notification.NewChannels()
+		if err != nil {
+			return err
+		}
+		notifier := application.NewNotificationService(repository.GetNotificationTargetRepository(), channels...)
+		consumer, producer := event.NewClient(), event.NewClient()
+		broker.Set(consumer, producer)
+		supervisor.Ready(ctx)
+		application.NewEventSenderProcessor(
+			repository.GetEventRepository(), notifier, consumer, producer,
+		).Consume(ctx)
+		return nil
+	})
+}
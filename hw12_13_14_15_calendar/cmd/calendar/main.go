@@ -2,49 +2,75 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"time"
+	_ "time/tzdata" // IANA time zones for the date listings in images without tzdata.
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/http/fiber"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/health"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
 )
 
+// modeAll runs the scheduler and the sender in the calendar process.
+const modeAll = "all"
+
+var errComponentsNotRunning = errors.New("not all components are running")
+
+// newReadiness returns the readiness checks of the database, its schema and the components.
+func newReadiness(components *supervisor.Supervisor) *health.Checker {
+	readiness := health.NewChecker(time.Duration(common.Config.Health.CheckTimeout) * time.Second)
+	readiness.Add("db", repository.CheckDB)
+	readiness.Add("migrations", repository.CheckMigrations)
+	readiness.Add("components", func(context.Context) error {
+		if !components.Healthy() {
+			return errComponentsNotRunning
+		}
+		return nil
+	})
+	return readiness
+}
+
 func main() {
 	common.Config.SetConfigFileSettings(common.GetConfigPathFromArg())
+	os.Exit(run(flag.Arg(0)))
+}
 
-	server := fiber.NewServer()
-	grpcServer := grpc.NewServer()
-
+func run(mode string) int {
 	ctx, cancel := common.GetNotifyCancelCtx()
 	defer cancel()
+	flushSpans, err := common.InitTracing(ctx, "calendar")
+	if common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to init tracing: %v", err)
+		return 1
+	}
+	defer flushSpans()
 
-	go func() {
-		<-ctx.Done()
-		ctx, cancel := context.WithTimeout(
-			context.Background(), time.Duration(common.Config.Server.ShutdownTimeout)*time.Second,
-		)
-		defer cancel()
-		if err := server.Stop(ctx); common.IsErr(err) {
-			common.Logger.Error().Msg("failed to stop http server: " + err.Error())
-		}
-	}()
+	components := supervisor.New()
+	readiness := newReadiness(components)
+	components.Add("http", fiber.NewServer(components, readiness))
+	components.Add("grpc", grpc.NewServer(readiness))
+	switch mode {
+	case "":
+	case modeAll:
+		components.Add("scheduler", newScheduler(readiness))
+		components.Add("sender", newSender(readiness))
+	default:
+		fmt.Fprintf(os.Stderr, "usage: calendar [-config path] [%s]\n", modeAll)
+		return 2
+	}
 
 	common.Logger.Info().Msg("calendar service is starting...")
-
-	go func() {
-		if err := grpcServer.Start(ctx); common.IsErr(err) {
-			common.Logger.Error().Msg("failed to start grpc server: " + err.Error())
-			cancel()
-			os.Exit(1)
-		}
-	}()
-	go func() {
-		if err := server.Start(ctx); common.IsErr(err) {
-			common.Logger.Error().Msg("failed to start http server: " + err.Error())
-			cancel()
-			os.Exit(1)
-		}
-	}()
-	<-ctx.Done()
+	err = components.Run(ctx, time.Duration(common.Config.Server.ShutdownTimeout)*time.Second)
+	if common.IsErr(err) {
+		common.Logger.Error().Msgf("calendar service stopped with errors: %v", err)
+		return 1
+	}
+	common.Logger.Info().Msg("calendar service stopped")
+	return 0
 }
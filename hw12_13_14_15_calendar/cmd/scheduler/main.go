@@ -1,20 +1,55 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/event"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/http/metrics"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/health"
 )
 
 func main() {
 	common.Config.SetConfigFileSettings(common.GetConfigPathFromArg())
 	ctx, cancel := common.GetNotifyCancelCtx()
 	defer cancel()
+	flushSpans, err := common.InitTracing(ctx, "calendar-scheduler")
+	if common.IsErr(err) {
+		common.Logger.Fatal().Msgf("failed to init tracing: %v", err)
+	}
+	defer flushSpans()
+	broker := &event.ClientsCheck{}
+	readiness := health.NewChecker(time.Duration(common.Config.Health.CheckTimeout) * time.Second)
+	readiness.Add("db", repository.CheckDB)
+	readiness.Add("migrations", repository.CheckMigrations)
+	readiness.Add("broker", broker.Check)
+	exporter := metrics.NewServer(
+		common.GetServerAddr(common.Config.Metrics.Host, common.Config.Metrics.SchedulerPort), readiness,
+	)
+	go func() {
+		if err := exporter.Start(ctx); common.IsErr(err) {
+			common.Logger.Error().Msgf("failed to start metrics server: %v", err)
+		}
+	}()
 	go func() {
+		client := event.NewClient()
+		broker.Set(client)
 		application.NewEventSchedulerProcessor(
-			repository.GetEventRepository(), event.NewRabbitClient(),
+			repository.GetEventRepository(),
+			repository.GetIdempotencyRepository(),
+			repository.GetNotificationOutbox(),
+			client,
 		).Schedule(ctx)
 	}()
 	<-ctx.Done()
+	stopCtx, stopCancel := context.WithTimeout(
+		context.Background(), time.Duration(common.Config.Server.ShutdownTimeout)*time.Second,
+	)
+	defer stopCancel()
+	if err := exporter.Stop(stopCtx); common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to stop metrics server: %v", err)
+	}
 }
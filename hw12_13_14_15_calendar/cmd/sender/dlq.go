@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/event"
+)
+
+const dlqUsage = `usage: sender [-config path] dlq <command> [-queue name] [-limit n]
+
+commands:
+  list    print dead letters of the queue as JSON lines
+  replay  move dead letters back to the queue
+`
+
+// deadLetterView is a dead letter printed by the list command.
+type deadLetterView struct {
+	*domain.DeadLetter
+	Body string
+}
+
+// runDeadLetterCommand runs the dlq subcommand and returns an exit code.
+func runDeadLetterCommand(
+	ctx context.Context, dlq domain.DeadLetterQueue, args []string, stdout, stderr io.Writer,
+) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, dlqUsage)
+		return 2
+	}
+	flags := flag.NewFlagSet("dlq "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	queue := flags.String("queue", application.EventQueueName, "source queue of the dead letters")
+	limit := flags.Int("limit", 100, "maximum number of the dead letters")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	switch args[0] {
+	case "list":
+		letters, err := dlq.DeadLetters(*queue, *limit)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to get dead letters: %v\n", err)
+			return 1
+		}
+		encoder := json.NewEncoder(stdout)
+		for _, letter := range letters {
+			if err = encoder.Encode(deadLetterView{letter, string(letter.Body)}); err != nil {
+				fmt.Fprintf(stderr, "failed to print dead letter: %v\n", err)
+				return 1
+			}
+		}
+	case "replay":
+		count, err := dlq.Replay(ctx, *queue, *limit)
+		fmt.Fprintf(stdout, "replayed %d dead letters\n", count)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to replay dead letters: %v\n", err)
+			return 1
+		}
+	default:
+		fmt.Fprint(stderr, dlqUsage)
+		return 2
+	}
+	return 0
+}
+
+// errNoBrokerLog is returned for the memory broker without the log, its dead letters are kept
+// in the memory of the process which rejected them.
+var errNoBrokerLog = errors.New("dead letters of the memory broker are available in its log file only, " +
+	"BROKER.FILE_PATH is not set")
+
+// checkDeadLetterBroker returns an error if dead letters of the broker can't be reached from another process.
+func checkDeadLetterBroker(config common.BrokerConfig) error {
+	if config.Type == event.BrokerMemory && config.FilePath == "" {
+		return errNoBrokerLog
+	}
+	return nil
+}
+
+// deadLetterCommand runs the dlq subcommand with the broker client of the config and returns an exit code.
+func deadLetterCommand(args []string) int {
+	if err := checkDeadLetterBroker(common.Config.Broker); common.IsErr(err) {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	ctx, cancel := common.GetNotifyCancelCtx()
+	defer cancel()
+	client := event.NewClient()
+	defer client.Close()
+	return runDeadLetterCommand(ctx, client, args, os.Stdout, os.Stderr)
+}
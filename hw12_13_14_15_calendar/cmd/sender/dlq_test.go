@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/event"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterCommand(t *testing.T) {
+	dlq := new(mocks.DeadLetterQueue)
+	dlq.On("DeadLetters", "events", 2).Return([]*domain.DeadLetter{
+		{Queue: "events", Body: []byte(`[{"EventID":"1"}]`), Attempts: 5, Error: "timeout"},
+	}, nil)
+	dlq.On("Replay", mock.Anything, "events", 100).Return(3, nil)
+	dlq.On("Replay", mock.Anything, "results", 100).Return(1, errors.New("channel closed"))
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, runDeadLetterCommand(context.Background(), dlq, []string{"list", "-limit", "2"}, &stdout, &stderr))
+	var letter map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &letter))
+	require.Equal(t, `[{"EventID":"1"}]`, letter["Body"])
+	require.Equal(t, "timeout", letter["Error"])
+
+	stdout.Reset()
+	require.Equal(t, 0, runDeadLetterCommand(context.Background(), dlq, []string{"replay"}, &stdout, &stderr))
+	require.Equal(t, "replayed 3 dead letters\n", stdout.String())
+
+	require.Equal(t, 1, runDeadLetterCommand(
+		context.Background(), dlq, []string{"replay", "-queue", "results"}, &stdout, &stderr,
+	))
+	require.Equal(t, 2, runDeadLetterCommand(context.Background(), dlq, []string{"purge"}, &stdout, &stderr))
+	require.Equal(t, 2, runDeadLetterCommand(context.Background(), dlq, nil, &stdout, &stderr))
+	dlq.AssertExpectations(t)
+}
+
+func TestCheckDeadLetterBroker(t *testing.T) {
+	require.NoError(t, checkDeadLetterBroker(common.BrokerConfig{Type: event.BrokerRabbitMQ}))
+	require.NoError(t, checkDeadLetterBroker(common.BrokerConfig{Type: event.BrokerMemory, FilePath: "broker.log"}))
+	require.ErrorIs(t, checkDeadLetterBroker(common.BrokerConfig{Type: event.BrokerMemory}), errNoBrokerLog)
+}
@@ -1,20 +1,63 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/event"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/notification"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/http/metrics"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/health"
 )
 
 func main() {
 	common.Config.SetConfigFileSettings(common.GetConfigPathFromArg())
+	if flag.Arg(0) == "dlq" {
+		os.Exit(deadLetterCommand(flag.Args()[1:]))
+	}
 	ctx, cancel := common.GetNotifyCancelCtx()
 	defer cancel()
+	flushSpans, err := common.InitTracing(ctx, "calendar-sender")
+	if common.IsErr(err) {
+		common.Logger.Fatal().Msgf("failed to init tracing: %v", err)
+	}
+	defer flushSpans()
+	channels, err := notification.NewChannels()
+	if common.IsErr(err) {
+		common.Logger.Fatal().Msgf("failed to create notification channels: %v", err)
+	}
+	broker := &event.ClientsCheck{}
+	readiness := health.NewChecker(time.Duration(common.Config.Health.CheckTimeout) * time.Second)
+	readiness.Add("db", repository.CheckDB)
+	readiness.Add("migrations", repository.CheckMigrations)
+	readiness.Add("broker", broker.Check)
+	exporter := metrics.NewServer(
+		common.GetServerAddr(common.Config.Metrics.Host, common.Config.Metrics.SenderPort), readiness,
+	)
+	go func() {
+		if err := exporter.Start(ctx); common.IsErr(err) {
+			common.Logger.Error().Msgf("failed to start metrics server: %v", err)
+		}
+	}()
+	notifier := application.NewNotificationService(repository.GetNotificationTargetRepository(), channels...)
 	go func() {
+		consumer, producer := event.NewClient(), event.NewClient()
+		broker.Set(consumer, producer)
 		application.NewEventSenderProcessor(
-			repository.GetEventRepository(), event.NewRabbitClient(), event.NewRabbitClient(),
+			repository.GetEventRepository(), notifier, consumer, producer,
 		).Consume(ctx)
 	}()
 	<-ctx.Done()
+	stopCtx, stopCancel := context.WithTimeout(
+		context.Background(), time.Duration(common.Config.Server.ShutdownTimeout)*time.Second,
+	)
+	defer stopCancel()
+	if err := exporter.Stop(stopCtx); common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to stop metrics server: %v", err)
+	}
 }
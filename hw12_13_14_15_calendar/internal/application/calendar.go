@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CalendarService manages calendars of users and their sharing grants.
+type CalendarService struct {
+	repository domain.CalendarRepository
+}
+
+// NewCalendarService returns a new instance of the calendar service.
+func NewCalendarService(repository domain.CalendarRepository) *CalendarService {
+	return &CalendarService{repository: repository}
+}
+
+// Create creates a new calendar owned by the user, the time zone is UTC unless it's set.
+func (s *CalendarService) Create(ctx context.Context, userID int64, calendar *domain.Calendar) (err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Create")
+	defer func() { common.EndSpan(span, err) }()
+	calendar.ID = uuid.New().String()
+	calendar.UserID = userID
+	calendar.Default = false
+	calendar.Access = domain.CalendarAccessOwner
+	if calendar.TimeZone == "" {
+		calendar.TimeZone = time.UTC.String()
+	}
+	if err := calendar.Validate(); common.IsErr(err) {
+		return err
+	}
+	return s.repository.Add(ctx, calendar)
+}
+
+// Get returns a calendar of the user or a calendar shared with the user by its id.
+func (s *CalendarService) Get(ctx context.Context, userID int64, id string) (calendar *domain.Calendar, err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Get")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+	return s.repository.Get(ctx, userID, id)
+}
+
+// List returns a list of the user calendars and calendars shared with the user.
+func (s *CalendarService) List(ctx context.Context, userID int64) (calendars []*domain.Calendar, err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.List")
+	defer func() { common.EndSpan(span, err) }()
+	return s.repository.GetCalendars(ctx, userID)
+}
+
+// Update updates the name, the color and the time zone of a calendar of the user.
+func (s *CalendarService) Update(ctx context.Context, userID int64, calendar *domain.Calendar) (err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Update")
+	defer func() { common.EndSpan(span, err) }()
+	calendar.UserID = userID
+	if calendar.TimeZone == "" {
+		calendar.TimeZone = time.UTC.String()
+	}
+	if err := calendar.Validate(); common.IsErr(err) {
+		return err
+	}
+	return s.repository.Update(ctx, calendar)
+}
+
+// Delete removes an empty calendar of the user.
+func (s *CalendarService) Delete(ctx context.Context, userID int64, id string) (err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Delete")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return err
+	}
+	return s.repository.Delete(ctx, userID, id)
+}
+
+// Share grants the access to a calendar of the user to another user.
+func (s *CalendarService) Share(ctx context.Context, userID int64, id string, share *domain.CalendarShare) (err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Share")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return err
+	}
+	return s.repository.Share(ctx, userID, id, share)
+}
+
+// Unshare revokes the access of another user to a calendar of the user.
+func (s *CalendarService) Unshare(ctx context.Context, userID int64, id string, shareUserID int64) (err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Unshare")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return err
+	}
+	return s.repository.Unshare(ctx, userID, id, shareUserID)
+}
+
+// Shares returns a list of shares of a calendar of the user.
+func (s *CalendarService) Shares(
+	ctx context.Context, userID int64, id string,
+) (shares []*domain.CalendarShare, err error) {
+	ctx, span := common.Tracer.Start(ctx, "CalendarService.Shares")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+	return s.repository.GetShares(ctx, userID, id)
+}
+
+func (s *CalendarService) validateID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.ErrUUID
+	}
+	return nil
+}
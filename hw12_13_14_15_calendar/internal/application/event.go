@@ -3,16 +3,23 @@ package application
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type EventSchedulerProcessor struct {
-	repository domain.EventRepository
-	producer   domain.EventProducer
-	consumer   domain.EventConsumer
+	repository  domain.EventRepository
+	idempotency domain.IdempotencyRepository
+	outbox      domain.NotificationOutbox
+	producer    domain.EventProducer
+	consumer    domain.EventConsumer
+	notifier    *NotificationService
 }
 
 const (
@@ -23,36 +30,47 @@ const (
 // NewEventSchedulerProcessor returns a new instance of the event scheduler service.
 func NewEventSchedulerProcessor(
 	repository domain.EventRepository,
+	idempotency domain.IdempotencyRepository,
+	outbox domain.NotificationOutbox,
 	producer domain.EventProducer,
 ) *EventSchedulerProcessor {
-	return &EventSchedulerProcessor{repository: repository, producer: producer}
+	return &EventSchedulerProcessor{repository: repository, idempotency: idempotency, outbox: outbox, producer: producer}
 }
 
 // NewEventSenderProcessor returns a new instance of the event sender service.
 func NewEventSenderProcessor(
 	repository domain.EventRepository,
+	notifier *NotificationService,
 	consumer domain.EventConsumer,
 	producer domain.EventProducer,
 ) *EventSchedulerProcessor {
-	return &EventSchedulerProcessor{repository: repository, consumer: consumer, producer: producer}
+	return &EventSchedulerProcessor{repository: repository, notifier: notifier, consumer: consumer, producer: producer}
 }
 
-func (s *EventSchedulerProcessor) cleanEvents() {
+// cleanEvents purges events which expired or stayed in the trash longer than their retention periods
+// and expired results of write requests.
+func (s *EventSchedulerProcessor) cleanEvents(ctx context.Context) {
 	common.Logger.Info().Msg("event cleanup started")
-	t := time.Now().Add(-time.Duration(common.Config.Scheduler.EventLifetime) * time.Second)
-	err := s.repository.DeleteEventBeforeDate(t)
+	now := time.Now().UTC()
+	expiredBefore := now.Add(-time.Duration(common.Config.Scheduler.EventLifetime) * time.Second)
+	deletedBefore := now.Add(-time.Duration(common.Config.Scheduler.DeletedRetention) * time.Second)
+	err := s.repository.PurgeEvents(ctx, expiredBefore, deletedBefore)
 	if common.IsErr(err) {
 		common.Logger.Error().Msgf("failed to clean events: %v", err)
 	}
+	err = s.idempotency.Purge(ctx, now)
+	if common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to clean request results: %v", err)
+	}
 	common.Logger.Info().Msg("event cleanup completed")
 }
 
-// Schedule schedules events.
+// Schedule publishes due notifications of the outbox and cleans old events periodically.
 func (s *EventSchedulerProcessor) Schedule(ctx context.Context) {
 	common.Logger.Info().Msg("running the scheduler")
 	periodTime := time.Duration(common.Config.Scheduler.PublishPeriodTime) * time.Second
 	ticker := time.NewTicker(periodTime)
-	startDate := time.Now().UTC().Add(-periodTime).Round(time.Second)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
@@ -60,45 +78,78 @@ func (s *EventSchedulerProcessor) Schedule(ctx context.Context) {
 			if common.IsErr(err) {
 				common.Logger.Error().Msgf("failed to close producer: %v", err)
 			}
+			return
 		case <-ticker.C:
-			endDate := time.Now().UTC().Round(time.Second)
-			events, err := s.repository.GetEventsByNotifyTime(startDate, endDate)
-			if common.IsErr(err) {
-				common.Logger.Error().Msgf("failed to handle events: %v", err)
-			}
-			notifications := make([]*domain.Notification, len(events))
-			for i, event := range events {
-				notification := domain.Notification{
-					EventID:    event.ID,
-					EventTitle: event.Title,
-					EventDate:  event.StartTime,
-					UserToSend: event.UserID,
+			s.publishNotifications(ctx)
+			s.cleanEvents(ctx)
+		}
+	}
+}
+
+// outboxBackoff returns the backoff of notification retries from the config.
+func outboxBackoff() domain.Backoff {
+	return domain.Backoff{
+		BaseDelay:   time.Duration(common.Config.Scheduler.OutboxRetryDelay) * time.Second,
+		MaxDelay:    time.Duration(common.Config.Scheduler.OutboxMaxRetryDelay) * time.Second,
+		MaxAttempts: common.Config.Scheduler.OutboxMaxAttempts,
+	}
+}
+
+// publishNotifications publishes due notifications of the outbox batch by batch
+// until a batch is not full or has failed notifications.
+func (s *EventSchedulerProcessor) publishNotifications(ctx context.Context) {
+	common.Logger.Info().Msg("started publishing notifications")
+	batchSize := common.Config.Scheduler.OutboxBatchSize
+	backoff := outboxBackoff()
+	for ctx.Err() == nil {
+		failed := false
+		count, err := s.outbox.ProcessNotifications(
+			ctx, time.Now().UTC(), batchSize, backoff, func(m *domain.OutboxMessage) error {
+				common.EventsScheduled.Inc()
+				err := s.publishNotification(ctx, m)
+				if common.IsErr(err) {
+					failed = true
+					common.EventsFailed.Inc()
+					common.Logger.Error().Msgf("failed to publish notification %d: %v", m.ID, err)
+					return err
 				}
-				notifications[i] = &notification
-			}
-			data, err := json.Marshal(notifications)
-			if common.IsErr(err) {
-				common.Logger.Error().Msgf("failed to marshal notification: %v", err)
-				continue
-			}
-			common.Logger.Info().Msg("started publishing notifications")
-			err = s.producer.Publish(ctx, "events", data)
-			if common.IsErr(err) {
-				common.Logger.Error().Msgf("failed to publish notification: %v", err)
-				continue
-			}
-			startDate = endDate
-			s.cleanEvents()
+				common.EventsPublished.Inc()
+				return nil
+			},
+		)
+		if common.IsErr(err) {
+			common.Logger.Error().Msgf("failed to handle notifications: %v", err)
+			return
 		}
+		if failed || count < batchSize {
+			return
+		}
+	}
+}
+
+// publishNotification publishes the notification continuing the trace of the write which enqueued it.
+func (s *EventSchedulerProcessor) publishNotification(ctx context.Context, m *domain.OutboxMessage) (err error) {
+	ctx, span := common.Tracer.Start(
+		common.ContextWithTrace(ctx, m.TraceContext),
+		"EventSchedulerProcessor.publishNotification",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("event.id", m.EventID)),
+	)
+	defer func() { common.EndSpan(span, err) }()
+	data, err := json.Marshal(m.Notifications)
+	if common.IsErr(err) {
+		return err
 	}
+	return s.producer.Publish(ctx, EventQueueName, data)
 }
 
-// Consume consumes events.
+// Consume consumes notifications and delivers them until the context is done.
 func (s *EventSchedulerProcessor) Consume(ctx context.Context) {
 	common.Logger.Info().Msg("start consume")
-	consumer, err := s.consumer.Consume(EventQueueName)
+	deliveries, err := s.consumer.Consume(EventQueueName)
 	if common.IsErr(err) {
 		common.Logger.Error().Msgf("failed to consume: %v", err)
+		return
 	}
 	for {
 		select {
@@ -107,20 +158,79 @@ func (s *EventSchedulerProcessor) Consume(ctx context.Context) {
 			if common.IsErr(err) {
 				common.Logger.Error().Msgf("failed to close consumer: %v", err)
 			}
-		case data := <-consumer:
-			var notifications []*domain.Notification
-			err := json.Unmarshal(data, &notifications)
-			if common.IsErr(err) {
-				common.Logger.Error().Msgf("failed to unmarshal notification: %v", err)
-				continue
-			}
-			for _, notification := range notifications {
-				common.Logger.Info().Msgf("sending notification: %v", notification)
-				err = s.producer.Publish(ctx, EventResultQueueName, []byte(notification.EventID))
-				if common.IsErr(err) {
-					common.Logger.Error().Msgf("failed to publish result in queue: %v", err)
-				}
+			return
+		case d, ok := <-deliveries:
+			if !ok {
+				return
 			}
+			s.handleDelivery(ctx, d)
+		}
+	}
+}
+
+// handleDelivery sends notifications of the message and acks it, the message is retried if any notification
+// is not delivered and rejected if it's not a list of notifications.
+func (s *EventSchedulerProcessor) handleDelivery(ctx context.Context, d domain.Delivery) {
+	ctx, span := common.Tracer.Start(
+		d.Context(ctx), "EventSchedulerProcessor.handleDelivery", trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+	defer span.End()
+	log := common.LoggerCtx(ctx)
+	if !d.Timestamp().IsZero() {
+		common.ConsumerLag.WithLabelValues(EventQueueName).Observe(time.Since(d.Timestamp()).Seconds())
+	}
+	var notifications []*domain.Notification
+	err := json.Unmarshal(d.Body(), &notifications)
+	if common.IsErr(err) {
+		log.Error().Msgf("failed to unmarshal notification: %v", err)
+		err = d.Reject(fmt.Errorf("invalid notification message: %w", err))
+		if common.IsErr(err) {
+			log.Error().Msgf("failed to reject message: %v", err)
+		}
+		return
+	}
+	var failed error
+	for _, notification := range notifications {
+		result := s.sendNotification(ctx, notification, d.Attempt())
+		if !result.Delivered {
+			failed = errors.New(result.Error)
 		}
 	}
+	if failed != nil {
+		err = d.Retry(failed)
+	} else {
+		err = d.Ack()
+	}
+	if common.IsErr(err) {
+		log.Error().Msgf("failed to settle message: %v", err)
+	}
+}
+
+// sendNotification delivers the notification and publishes the delivery result.
+func (s *EventSchedulerProcessor) sendNotification(
+	ctx context.Context,
+	notification *domain.Notification,
+	attempt int,
+) *domain.DeliveryResult {
+	log := common.LoggerCtx(ctx)
+	log.Info().Msgf("sending notification: %v", notification)
+	result := s.notifier.Deliver(ctx, notification)
+	result.Attempt = attempt
+	if result.Delivered {
+		common.NotificationsSent.WithLabelValues(result.Channel, common.MetricStatusOK).Inc()
+		log.Info().Msgf("notification of event %s sent via %s", result.EventID, result.Channel)
+	} else {
+		common.NotificationsSent.WithLabelValues(result.Channel, common.MetricStatusError).Inc()
+		log.Error().Msgf("failed to send notification of event %s: %s", result.EventID, result.Error)
+	}
+	data, err := json.Marshal(result)
+	if common.IsErr(err) {
+		log.Error().Msgf("failed to marshal delivery result: %v", err)
+		return result
+	}
+	err = s.producer.Publish(ctx, EventResultQueueName, data)
+	if common.IsErr(err) {
+		log.Error().Msgf("failed to publish result in queue: %v", err)
+	}
+	return result
 }
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// FreeBusyService answers free/busy queries across users and proposes meeting slots.
+type FreeBusyService struct {
+	events    domain.EventRepository
+	calendars domain.CalendarRepository
+}
+
+// NewFreeBusyService returns a new instance of the free/busy service.
+func NewFreeBusyService(events domain.EventRepository, calendars domain.CalendarRepository) *FreeBusyService {
+	return &FreeBusyService{events: events, calendars: calendars}
+}
+
+// Query returns busy periods of the users within the period in the order of the users. The user sees busy
+// periods of own events and events of calendars shared with the user with the free/busy access at least,
+// events of other calendars are left out.
+func (s *FreeBusyService) Query(
+	ctx context.Context, userID int64, userIDs []int64, startTime, endTime time.Time,
+) (result []*domain.FreeBusy, err error) {
+	ctx, span := common.Tracer.Start(ctx, "FreeBusyService.Query")
+	defer func() { common.EndSpan(span, err) }()
+	if err := domain.ValidateFreeBusyPeriod(startTime, endTime); common.IsErr(err) {
+		return nil, err
+	}
+	visible := make(map[string]bool)
+	result = make([]*domain.FreeBusy, len(userIDs))
+	for i, id := range userIDs {
+		events, err := s.events.GetOverlappingEvents(ctx, id, startTime, endTime)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		var busy []*domain.Event
+		for _, e := range events {
+			ok, err := s.visible(ctx, userID, e, visible)
+			if common.IsErr(err) {
+				return nil, err
+			}
+			if ok {
+				busy = append(busy, e)
+			}
+		}
+		result[i] = &domain.FreeBusy{UserID: id, Busy: domain.BusyPeriods(busy, startTime, endTime)}
+	}
+	return result, nil
+}
+
+// visible reports whether the user may see the busy period of the event, accesses of calendars
+// are cached in calendars.
+func (s *FreeBusyService) visible(
+	ctx context.Context, userID int64, event *domain.Event, calendars map[string]bool,
+) (bool, error) {
+	if event.UserID == userID {
+		return true, nil
+	}
+	if event.CalendarID == "" {
+		return false, nil
+	}
+	if ok, found := calendars[event.CalendarID]; found {
+		return ok, nil
+	}
+	calendar, err := s.calendars.Get(ctx, userID, event.CalendarID)
+	if errors.Is(err, domain.ErrPermission) || errors.Is(err, domain.ErrCalendarNotExist) {
+		calendars[event.CalendarID] = false
+		return false, nil
+	}
+	if common.IsErr(err) {
+		return false, err
+	}
+	calendars[event.CalendarID] = calendar.Access.Allows(domain.CalendarAccessFreeBusy)
+	return calendars[event.CalendarID], nil
+}
+
+// FindMeetingSlots returns free slots of the query for a meeting of the user with the query users,
+// busy periods the user can't see are ignored as in Query.
+func (s *FreeBusyService) FindMeetingSlots(
+	ctx context.Context, userID int64, query *domain.MeetingQuery,
+) (slots []domain.Period, err error) {
+	ctx, span := common.Tracer.Start(ctx, "FreeBusyService.FindMeetingSlots")
+	defer func() { common.EndSpan(span, err) }()
+	if err := query.Validate(); common.IsErr(err) {
+		return nil, err
+	}
+	userIDs := []int64{userID}
+	for _, id := range query.UserIDs {
+		if id != userID {
+			userIDs = append(userIDs, id)
+		}
+	}
+	freeBusy, err := s.Query(ctx, userID, userIDs, query.StartTime, query.EndTime)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	var busy []domain.Period
+	for _, fb := range freeBusy {
+		busy = append(busy, fb.Busy...)
+	}
+	return query.Hours.FreeSlots(
+		domain.MergePeriods(busy), query.StartTime, query.EndTime, query.Duration, query.Limit,
+	), nil
+}
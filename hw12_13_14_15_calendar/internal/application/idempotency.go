@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// IdempotencyService runs write requests once per request ID of the user, retries get the stored response.
+type IdempotencyService struct {
+	repository domain.IdempotencyRepository
+	ttl        time.Duration
+}
+
+// NewIdempotencyService returns a new instance of the idempotency service keeping responses for ttl.
+func NewIdempotencyService(repository domain.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repository: repository, ttl: ttl}
+}
+
+// Do calls fn for the request unless it's already completed and stores the response of fn for retries.
+// It returns ErrRequestIDReused if the request ID is used by another request and ErrRequestInProgress
+// if the request is being processed. Failed requests aren't stored, so they can be retried.
+func (s *IdempotencyService) Do(
+	ctx context.Context, request *domain.IdempotentRequest, fn func(ctx context.Context) ([]byte, error),
+) (response []byte, err error) {
+	ctx, span := common.Tracer.Start(ctx, "IdempotencyService.Do")
+	defer func() { common.EndSpan(span, err) }()
+	record, err := s.repository.Reserve(ctx, request, s.ttl)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if record != nil {
+		if record.Hash != request.Hash {
+			return nil, domain.ErrRequestIDReused
+		}
+		if !record.Completed {
+			return nil, domain.ErrRequestInProgress
+		}
+		return record.Response, nil
+	}
+	response, err = fn(ctx)
+	if common.IsErr(err) {
+		// The reservation is released even if the request was canceled.
+		if releaseErr := s.repository.Release(context.WithoutCancel(ctx), request); common.IsErr(releaseErr) {
+			common.Logger.Error().Msgf("failed to release request %s: %v", request.RequestID, releaseErr)
+		}
+		return nil, err
+	}
+	// The change is already made, so the request stays reserved if the response isn't stored,
+	// its retries are rejected as in progress instead of repeating the change.
+	if err := s.repository.Complete(context.WithoutCancel(ctx), request, response, s.ttl); common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to store response of request %s: %v", request.RequestID, err)
+	}
+	return response, nil
+}
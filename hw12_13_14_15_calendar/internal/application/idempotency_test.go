@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyService_Do(t *testing.T) {
+	request := domain.NewIdempotentRequest(7, "request-1", "CreateEvent", []byte("payload"))
+
+	t.Run("new request", func(t *testing.T) {
+		repo := new(mocks.IdempotencyRepository)
+		repo.On("Reserve", mock.Anything, request, time.Hour).Return(nil, nil)
+		repo.On("Complete", mock.Anything, request, []byte("response"), time.Hour).Return(nil)
+
+		response, err := NewIdempotencyService(repo, time.Hour).Do(
+			context.Background(), request, func(context.Context) ([]byte, error) { return []byte("response"), nil },
+		)
+		require.NoError(t, err)
+		require.Equal(t, []byte("response"), response)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		repo := new(mocks.IdempotencyRepository)
+		repo.On("Reserve", mock.Anything, request, time.Hour).Return(nil, nil)
+		repo.On("Release", mock.Anything, request).Return(nil)
+
+		_, err := NewIdempotencyService(repo, time.Hour).Do(
+			context.Background(), request, func(context.Context) ([]byte, error) { return nil, domain.ErrDateBusy },
+		)
+		require.ErrorIs(t, err, domain.ErrDateBusy)
+		repo.AssertExpectations(t)
+	})
+
+	for _, tc := range []struct {
+		name     string
+		record   *domain.IdempotencyRecord
+		response []byte
+		err      error
+	}{
+		{
+			name:     "completed request",
+			record:   &domain.IdempotencyRecord{Hash: request.Hash, Completed: true, Response: []byte("response")},
+			response: []byte("response"),
+		},
+		{
+			name:   "request in progress",
+			record: &domain.IdempotencyRecord{Hash: request.Hash},
+			err:    domain.ErrRequestInProgress,
+		},
+		{
+			name:   "reused request ID",
+			record: &domain.IdempotencyRecord{Hash: "other", Completed: true, Response: []byte("response")},
+			err:    domain.ErrRequestIDReused,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mocks.IdempotencyRepository)
+			repo.On("Reserve", mock.Anything, request, time.Hour).Return(tc.record, nil)
+
+			response, err := NewIdempotencyService(repo, time.Hour).Do(
+				context.Background(), request, func(context.Context) ([]byte, error) {
+					return nil, errors.New("request must not be called")
+				},
+			)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.response, response)
+			repo.AssertExpectations(t)
+		})
+	}
+}
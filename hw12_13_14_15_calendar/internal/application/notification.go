@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// NotificationService delivers notifications through the channel of the event or the user.
+type NotificationService struct {
+	targets  domain.NotificationTargetRepository
+	channels map[string]domain.NotificationChannel
+}
+
+// NewNotificationService returns a new instance of the notification service.
+func NewNotificationService(
+	targets domain.NotificationTargetRepository,
+	channels ...domain.NotificationChannel,
+) *NotificationService {
+	s := &NotificationService{targets: targets, channels: make(map[string]domain.NotificationChannel)}
+	for _, channel := range channels {
+		s.channels[channel.Name()] = channel
+	}
+	return s
+}
+
+// GetUserTarget returns the notification target of the user.
+func (s *NotificationService) GetUserTarget(ctx context.Context, userID int64) (*domain.NotificationTarget, error) {
+	return s.targets.Get(ctx, userID)
+}
+
+// SetUserTarget sets the notification target of the user.
+func (s *NotificationService) SetUserTarget(
+	ctx context.Context, userID int64, target *domain.NotificationTarget,
+) error {
+	if err := target.Validate(); common.IsErr(err) {
+		return err
+	}
+	return s.targets.Set(ctx, userID, target)
+}
+
+// target returns the target of the notification: the event one, the user one or the default channel.
+func (s *NotificationService) target(ctx context.Context, n *domain.Notification) (*domain.NotificationTarget, error) {
+	if n.Target != nil {
+		return n.Target, nil
+	}
+	target, err := s.targets.Get(ctx, n.UserToSend)
+	if errors.Is(err, domain.ErrNotificationTargetNotExist) {
+		return &domain.NotificationTarget{Channel: common.Config.Notification.DefaultChannel}, nil
+	}
+	return target, err
+}
+
+// Deliver sends the notification and returns the delivery result.
+func (s *NotificationService) Deliver(ctx context.Context, n *domain.Notification) *domain.DeliveryResult {
+	ctx, span := common.Tracer.Start(ctx, "NotificationService.Deliver")
+	result := &domain.DeliveryResult{EventID: n.EventID, UserID: n.UserToSend, EventDate: n.EventDate}
+	err := s.deliver(ctx, n, result)
+	span.SetAttributes(attribute.String("notification.channel", result.Channel))
+	common.EndSpan(span, err)
+	result.Time = time.Now().UTC()
+	if common.IsErr(err) {
+		result.Error = err.Error()
+	} else {
+		result.Delivered = true
+	}
+	return result
+}
+
+func (s *NotificationService) deliver(
+	ctx context.Context,
+	n *domain.Notification,
+	result *domain.DeliveryResult,
+) error {
+	target, err := s.target(ctx, n)
+	if common.IsErr(err) {
+		return err
+	}
+	result.Channel, result.Address = target.Channel, target.Address
+	channel, ok := s.channels[target.Channel]
+	if !ok {
+		return fmt.Errorf("%w: channel %q is not enabled", domain.ErrNotificationTarget, target.Channel)
+	}
+	return channel.Send(ctx, n, target.Address)
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockChannel(name string) *mocks.NotificationChannel {
+	channel := new(mocks.NotificationChannel)
+	channel.On("Name").Return(name)
+	return channel
+}
+
+func TestNotificationService_Deliver(t *testing.T) {
+	email := newMockChannel(domain.ChannelEmail)
+	webhook := newMockChannel(domain.ChannelWebhook)
+	log := newMockChannel(domain.ChannelLog)
+	targets := new(mocks.NotificationTargetRepository)
+	s := NewNotificationService(targets, email, webhook, log)
+
+	eventTarget := &domain.NotificationTarget{Channel: domain.ChannelWebhook, Address: "https://example.com/hook"}
+	userTarget := &domain.NotificationTarget{Channel: domain.ChannelEmail, Address: "user@example.com"}
+	targets.On("Get", mock.Anything, int64(1)).Return(userTarget, nil)
+	targets.On("Get", mock.Anything, int64(2)).Return(nil, domain.ErrNotificationTargetNotExist)
+
+	byEvent := &domain.Notification{EventID: "1", UserToSend: 1, Target: eventTarget}
+	webhook.On("Send", mock.Anything, byEvent, eventTarget.Address).Return(nil)
+	result := s.Deliver(context.Background(), byEvent)
+	require.True(t, result.Delivered)
+	require.Equal(t, domain.ChannelWebhook, result.Channel)
+
+	byUser := &domain.Notification{EventID: "2", UserToSend: 1}
+	email.On("Send", mock.Anything, byUser, userTarget.Address).Return(errors.New("mailbox unavailable"))
+	result = s.Deliver(context.Background(), byUser)
+	require.False(t, result.Delivered)
+	require.Equal(t, domain.ChannelEmail, result.Channel)
+	require.Equal(t, userTarget.Address, result.Address)
+	require.Equal(t, "mailbox unavailable", result.Error)
+
+	byDefault := &domain.Notification{EventID: "3", UserToSend: 2}
+	log.On("Send", mock.Anything, byDefault, "").Return(nil)
+	result = s.Deliver(context.Background(), byDefault)
+	require.True(t, result.Delivered)
+	require.Equal(t, domain.ChannelLog, result.Channel)
+
+	email.AssertExpectations(t)
+	webhook.AssertExpectations(t)
+	log.AssertExpectations(t)
+}
+
+func TestNotificationService_DeliverDisabledChannel(t *testing.T) {
+	s := NewNotificationService(new(mocks.NotificationTargetRepository), newMockChannel(domain.ChannelLog))
+	n := &domain.Notification{Target: &domain.NotificationTarget{Channel: domain.ChannelEmail, Address: "a@b.c"}}
+	result := s.Deliver(context.Background(), n)
+	require.False(t, result.Delivered)
+	require.Contains(t, result.Error, "not enabled")
+}
+
+func TestEventSenderProcessor_HandleDelivery(t *testing.T) {
+	channel := newMockChannel(domain.ChannelLog)
+	targets := new(mocks.NotificationTargetRepository)
+	targets.On("Get", mock.Anything, mock.Anything).Return(nil, domain.ErrNotificationTargetNotExist)
+	producer := new(mocks.EventProducer)
+	producer.On("Publish", mock.Anything, EventResultQueueName, mock.Anything).Return(nil)
+	s := NewEventSenderProcessor(nil, NewNotificationService(targets, channel), nil, producer)
+	sent := common.NotificationsSent.WithLabelValues(domain.ChannelLog, common.MetricStatusOK)
+	notSent := common.NotificationsSent.WithLabelValues(domain.ChannelLog, common.MetricStatusError)
+	sentBefore, notSentBefore := testutil.ToFloat64(sent), testutil.ToFloat64(notSent)
+
+	delivered := new(mocks.Delivery)
+	delivered.On("Context", mock.Anything).Return(context.Background())
+	delivered.On("Body").Return([]byte(`[{"EventID":"1","UserToSend":1}]`))
+	delivered.On("Timestamp").Return(time.Now().Add(-time.Second))
+	delivered.On("Attempt").Return(1)
+	delivered.On("Ack").Return(nil)
+	channel.On("Send", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.EventID == "1"
+	}), "").Return(nil)
+	s.handleDelivery(context.Background(), delivered)
+	delivered.AssertExpectations(t)
+
+	failed := new(mocks.Delivery)
+	failed.On("Context", mock.Anything).Return(context.Background())
+	failed.On("Body").Return([]byte(`[{"EventID":"2","UserToSend":1}]`))
+	failed.On("Timestamp").Return(time.Time{})
+	failed.On("Attempt").Return(2)
+	failed.On("Retry", errors.New("disk is full")).Return(nil)
+	channel.On("Send", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.EventID == "2"
+	}), "").Return(errors.New("disk is full"))
+	s.handleDelivery(context.Background(), failed)
+	failed.AssertExpectations(t)
+
+	invalid := new(mocks.Delivery)
+	invalid.On("Context", mock.Anything).Return(context.Background())
+	invalid.On("Body").Return([]byte(`{`))
+	invalid.On("Timestamp").Return(time.Time{})
+	invalid.On("Reject", mock.Anything).Return(nil)
+	s.handleDelivery(context.Background(), invalid)
+	invalid.AssertExpectations(t)
+
+	producer.AssertNumberOfCalls(t, "Publish", 2)
+	require.Equal(t, sentBefore+1, testutil.ToFloat64(sent))
+	require.Equal(t, notSentBefore+1, testutil.ToFloat64(notSent))
+	require.Equal(t, 1, testutil.CollectAndCount(common.ConsumerLag))
+}
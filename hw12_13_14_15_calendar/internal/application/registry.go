@@ -1,20 +1,35 @@
 package application
 
 import (
+	"time"
+
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
-	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
 )
 
 // EventApplicationService instance of the event service.
 var EventApplicationService *EventService
 
+// CalendarApplicationService instance of the calendar service.
+var CalendarApplicationService *CalendarService
+
+// FreeBusyApplicationService instance of the free/busy service of meeting scheduling.
+var FreeBusyApplicationService *FreeBusyService
+
+// NotificationApplicationService instance of the notification service managing notification targets.
+var NotificationApplicationService *NotificationService
+
+// IdempotencyApplicationService instance of the idempotency service of write requests.
+var IdempotencyApplicationService *IdempotencyService
+
 func init() {
-	var eventRepository domain.EventRepository
-	if common.Config.UseCacheDB {
-		eventRepository = repository.NewEventCacheRepository()
-	} else {
-		eventRepository = repository.NewEventDBRepository()
-	}
-	EventApplicationService = NewEventService(eventRepository)
+	eventRepository := repository.GetEventRepository()
+	calendarRepository := repository.GetCalendarRepository()
+	EventApplicationService = NewEventService(eventRepository, calendarRepository)
+	CalendarApplicationService = NewCalendarService(calendarRepository)
+	FreeBusyApplicationService = NewFreeBusyService(eventRepository, calendarRepository)
+	NotificationApplicationService = NewNotificationService(repository.GetNotificationTargetRepository())
+	IdempotencyApplicationService = NewIdempotencyService(
+		repository.GetIdempotencyRepository(), time.Duration(common.Config.Server.IdempotencyTTL)*time.Second,
+	)
 }
@@ -0,0 +1,17 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_InstrumentedEventRepository(t *testing.T) {
+	instrumented := reflect.TypeOf(repository.GetEventRepository())
+	require.NotEqual(t, reflect.TypeOf(repository.NewEventDBRepository()), instrumented)
+	require.NotEqual(t, reflect.TypeOf(repository.NewEventCacheRepository()), instrumented)
+	require.Equal(t, instrumented, reflect.TypeOf(EventApplicationService.repository))
+	require.Equal(t, instrumented, reflect.TypeOf(FreeBusyApplicationService.events))
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
@@ -8,51 +9,308 @@ import (
 	"github.com/google/uuid"
 )
 
+// defaultPageSize is a page size of event listings without a page size.
+const defaultPageSize = 100
+
+// overlapCheckPeriod limits the overlap check of infinite recurring events.
+const overlapCheckPeriod = 366 * 24 * time.Hour
+
 type EventService struct {
 	repository domain.EventRepository
+	calendars  domain.CalendarRepository
 }
 
 // NewEventService returns a new instance of the event service.
-func NewEventService(repository domain.EventRepository) *EventService {
-	return &EventService{repository: repository}
+func NewEventService(repository domain.EventRepository, calendars domain.CalendarRepository) *EventService {
+	return &EventService{repository: repository, calendars: calendars}
 }
 
-// Get returns an event by its id.
-func (s *EventService) Get(id string) (*domain.Event, error) {
+// Get returns an event of the user by its id.
+func (s *EventService) Get(ctx context.Context, userID int64, id string) (event *domain.Event, err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.Get")
+	defer func() { common.EndSpan(span, err) }()
 	if err := s.validateID(id); err != nil {
 		return nil, err
 	}
-	return s.repository.Get(id)
+	return s.repository.Get(ctx, userID, id)
 }
 
-// Create creates a new event.
-func (s *EventService) Create(event *domain.Event) error {
+// Create creates a new event in a calendar the user may write to, the default calendar of the user without
+// a calendar. An event without a time zone takes the zone of the given calendar, UTC otherwise. Overlapping
+// events are rejected unless allowOverlap is set.
+func (s *EventService) Create(ctx context.Context, userID int64, event *domain.Event, allowOverlap bool) (err error) {
+	ctx, span := common.Tracer.Start(domain.WithActor(ctx, userID), "EventService.Create")
+	defer func() { common.EndSpan(span, err) }()
+	ownerID := userID
+	if event.CalendarID != "" {
+		calendar, err := s.writableCalendar(ctx, userID, event.CalendarID)
+		if common.IsErr(err) {
+			return err
+		}
+		ownerID = calendar.UserID
+		if event.TimeZone == "" {
+			event.TimeZone = calendar.TimeZone
+		}
+	}
+	if err := s.setOwner(userID, ownerID, event); common.IsErr(err) {
+		return err
+	}
 	event.ID = event.NewUUID()
+	// All-day events are aligned to days of their time zone before overlapping events are checked.
+	event.NormalizeTime()
 	if err := event.Validate(); common.IsErr(err) {
 		return err
 	}
-	return s.repository.Add(event)
+	if !allowOverlap {
+		if err := s.checkOverlap(ctx, event); common.IsErr(err) {
+			return err
+		}
+	}
+	return s.repository.Add(ctx, event)
 }
 
-// Update updates an existing event.
-func (s *EventService) Update(event *domain.Event) error {
+// Update updates an existing event the user may write to, the event may be moved to another calendar
+// of the same owner. An event without a time zone keeps the current zone. Overlapping events are rejected
+// unless allowOverlap is set.
+func (s *EventService) Update(ctx context.Context, userID int64, event *domain.Event, allowOverlap bool) (err error) {
+	ctx, span := common.Tracer.Start(domain.WithActor(ctx, userID), "EventService.Update")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(event.ID); err != nil {
+		return err
+	}
+	current, err := s.repository.Get(ctx, userID, event.ID)
+	if common.IsErr(err) {
+		return err
+	}
+	if event.CalendarID == "" {
+		event.CalendarID = current.CalendarID
+	}
+	if event.CalendarID != current.CalendarID {
+		calendar, err := s.writableCalendar(ctx, userID, event.CalendarID)
+		if common.IsErr(err) {
+			return err
+		}
+		if calendar.UserID != current.UserID {
+			return domain.ErrPermission
+		}
+	}
+	if err := s.setOwner(userID, current.UserID, event); common.IsErr(err) {
+		return err
+	}
+	if event.TimeZone == "" {
+		event.TimeZone = current.TimeZone
+	}
+	event.NormalizeTime()
 	if err := event.Validate(); common.IsErr(err) {
 		return err
 	}
-	return s.repository.Update(event)
+	if !allowOverlap {
+		if err := s.checkOverlap(ctx, event); common.IsErr(err) {
+			return err
+		}
+	}
+	return s.repository.Update(ctx, userID, event)
+}
+
+// writableCalendar returns a calendar the user may write events to.
+func (s *EventService) writableCalendar(ctx context.Context, userID int64, id string) (*domain.Calendar, error) {
+	calendar, err := s.calendars.Get(ctx, userID, id)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if !calendar.Access.Allows(domain.CalendarAccessWrite) {
+		return nil, domain.ErrPermission
+	}
+	return calendar, nil
+}
+
+// checkOverlap returns ErrDateBusy if the event (any of its occurrences) overlaps another event of the owner.
+func (s *EventService) checkOverlap(ctx context.Context, event *domain.Event) error {
+	occurrences := []*domain.Event{event}
+	endTime := event.StartTime
+	if event.EndTime != nil {
+		endTime = *event.EndTime
+	}
+	if event.Recurrence != nil {
+		if end := event.RecurrenceEnd(); end != nil {
+			endTime = end.Add(endTime.Sub(event.StartTime))
+		} else {
+			endTime = event.StartTime.Add(overlapCheckPeriod)
+		}
+		occurrences = event.OccurrencesByRange(event.StartTime, endTime)
+	}
+	events, err := s.repository.GetOverlappingEvents(ctx, event.UserID, event.StartTime, endTime)
+	if common.IsErr(err) {
+		return err
+	}
+	for _, e := range events {
+		if e.ID == event.ID {
+			continue
+		}
+		for _, o := range occurrences {
+			if o.Overlaps(e) {
+				return domain.ErrDateBusy
+			}
+		}
+	}
+	return nil
 }
 
-// Delete removes an event by ID.
-func (s *EventService) Delete(id string) error {
+// Delete moves an event of the user to the trash or removes it irrecoverably if permanent is set.
+func (s *EventService) Delete(ctx context.Context, userID int64, id string, permanent bool) (err error) {
+	ctx, span := common.Tracer.Start(domain.WithActor(ctx, userID), "EventService.Delete")
+	defer func() { common.EndSpan(span, err) }()
 	if err := s.validateID(id); err != nil {
 		return err
 	}
-	return s.repository.Delete(id)
+	if permanent {
+		return s.repository.DeletePermanently(ctx, userID, id)
+	}
+	return s.repository.Delete(ctx, userID, id)
+}
+
+// Restore restores an event of the user from the trash.
+func (s *EventService) Restore(ctx context.Context, userID int64, id string) (event *domain.Event, err error) {
+	ctx, span := common.Tracer.Start(domain.WithActor(ctx, userID), "EventService.Restore")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+	return s.repository.Restore(ctx, userID, id)
+}
+
+// Invite invites the users to an event of the user.
+func (s *EventService) Invite(
+	ctx context.Context, userID int64, id string, userIDs []int64,
+) (event *domain.Event, err error) {
+	ctx, span := common.Tracer.Start(domain.WithActor(ctx, userID), "EventService.Invite")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+	return s.repository.InviteAttendees(ctx, userID, id, userIDs)
 }
 
-// ListByPeriod returns a list of events for a period.
-func (s *EventService) ListByPeriod(startTime, endTime time.Time) ([]*domain.Event, error) {
-	return s.repository.GetEventsByPeriod(startTime, endTime)
+// Respond sets the response of the user to an event the user is invited to.
+func (s *EventService) Respond(
+	ctx context.Context, userID int64, id string, status domain.AttendeeStatus,
+) (event *domain.Event, err error) {
+	ctx, span := common.Tracer.Start(domain.WithActor(ctx, userID), "EventService.Respond")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+	return s.repository.RespondInvitation(ctx, userID, id, status)
+}
+
+// ListInvitations returns a list of events the user is invited to, all of them if the status is empty.
+func (s *EventService) ListInvitations(
+	ctx context.Context, userID int64, status domain.AttendeeStatus,
+) (events []*domain.Event, err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.ListInvitations")
+	defer func() { common.EndSpan(span, err) }()
+	return s.repository.GetInvitations(ctx, userID, status)
+}
+
+// History returns changes of an event of the user in the order they were made.
+func (s *EventService) History(
+	ctx context.Context, userID int64, id string,
+) (changes []*domain.EventChange, err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.History")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.validateID(id); err != nil {
+		return nil, err
+	}
+	return s.repository.GetHistory(ctx, userID, id)
+}
+
+// ListDeleted returns a list of the user events in the trash.
+func (s *EventService) ListDeleted(ctx context.Context, userID int64) (events []*domain.Event, err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.ListDeleted")
+	defer func() { common.EndSpan(span, err) }()
+	return s.repository.GetDeletedEvents(ctx, userID)
+}
+
+// ListByPeriod returns a list of the user events for a period.
+func (s *EventService) ListByPeriod(
+	ctx context.Context, userID int64, startTime, endTime time.Time,
+) (events []*domain.Event, err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.ListByPeriod")
+	defer func() { common.EndSpan(span, err) }()
+	return s.repository.GetEventsByPeriod(ctx, userID, startTime, endTime)
+}
+
+// ListPage returns a page of events matching the filter, the filter is limited to the user events.
+func (s *EventService) ListPage(
+	ctx context.Context, userID int64, filter *domain.EventFilter,
+) (page *domain.EventPage, err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.ListPage")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.setFilterUser(userID, filter); common.IsErr(err) {
+		return nil, err
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+	return s.repository.GetEventsPage(ctx, filter)
+}
+
+// Stream calls fn for each of the events matching the filter without loading all of them,
+// the filter is limited to the user events. Streaming stops on the context cancellation or an error of fn.
+func (s *EventService) Stream(
+	ctx context.Context,
+	userID int64,
+	filter *domain.EventFilter,
+	fn func(event *domain.Event) error,
+) (err error) {
+	ctx, span := common.Tracer.Start(ctx, "EventService.Stream")
+	defer func() { common.EndSpan(span, err) }()
+	if err := s.setFilterUser(userID, filter); common.IsErr(err) {
+		return err
+	}
+	return s.repository.IterateEvents(ctx, filter, fn)
+}
+
+// ListByDay returns a list of events for the day of the date, boundaries are computed in the date location.
+func (s *EventService) ListByDay(ctx context.Context, userID int64, date time.Time) ([]*domain.Event, error) {
+	start := startOfDay(date)
+	return s.ListByPeriod(ctx, userID, start, start.AddDate(0, 0, 1))
+}
+
+// ListByWeek returns a list of events for the week (starting on Monday) of the date.
+func (s *EventService) ListByWeek(ctx context.Context, userID int64, date time.Time) ([]*domain.Event, error) {
+	start := startOfDay(date)
+	start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	return s.ListByPeriod(ctx, userID, start, start.AddDate(0, 0, 7))
+}
+
+// ListByMonth returns a list of events for the month of the date.
+func (s *EventService) ListByMonth(ctx context.Context, userID int64, date time.Time) ([]*domain.Event, error) {
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	return s.ListByPeriod(ctx, userID, start, start.AddDate(0, 1, 0))
+}
+
+// startOfDay returns the local midnight of the date, AddDate keeps it aligned across DST transitions.
+func startOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// setOwner sets the calendar owner as the event owner, the event may name the user or the owner only.
+func (s *EventService) setOwner(userID, ownerID int64, event *domain.Event) error {
+	if event.UserID != 0 && event.UserID != userID && event.UserID != ownerID {
+		return domain.ErrPermission
+	}
+	event.UserID = ownerID
+	return nil
+}
+
+// setFilterUser limits the filter to the events visible to the user, the owner filter may name the user only.
+func (s *EventService) setFilterUser(userID int64, filter *domain.EventFilter) error {
+	if filter.OwnerID != 0 && filter.OwnerID != userID {
+		return domain.ErrPermission
+	}
+	filter.UserID = userID
+	return nil
 }
 
 func (s *EventService) validateID(id string) error {
@@ -16,6 +16,10 @@ type DBConfig struct {
 	Host     string `mapstructure:"HOST"`
 	Port     int    `mapstructure:"PORT"`
 	SSLMode  string `mapstructure:"SSL_MODE"`
+	// QueryTimeout of repository operations, operations aren't limited if it's 0.
+	QueryTimeout int `mapstructure:"QUERY_TIMEOUT_SECOND"`
+	// StreamTimeout of streaming repository operations which read events while they are sent to the client.
+	StreamTimeout int `mapstructure:"STREAM_TIMEOUT_SECOND"`
 }
 
 // ServerConfig server config.
@@ -31,27 +35,90 @@ type ServerConfig struct {
 	ShutdownTimeout   int    `mapstructure:"SHUTDOWN_TIMEOUT_SECOND"`
 	ReadHeaderTimeout int    `mapstructure:"READ_HEADER_TIMEOUT_SECOND"`
 	ReadTimeout       int    `mapstructure:"READ_TIMEOUT_SECOND"`
+	// IdempotencyTTL is a period results of write requests are kept for their retries with the same request ID.
+	IdempotencyTTL int `mapstructure:"IDEMPOTENCY_TTL_SECOND"`
 }
 
 type SchedulerConfig struct {
-	EventLifetime     int `mapstructure:"EVENT_LIFETIME_SECOND"`
-	PublishPeriodTime int `mapstructure:"PUBLISH_PERIOD_TIME_SECOND"`
+	EventLifetime       int `mapstructure:"EVENT_LIFETIME_SECOND"`
+	DeletedRetention    int `mapstructure:"DELETED_RETENTION_SECOND"`
+	PublishPeriodTime   int `mapstructure:"PUBLISH_PERIOD_TIME_SECOND"`
+	OutboxBatchSize     int `mapstructure:"OUTBOX_BATCH_SIZE"`
+	OutboxRetryDelay    int `mapstructure:"OUTBOX_RETRY_DELAY_SECOND"`
+	OutboxMaxRetryDelay int `mapstructure:"OUTBOX_MAX_RETRY_DELAY_SECOND"`
+	OutboxMaxAttempts   int `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
 }
 
 type RabbitConfig struct {
-	Host     string `mapstructure:"HOST"`
-	Port     int    `mapstructure:"PORT"`
-	Username string `mapstructure:"USERNAME"`
-	Password string `mapstructure:"PASSWORD"`
+	Host          string `mapstructure:"HOST"`
+	Port          int    `mapstructure:"PORT"`
+	Username      string `mapstructure:"USERNAME"`
+	Password      string `mapstructure:"PASSWORD"`
+	PrefetchCount int    `mapstructure:"PREFETCH_COUNT"`
+}
+
+// BrokerConfig message broker config.
+type BrokerConfig struct {
+	// Type is rabbitmq or memory, the memory broker is shared by components running in a single process.
+	Type string `mapstructure:"TYPE"`
+	// FilePath of the memory broker log, messages of the memory broker aren't durable if empty.
+	FilePath string `mapstructure:"FILE_PATH"`
+	// MaxAttempts of message handling before the message is moved to the dead-letter queue.
+	MaxAttempts int `mapstructure:"MAX_ATTEMPTS"`
+	RetryDelay  int `mapstructure:"RETRY_DELAY_SECOND"`
+}
+
+// NotificationConfig notification delivery channels config.
+type NotificationConfig struct {
+	DefaultChannel string `mapstructure:"DEFAULT_CHANNEL"`
+	// FilePath of the log channel, notifications are written to stdout if empty.
+	FilePath     string `mapstructure:"FILE_PATH"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	// WebhookSecret is a key of webhook request signatures, the webhook channel is disabled if empty.
+	WebhookSecret  string `mapstructure:"WEBHOOK_SECRET"`
+	WebhookTimeout int    `mapstructure:"WEBHOOK_TIMEOUT_SECOND"`
+}
+
+// MetricsConfig metrics exporters config of the scheduler and the sender,
+// the calendar service exports metrics on its HTTP server.
+type MetricsConfig struct {
+	Host          string `mapstructure:"HOST"`
+	SchedulerPort int    `mapstructure:"SCHEDULER_PORT"`
+	SenderPort    int    `mapstructure:"SENDER_PORT"`
+}
+
+// TracingConfig tracing config.
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp.
+	Exporter    string  `mapstructure:"EXPORTER"`
+	Endpoint    string  `mapstructure:"OTLP_ENDPOINT"`
+	Insecure    bool    `mapstructure:"OTLP_INSECURE"`
+	SampleRatio float64 `mapstructure:"SAMPLE_RATIO"`
+}
+
+// HealthConfig readiness checks config.
+type HealthConfig struct {
+	CheckTimeout int `mapstructure:"CHECK_TIMEOUT_SECOND"`
+	// CheckInterval is a period of the grpc health status updates.
+	CheckInterval int `mapstructure:"CHECK_INTERVAL_SECOND"`
 }
 
 // AppConfig app config.
 type AppConfig struct {
-	Server     ServerConfig    `mapstructure:"APP"`
-	Scheduler  SchedulerConfig `mapstructure:"SCHEDULER"`
-	DB         DBConfig        `mapstructure:"DB"`
-	RabbitMQ   RabbitConfig    `mapstructure:"RABBITMQ"`
-	UseCacheDB bool            `mapstructure:"USE_CACHE_DB"`
+	Server       ServerConfig       `mapstructure:"APP"`
+	Scheduler    SchedulerConfig    `mapstructure:"SCHEDULER"`
+	DB           DBConfig           `mapstructure:"DB"`
+	RabbitMQ     RabbitConfig       `mapstructure:"RABBITMQ"`
+	Broker       BrokerConfig       `mapstructure:"BROKER"`
+	Notification NotificationConfig `mapstructure:"NOTIFICATION"`
+	Metrics      MetricsConfig      `mapstructure:"METRICS"`
+	Tracing      TracingConfig      `mapstructure:"TRACING"`
+	Health       HealthConfig       `mapstructure:"HEALTH"`
+	UseCacheDB   bool               `mapstructure:"USE_CACHE_DB"`
 }
 
 // Config project config.
@@ -66,6 +133,8 @@ func setDefaults() {
 	viper.SetDefault("DB.HOST", "127.0.0.1")
 	viper.SetDefault("DB.PORT", 5455)
 	viper.SetDefault("DB.SSL_MODE", "disable")
+	viper.SetDefault("DB.QUERY_TIMEOUT_SECOND", 5)
+	viper.SetDefault("DB.STREAM_TIMEOUT_SECOND", 300)
 
 	viper.SetDefault("APP.HOST", "127.0.0.1")
 	viper.SetDefault("APP.PORT", 8080)
@@ -78,14 +147,48 @@ func setDefaults() {
 	viper.SetDefault("APP.SHUTDOWN_TIMEOUT_SECOND", 30)
 	viper.SetDefault("APP.READ_HEADER_TIMEOUT_SECOND", 10)
 	viper.SetDefault("APP.READ_TIMEOUT_SECOND", 10)
+	viper.SetDefault("APP.IDEMPOTENCY_TTL_SECOND", 60*60*24)
 
 	viper.SetDefault("RABBITMQ.HOST", "127.0.0.1")
 	viper.SetDefault("RABBITMQ.PORT", 5675)
 	viper.SetDefault("RABBITMQ.USERNAME", "admin")
 	viper.SetDefault("RABBITMQ.PASSWORD", "password")
+	viper.SetDefault("RABBITMQ.PREFETCH_COUNT", 10)
+
+	viper.SetDefault("BROKER.TYPE", "rabbitmq")
+	viper.SetDefault("BROKER.FILE_PATH", "")
+	viper.SetDefault("BROKER.MAX_ATTEMPTS", 5)
+	viper.SetDefault("BROKER.RETRY_DELAY_SECOND", 30)
 
 	viper.SetDefault("SCHEDULER.EVENT_LIFETIME_SECOND", 60*60*24*365)
+	viper.SetDefault("SCHEDULER.DELETED_RETENTION_SECOND", 60*60*24*30)
 	viper.SetDefault("SCHEDULER.PUBLISH_PERIOD_TIME_SECOND", 10)
+	viper.SetDefault("SCHEDULER.OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("SCHEDULER.OUTBOX_RETRY_DELAY_SECOND", 10)
+	viper.SetDefault("SCHEDULER.OUTBOX_MAX_RETRY_DELAY_SECOND", 60*60)
+	viper.SetDefault("SCHEDULER.OUTBOX_MAX_ATTEMPTS", 10)
+
+	viper.SetDefault("NOTIFICATION.DEFAULT_CHANNEL", "log")
+	viper.SetDefault("NOTIFICATION.FILE_PATH", "")
+	viper.SetDefault("NOTIFICATION.SMTP_HOST", "")
+	viper.SetDefault("NOTIFICATION.SMTP_PORT", 587)
+	viper.SetDefault("NOTIFICATION.SMTP_USERNAME", "")
+	viper.SetDefault("NOTIFICATION.SMTP_PASSWORD", "")
+	viper.SetDefault("NOTIFICATION.SMTP_FROM", "calendar@localhost")
+	viper.SetDefault("NOTIFICATION.WEBHOOK_SECRET", "")
+	viper.SetDefault("NOTIFICATION.WEBHOOK_TIMEOUT_SECOND", 10)
+
+	viper.SetDefault("METRICS.HOST", "127.0.0.1")
+	viper.SetDefault("METRICS.SCHEDULER_PORT", 9101)
+	viper.SetDefault("METRICS.SENDER_PORT", 9102)
+
+	viper.SetDefault("TRACING.EXPORTER", "none")
+	viper.SetDefault("TRACING.OTLP_ENDPOINT", "127.0.0.1:4317")
+	viper.SetDefault("TRACING.OTLP_INSECURE", true)
+	viper.SetDefault("TRACING.SAMPLE_RATIO", 1.0)
+
+	viper.SetDefault("HEALTH.CHECK_TIMEOUT_SECOND", 2)
+	viper.SetDefault("HEALTH.CHECK_INTERVAL_SECOND", 5)
 }
 
 func init() {
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "calendar"
+
+// Metric label values of operation results.
+const (
+	MetricStatusOK    = "ok"
+	MetricStatusError = "error"
+)
+
+// MetricsRegistry is a registry of the project metrics(singleton), components running in a single process
+// share it.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var metrics = promauto.With(MetricsRegistry)
+
+var (
+	// RPCDuration is a latency of grpc calls by method and status code.
+	RPCDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of grpc calls by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// RepositoryQueryDuration is a latency of repository calls by repository, operation and status.
+	RepositoryQueryDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "repository",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of repository calls by repository, operation and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"repository", "operation", "status"})
+
+	// EventsScheduled is a number of notifications claimed from the outbox by the scheduler.
+	EventsScheduled = metrics.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "scheduler",
+		Name:      "events_scheduled_total",
+		Help:      "Number of notifications claimed from the outbox.",
+	})
+
+	// EventsPublished is a number of notifications published to the queue by the scheduler.
+	EventsPublished = metrics.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "scheduler",
+		Name:      "events_published_total",
+		Help:      "Number of notifications published to the queue.",
+	})
+
+	// EventsFailed is a number of notifications the scheduler failed to publish.
+	EventsFailed = metrics.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "scheduler",
+		Name:      "events_failed_total",
+		Help:      "Number of notifications failed to publish.",
+	})
+
+	// NotificationsSent is a number of notification deliveries by channel and status.
+	NotificationsSent = metrics.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "sender",
+		Name:      "notifications_total",
+		Help:      "Number of notification deliveries by channel and status.",
+	}, []string{"channel", "status"})
+
+	// ConsumerLag is a time between publishing and consuming of queue messages.
+	ConsumerLag = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "queue",
+		Name:      "consumer_lag_seconds",
+		Help:      "Time between publishing and consuming of queue messages.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600},
+	}, []string{"queue"})
+
+	// RabbitReconnects is a number of reconnections of rabbitmq clients.
+	RabbitReconnects = metrics.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "rabbitmq",
+		Name:      "reconnects_total",
+		Help:      "Number of reconnections to rabbitmq.",
+	})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// MetricsHandler returns the HTTP handler of the project metrics in the prometheus format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{})
+}
+
+// MetricStatus returns the status label value of the operation result.
+func MetricStatus(err error) string {
+	if IsErr(err) {
+		return MetricStatusError
+	}
+	return MetricStatusOK
+}
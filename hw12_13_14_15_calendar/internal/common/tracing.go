@@ -0,0 +1,73 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/tracing"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is a main tracer for the project(singleton), spans are exported after InitTracing.
+var Tracer = otel.Tracer("github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar")
+
+// InitTracing initializes tracing of the service from the config,
+// it returns the function which flushes spans waiting up to the shutdown timeout.
+func InitTracing(ctx context.Context, serviceName string) (func(), error) {
+	shutdown, err := tracing.Init(ctx, tracing.Config{
+		ServiceName: serviceName,
+		Exporter:    Config.Tracing.Exporter,
+		Endpoint:    Config.Tracing.Endpoint,
+		Insecure:    Config.Tracing.Insecure,
+		SampleRatio: Config.Tracing.SampleRatio,
+	})
+	if IsErr(err) {
+		return nil, err
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(Config.Server.ShutdownTimeout)*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); IsErr(err) {
+			Logger.Error().Msgf("failed to flush spans: %v", err)
+		}
+	}, nil
+}
+
+// LoggerCtx returns the main logger with the trace ID of the context span.
+func LoggerCtx(ctx context.Context) *zerolog.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return Logger
+	}
+	log := Logger.With().Str("trace_id", spanContext.TraceID().String()).Logger()
+	return &log
+}
+
+// TraceCarrier returns the trace context of the context span to store it with data processed later,
+// it returns nil if there is no span.
+func TraceCarrier(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// ContextWithTrace returns a copy of the context continuing the trace of the carrier.
+func ContextWithTrace(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// EndSpan records the error of the operation in the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if IsErr(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
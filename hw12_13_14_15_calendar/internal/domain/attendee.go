@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AttendeeStatus is a response of an attendee to the event invitation.
+type AttendeeStatus string
+
+const (
+	AttendeeInvited   AttendeeStatus = "invited"
+	AttendeeAccepted  AttendeeStatus = "accepted"
+	AttendeeDeclined  AttendeeStatus = "declined"
+	AttendeeTentative AttendeeStatus = "tentative"
+)
+
+// Attendee is a user invited to the event by its owner.
+type Attendee struct {
+	UserID int64
+	Status AttendeeStatus
+}
+
+// Attendee returns the attendee of the user, nil if the user isn't invited.
+func (e *Event) Attendee(userID int64) *Attendee {
+	for _, a := range e.Attendees {
+		if a.UserID == userID {
+			return a
+		}
+	}
+	return nil
+}
+
+// CanView reports whether the user owns the event or is invited to it.
+func (e *Event) CanView(userID int64) bool {
+	return e.UserID == userID || e.Attendee(userID) != nil
+}
+
+// Attends reports whether the event is in the calendar of the user: the user owns the event
+// or is invited to it and hasn't declined.
+func (e *Event) Attends(userID int64) bool {
+	if e.UserID == userID {
+		return true
+	}
+	a := e.Attendee(userID)
+	return a != nil && a.Status != AttendeeDeclined
+}
+
+// Invite adds the users to the attendees of the event and returns the added ones,
+// attendees which are already invited keep their response.
+func (e *Event) Invite(userIDs []int64) ([]*Attendee, error) {
+	var added []*Attendee
+	for _, userID := range userIDs {
+		if userID <= 0 || userID == e.UserID {
+			return nil, fmt.Errorf("%w: user %d can't be invited", ErrAttendee, userID)
+		}
+		if e.Attendee(userID) != nil {
+			continue
+		}
+		a := &Attendee{UserID: userID, Status: AttendeeInvited}
+		e.Attendees = append(e.Attendees, a)
+		added = append(added, a)
+	}
+	sort.Slice(e.Attendees, func(i, j int) bool {
+		return e.Attendees[i].UserID < e.Attendees[j].UserID
+	})
+	return added, nil
+}
+
+// Respond sets the response of the attendee, ErrPermission is returned if the user isn't invited.
+func (e *Event) Respond(userID int64, status AttendeeStatus) (*Attendee, error) {
+	switch status {
+	case AttendeeAccepted, AttendeeDeclined, AttendeeTentative:
+	default:
+		return nil, fmt.Errorf("%w: unknown response %q", ErrAttendee, status)
+	}
+	a := e.Attendee(userID)
+	if a == nil {
+		return nil, ErrPermission
+	}
+	a.Status = status
+	return a, nil
+}
+
+// Copy returns a copy of the event which reminders and attendees can be changed without changing the event.
+func (e *Event) Copy() *Event {
+	c := *e
+	if e.Reminders != nil {
+		c.Reminders = make([]*Reminder, len(e.Reminders))
+		for i, r := range e.Reminders {
+			reminder := *r
+			c.Reminders[i] = &reminder
+		}
+	}
+	if e.Attendees != nil {
+		c.Attendees = make([]*Attendee, len(e.Attendees))
+		for i, a := range e.Attendees {
+			attendee := *a
+			c.Attendees[i] = &attendee
+		}
+	}
+	return &c
+}
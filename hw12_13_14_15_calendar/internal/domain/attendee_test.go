@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventInvite(t *testing.T) {
+	e := &Event{UserID: 1, Attendees: []*Attendee{{UserID: 3, Status: AttendeeDeclined}}}
+
+	added, err := e.Invite([]int64{4, 2, 3, 2})
+	require.NoError(t, err)
+	require.Equal(t, []*Attendee{{UserID: 4, Status: AttendeeInvited}, {UserID: 2, Status: AttendeeInvited}}, added)
+	require.Equal(
+		t,
+		[]*Attendee{
+			{UserID: 2, Status: AttendeeInvited},
+			{UserID: 3, Status: AttendeeDeclined},
+			{UserID: 4, Status: AttendeeInvited},
+		},
+		e.Attendees,
+	)
+	for _, userID := range []int64{0, 1} {
+		_, err = e.Invite([]int64{userID})
+		require.ErrorIs(t, err, ErrAttendee)
+	}
+}
+
+func TestEventRespond(t *testing.T) {
+	e := &Event{UserID: 1, Attendees: []*Attendee{{UserID: 2, Status: AttendeeInvited}}}
+	c := e.Copy()
+
+	a, err := c.Respond(2, AttendeeDeclined)
+	require.NoError(t, err)
+	require.Equal(t, &Attendee{UserID: 2, Status: AttendeeDeclined}, a)
+	require.Equal(t, AttendeeInvited, e.Attendees[0].Status)
+	require.True(t, e.Attends(2))
+	require.False(t, c.Attends(2))
+	require.True(t, c.CanView(2))
+	require.True(t, c.Attends(1))
+	require.False(t, c.CanView(3))
+
+	_, err = c.Respond(3, AttendeeAccepted)
+	require.ErrorIs(t, err, ErrPermission)
+	_, err = c.Respond(2, AttendeeInvited)
+	require.ErrorIs(t, err, ErrAttendee)
+}
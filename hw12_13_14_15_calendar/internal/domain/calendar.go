@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCalendarName is a name of the calendar created for events added without a calendar.
+const DefaultCalendarName = "Default"
+
+// calendarNameMaxLength limits calendar names in characters.
+const calendarNameMaxLength = 255
+
+// calendarColor matches hex RGB colors like #1a73e8.
+var calendarColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// CalendarAccess is an access of a user to a calendar, accesses include the lower ones.
+type CalendarAccess string
+
+const (
+	// CalendarAccessFreeBusy allows to see busy periods of the calendar events without their details.
+	CalendarAccessFreeBusy CalendarAccess = "free_busy"
+	// CalendarAccessRead allows to read the calendar events.
+	CalendarAccessRead CalendarAccess = "read"
+	// CalendarAccessWrite allows to create, change and delete the calendar events.
+	CalendarAccessWrite CalendarAccess = "write"
+	// CalendarAccessOwner is an access of the calendar owner, it can't be granted.
+	CalendarAccessOwner CalendarAccess = "owner"
+)
+
+// calendarAccessLevels orders the accesses, an unknown or empty access allows nothing.
+var calendarAccessLevels = map[CalendarAccess]int{
+	CalendarAccessFreeBusy: 1,
+	CalendarAccessRead:     2,
+	CalendarAccessWrite:    3,
+	CalendarAccessOwner:    4,
+}
+
+// Allows reports whether the access includes the required one.
+func (a CalendarAccess) Allows(required CalendarAccess) bool {
+	level := calendarAccessLevels[a]
+	return level > 0 && level >= calendarAccessLevels[required]
+}
+
+// Calendar entity, events of a calendar are owned by the calendar owner.
+type Calendar struct {
+	ID     string
+	UserID int64
+	Name   string
+	// Color is a hex RGB color like #1a73e8, it may be empty.
+	Color string
+	// TimeZone is an IANA time zone of the calendar.
+	TimeZone string
+	// Default calendar of the user keeps events added without a calendar, it can't be deleted.
+	Default     bool
+	CreatedTime *time.Time
+	// Access is an access of the user the calendar is got for.
+	Access CalendarAccess
+}
+
+// NewDefaultCalendar returns a new default calendar of the user.
+func NewDefaultCalendar(userID int64) *Calendar {
+	return &Calendar{
+		ID:       uuid.New().String(),
+		UserID:   userID,
+		Name:     DefaultCalendarName,
+		TimeZone: time.UTC.String(),
+		Default:  true,
+		Access:   CalendarAccessOwner,
+	}
+}
+
+func (c *Calendar) Validate() error {
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return ErrUUID
+	}
+	if c.Name == "" || utf8.RuneCountInString(c.Name) > calendarNameMaxLength {
+		return fmt.Errorf("%w: name must have 1 to %d characters", ErrCalendar, calendarNameMaxLength)
+	}
+	if c.Color != "" && !calendarColor.MatchString(c.Color) {
+		return fmt.Errorf("%w: color must be a hex RGB color", ErrCalendar)
+	}
+	if _, err := time.LoadLocation(c.TimeZone); c.TimeZone == "" || err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrCalendar, c.TimeZone)
+	}
+	return nil
+}
+
+// CalendarShare is an access to a calendar granted to a user by the owner.
+type CalendarShare struct {
+	UserID int64
+	Access CalendarAccess
+}
+
+// ValidateShare returns ErrCalendarShare for a share to the owner or a share of an access which can't be granted.
+func (c *Calendar) ValidateShare(share *CalendarShare) error {
+	if share.UserID <= 0 || share.UserID == c.UserID {
+		return fmt.Errorf("%w: calendar can't be shared with user %d", ErrCalendarShare, share.UserID)
+	}
+	switch share.Access {
+	case CalendarAccessFreeBusy, CalendarAccessRead, CalendarAccessWrite:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown access %q", ErrCalendarShare, share.Access)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarAccessAllows(t *testing.T) {
+	require.True(t, CalendarAccessOwner.Allows(CalendarAccessWrite))
+	require.True(t, CalendarAccessWrite.Allows(CalendarAccessRead))
+	require.True(t, CalendarAccessRead.Allows(CalendarAccessRead))
+	require.False(t, CalendarAccessRead.Allows(CalendarAccessWrite))
+	require.False(t, CalendarAccessFreeBusy.Allows(CalendarAccessRead))
+	require.True(t, CalendarAccessFreeBusy.Allows(CalendarAccessFreeBusy))
+	require.False(t, CalendarAccess("").Allows(CalendarAccessFreeBusy))
+	require.False(t, CalendarAccess("admin").Allows(CalendarAccessFreeBusy))
+}
+
+func TestCalendarValidate(t *testing.T) {
+	calendar := NewDefaultCalendar(1)
+	require.NoError(t, calendar.Validate())
+	calendar.Color = "#1A73e8"
+	calendar.TimeZone = "Europe/Moscow"
+	require.NoError(t, calendar.Validate())
+
+	for _, change := range []func(c *Calendar){
+		func(c *Calendar) { c.Name = "" },
+		func(c *Calendar) { c.Color = "red" },
+		func(c *Calendar) { c.Color = "#1a73e" },
+		func(c *Calendar) { c.TimeZone = "" },
+		func(c *Calendar) { c.TimeZone = "Mars/Olympus" },
+	} {
+		c := *calendar
+		change(&c)
+		require.ErrorIs(t, c.Validate(), ErrCalendar)
+	}
+	calendar.ID = "invalid"
+	require.ErrorIs(t, calendar.Validate(), ErrUUID)
+}
+
+func TestCalendarValidateShare(t *testing.T) {
+	calendar := NewDefaultCalendar(1)
+	require.NoError(t, calendar.ValidateShare(&CalendarShare{UserID: 2, Access: CalendarAccessFreeBusy}))
+	require.NoError(t, calendar.ValidateShare(&CalendarShare{UserID: 2, Access: CalendarAccessWrite}))
+	require.ErrorIs(t, calendar.ValidateShare(&CalendarShare{UserID: 1, Access: CalendarAccessRead}), ErrCalendarShare)
+	require.ErrorIs(t, calendar.ValidateShare(&CalendarShare{UserID: 0, Access: CalendarAccessRead}), ErrCalendarShare)
+	require.ErrorIs(t, calendar.ValidateShare(&CalendarShare{UserID: 2, Access: CalendarAccessOwner}), ErrCalendarShare)
+	require.ErrorIs(t, calendar.ValidateShare(&CalendarShare{UserID: 2}), ErrCalendarShare)
+}
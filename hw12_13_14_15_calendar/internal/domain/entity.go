@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,15 +13,41 @@ type Event struct {
 	Title       string
 	StartTime   time.Time
 	EndTime     *time.Time
-	NotifyTime  *time.Time
 	Description string
 	UserID      int64
 	CreatedTime *time.Time
+	// TimeZone is an IANA time zone of the event, occurrences of recurring events and days of all-day events
+	// are computed in the zone.
+	TimeZone string
+	// AllDay events take whole days of the event time zone from the midnight of the start time
+	// to the midnight of the end time.
+	AllDay bool
+	// CalendarID is a calendar of the event, the event is owned by the calendar owner.
+	CalendarID string
+	Recurrence *Recurrence
+	// RecurrenceID is a start time of the occurrence for expanded recurring events.
+	RecurrenceID *time.Time
+	// NotificationTarget overrides the notification target of the user for the event.
+	NotificationTarget *NotificationTarget
+	// DeletedTime is a time the event was moved to the trash, deleted events can be restored until they're purged.
+	DeletedTime *time.Time
+	// Version is incremented on every change of the event, updates of another version are rejected.
+	Version int64
+	// Reminders are notifications of the event sorted by their time.
+	Reminders []*Reminder `json:",omitempty"`
+	// Attendees are users invited to the event by the owner, sorted by the user ID.
+	Attendees []*Attendee `json:",omitempty"`
 }
 
-// NormalizeTime set UTC and truncates time to milliseconds.
+// NormalizeTime set UTC and truncates time to milliseconds, all-day events are aligned to days of their time zone.
 func (e *Event) NormalizeTime() {
 	const truncateTime = time.Millisecond
+	if e.TimeZone == "" {
+		e.TimeZone = time.UTC.String()
+	}
+	if e.AllDay {
+		e.alignDays()
+	}
 	if e.CreatedTime != nil {
 		createdTime := e.CreatedTime.UTC().Truncate(truncateTime)
 		e.CreatedTime = &createdTime
@@ -30,10 +57,50 @@ func (e *Event) NormalizeTime() {
 		t := e.EndTime.UTC().Truncate(truncateTime)
 		e.EndTime = &t
 	}
-	if e.NotifyTime != nil {
-		t := e.NotifyTime.UTC().Truncate(truncateTime)
-		e.NotifyTime = &t
+	if e.RecurrenceID != nil {
+		t := e.RecurrenceID.UTC().Truncate(truncateTime)
+		e.RecurrenceID = &t
+	}
+	if e.DeletedTime != nil {
+		t := e.DeletedTime.UTC().Truncate(truncateTime)
+		e.DeletedTime = &t
+	}
+	e.normalizeReminders(truncateTime)
+	if e.Recurrence != nil {
+		if e.Recurrence.Until != nil {
+			t := e.Recurrence.Until.UTC().Truncate(truncateTime)
+			e.Recurrence.Until = &t
+		}
+		for i, t := range e.Recurrence.Exceptions {
+			e.Recurrence.Exceptions[i] = t.UTC().Truncate(truncateTime)
+		}
+	}
+}
+
+// end returns the event end time, an event without end time takes a moment of its start time.
+func (e *Event) end() time.Time {
+	if e.EndTime == nil {
+		return e.StartTime
+	}
+	return *e.EndTime
+}
+
+// overlapsPeriod reports whether the event overlaps the period [startTime, endTime),
+// a moment (empty period) overlaps periods containing it.
+func (e *Event) overlapsPeriod(startTime, endTime time.Time) bool {
+	end := e.end()
+	if !end.After(e.StartTime) {
+		end = e.StartTime.Add(time.Nanosecond)
+	}
+	if !endTime.After(startTime) {
+		endTime = startTime.Add(time.Nanosecond)
 	}
+	return e.StartTime.Before(endTime) && end.After(startTime)
+}
+
+// Overlaps reports whether the event overlaps the other one, back-to-back events don't overlap.
+func (e *Event) Overlaps(other *Event) bool {
+	return e.overlapsPeriod(other.StartTime, other.end())
 }
 
 func (e *Event) NewUUID() string {
@@ -44,12 +111,29 @@ func (e *Event) Validate() error {
 	if e.EndTime != nil && e.StartTime.After(*e.EndTime) {
 		return ErrEndTime
 	}
-	if e.NotifyTime != nil && e.StartTime.After(*e.NotifyTime) {
-		return ErrNotifyTime
-	}
 	if _, err := uuid.Parse(e.ID); err != nil {
 		return ErrUUID
 	}
+	if _, err := uuid.Parse(e.CalendarID); e.CalendarID != "" && err != nil {
+		return ErrUUID
+	}
+	if err := e.validateTimeZone(); err != nil {
+		return err
+	}
+	if err := e.validateReminders(); err != nil {
+		return err
+	}
+	if e.Recurrence != nil {
+		if err := e.Recurrence.Validate(); err != nil {
+			return err
+		}
+		if e.Recurrence.Until != nil && e.StartTime.After(*e.Recurrence.Until) {
+			return fmt.Errorf("%w: until must be greater than start time", ErrRecurrenceRule)
+		}
+	}
+	if e.NotificationTarget != nil {
+		return e.NotificationTarget.Validate()
+	}
 	return nil
 }
 
@@ -58,5 +142,10 @@ type Notification struct {
 	EventID    string
 	EventTitle string
 	EventDate  time.Time
+	// TimeZone and AllDay of the event are used to present the event date.
+	TimeZone   string `json:",omitempty"`
+	AllDay     bool   `json:",omitempty"`
 	UserToSend int64
+	// Target is a notification target of the event, the user target is used if nil.
+	Target *NotificationTarget `json:",omitempty"`
 }
@@ -3,10 +3,41 @@ package domain
 import "errors"
 
 var (
-	ErrEventExist    = errors.New("event already exists")
-	ErrEventNotExist = errors.New("event doesn't exist")
-	ErrEventCreate   = errors.New("event creation failed")
-	ErrEndTime       = errors.New("end time must be greater than start time")
-	ErrNotifyTime    = errors.New("notify time must be greater than start time")
-	ErrUUID          = errors.New("invalid UUID")
+	ErrEventExist     = errors.New("event already exists")
+	ErrEventNotExist  = errors.New("event doesn't exist")
+	ErrEventCreate    = errors.New("event creation failed")
+	ErrEndTime        = errors.New("end time must be greater than start time")
+	ErrUUID           = errors.New("invalid UUID")
+	ErrRecurrenceRule = errors.New("invalid recurrence rule")
+	ErrPermission     = errors.New("permission denied")
+	ErrDateBusy       = errors.New("event overlaps another event of the user")
+	ErrPageToken      = errors.New("invalid page token")
+	// ErrTimeZone is returned for an unknown time zone of an event.
+	ErrTimeZone = errors.New("invalid time zone")
+	// ErrReminder is returned for too many, duplicate or invalid reminders of an event.
+	ErrReminder = errors.New("invalid reminder")
+	// ErrVersionConflict is returned for an update of an event version which was changed by another request.
+	ErrVersionConflict = errors.New("event version doesn't match the current one")
+	// ErrAttendee is returned for an invitation of the event owner or an unknown response of an attendee.
+	ErrAttendee = errors.New("invalid attendee")
+	// ErrCalendar is returned for an invalid name, color or time zone of a calendar.
+	ErrCalendar         = errors.New("invalid calendar")
+	ErrCalendarNotExist = errors.New("calendar doesn't exist")
+	// ErrCalendarShare is returned for a share of a calendar to its owner or a share of an unknown access.
+	ErrCalendarShare = errors.New("invalid calendar share")
+	// ErrCalendarNotEmpty is returned for a deletion of a calendar having events, including the ones in the trash.
+	ErrCalendarNotEmpty = errors.New("calendar has events")
+	// ErrDefaultCalendar is returned for a deletion of the default calendar of the user.
+	ErrDefaultCalendar = errors.New("default calendar can't be deleted")
+	// ErrFreeBusyPeriod is returned for an empty or too long period of a free/busy query.
+	ErrFreeBusyPeriod = errors.New("invalid free/busy period")
+	// ErrWorkingHours is returned for working hours which aren't a period of a day or have an unknown time zone.
+	ErrWorkingHours = errors.New("invalid working hours")
+	// ErrNotificationTarget is returned for an unknown channel or an invalid address of a notification target.
+	ErrNotificationTarget         = errors.New("invalid notification target")
+	ErrNotificationTargetNotExist = errors.New("notification target doesn't exist")
+	// ErrRequestIDReused is returned for a request ID used by another request of the user.
+	ErrRequestIDReused = errors.New("request ID is already used by another request")
+	// ErrRequestInProgress is returned for a retry of a request which isn't completed yet.
+	ErrRequestInProgress = errors.New("request with the same ID is in progress")
 )
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventCursor is a keyset pagination position, events are ordered by (StartTime, ID).
+type EventCursor struct {
+	StartTime time.Time
+	ID        string
+}
+
+// Token returns an opaque page token of the cursor.
+func (c *EventCursor) Token() string {
+	value := strconv.FormatInt(c.StartTime.UnixNano(), 10) + "," + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// ParseEventCursor parses a page token returned by EventCursor.Token.
+func ParseEventCursor(token string) (*EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrPageToken
+	}
+	nanos, id, ok := strings.Cut(string(data), ",")
+	if !ok || id == "" {
+		return nil, ErrPageToken
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrPageToken
+	}
+	return &EventCursor{StartTime: time.Unix(0, n).UTC(), ID: id}, nil
+}
+
+// EventFilter is a filter of the user events listing for a period.
+type EventFilter struct {
+	UserID    int64
+	StartTime time.Time
+	EndTime   time.Time
+	// OwnerID limits the listing to events owned by the user, other events visible to the user are skipped.
+	OwnerID int64
+	// CalendarID limits the listing to events of the calendar.
+	CalendarID string
+	// Title is a case-insensitive substring of the event title.
+	Title string
+	// HasNotification filters events with (or without) reminders.
+	HasNotification *bool
+	Descending      bool
+	PageSize        int
+	// Cursor is a position of the last event of the previous page.
+	Cursor *EventCursor
+	// Series returns recurring events having occurrences in the period as is instead of the occurrences.
+	Series bool
+}
+
+// EventPage is a page of the events listing.
+type EventPage struct {
+	Events []*Event
+	// NextCursor is nil for the last page.
+	NextCursor *EventCursor
+}
+
+// Match reports whether the event matches the filter fields except the user and the period,
+// events visible to the user are selected by repositories.
+func (f *EventFilter) Match(e *Event) bool {
+	if f.OwnerID != 0 && e.UserID != f.OwnerID {
+		return false
+	}
+	if f.CalendarID != "" && e.CalendarID != f.CalendarID {
+		return false
+	}
+	if f.Title != "" && !strings.Contains(strings.ToLower(e.Title), strings.ToLower(f.Title)) {
+		return false
+	}
+	if f.HasNotification != nil && *f.HasNotification != (len(e.Reminders) > 0) {
+		return false
+	}
+	return true
+}
+
+// before reports whether the event a goes before the event b in the filter order.
+func (f *EventFilter) before(a, b *EventCursor) bool {
+	less := a.StartTime.Before(b.StartTime) || (a.StartTime.Equal(b.StartTime) && a.ID < b.ID)
+	if f.Descending {
+		return !less && (a.ID != b.ID || !a.StartTime.Equal(b.StartTime))
+	}
+	return less
+}
+
+// Page sorts the events and returns the page after the filter cursor.
+func (f *EventFilter) Page(events []*Event) *EventPage {
+	cursor := func(e *Event) *EventCursor {
+		return &EventCursor{StartTime: e.StartTime, ID: e.ID}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return f.before(cursor(events[i]), cursor(events[j]))
+	})
+	if f.Cursor != nil {
+		i := sort.Search(len(events), func(i int) bool {
+			return f.before(f.Cursor, cursor(events[i]))
+		})
+		events = events[i:]
+	}
+	page := &EventPage{Events: events}
+	if f.PageSize > 0 && len(events) > f.PageSize {
+		page.Events = events[:f.PageSize]
+		page.NextCursor = cursor(page.Events[f.PageSize-1])
+	}
+	return page
+}
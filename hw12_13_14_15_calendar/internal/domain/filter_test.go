@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventCursorToken(t *testing.T) {
+	cursor := &EventCursor{StartTime: date(1, 10), ID: "6f0cbb0e-9b37-4bd5-a1a4-2f8e3ec62c47"}
+	parsed, err := ParseEventCursor(cursor.Token())
+	require.NoError(t, err)
+	require.Equal(t, cursor, parsed)
+
+	for _, token := range []string{"", "!", "MTIz", "eCxpZA"} {
+		_, err := ParseEventCursor(token)
+		require.ErrorIs(t, err, ErrPageToken, token)
+	}
+}
+
+func TestEventFilterMatch(t *testing.T) {
+	e := &Event{Title: "Team Meeting", UserID: 1, CalendarID: "work", Reminders: []*Reminder{{Offset: -time.Hour}}}
+	yes, no := true, false
+	require.True(t, (&EventFilter{UserID: 1, Title: "meet", HasNotification: &yes}).Match(e))
+	require.True(t, (&EventFilter{UserID: 1, CalendarID: "work"}).Match(e))
+	require.False(t, (&EventFilter{UserID: 1, CalendarID: "home"}).Match(e))
+	require.False(t, (&EventFilter{UserID: 1, Title: "lunch"}).Match(e))
+	require.False(t, (&EventFilter{UserID: 1, HasNotification: &no}).Match(e))
+}
+
+func TestEventFilterPage(t *testing.T) {
+	events := func() []*Event {
+		return []*Event{
+			{ID: "c", StartTime: date(2, 10)},
+			{ID: "b", StartTime: date(1, 10)},
+			{ID: "a", StartTime: date(1, 10)},
+			{ID: "d", StartTime: date(3, 10)},
+		}
+	}
+	ids := func(events []*Event) []string {
+		result := make([]string, len(events))
+		for i, e := range events {
+			result[i] = e.ID
+		}
+		return result
+	}
+	cases := []struct {
+		name       string
+		descending bool
+		expected   [][]string
+	}{
+		{name: "ascending", expected: [][]string{{"a", "b"}, {"c", "d"}}},
+		{name: "descending", descending: true, expected: [][]string{{"d", "c"}, {"b", "a"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			filter := &EventFilter{Descending: c.descending, PageSize: 2}
+			var pages [][]string
+			for {
+				page := filter.Page(events())
+				pages = append(pages, ids(page.Events))
+				if page.NextCursor == nil {
+					break
+				}
+				filter.Cursor = page.NextCursor
+			}
+			require.Equal(t, c.expected, pages)
+		})
+	}
+	page := (&EventFilter{Cursor: &EventCursor{StartTime: date(1, 10).Add(time.Hour)}}).Page(events())
+	require.Equal(t, []string{"c", "d"}, ids(page.Events))
+}
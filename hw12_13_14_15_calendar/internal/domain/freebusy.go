@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FreeBusyMaxPeriod limits periods of free/busy queries.
+const FreeBusyMaxPeriod = 62 * 24 * time.Hour
+
+// meetingSlotStep aligns start times of proposed meeting slots.
+const meetingSlotStep = 15 * time.Minute
+
+// Period is a period of time [StartTime, EndTime).
+type Period struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// ValidateFreeBusyPeriod returns ErrFreeBusyPeriod for an empty period or a period longer than FreeBusyMaxPeriod.
+func ValidateFreeBusyPeriod(startTime, endTime time.Time) error {
+	if !endTime.After(startTime) {
+		return fmt.Errorf("%w: end time must be greater than start time", ErrFreeBusyPeriod)
+	}
+	if endTime.Sub(startTime) > FreeBusyMaxPeriod {
+		return fmt.Errorf("%w: period must not be longer than %v", ErrFreeBusyPeriod, FreeBusyMaxPeriod)
+	}
+	return nil
+}
+
+// FreeBusy is busy periods of the user sorted by start time, they don't overlap each other.
+type FreeBusy struct {
+	UserID int64
+	Busy   []Period
+}
+
+// BusyPeriods returns merged periods of the events within the period [startTime, endTime),
+// events without duration don't take time.
+func BusyPeriods(events []*Event, startTime, endTime time.Time) []Period {
+	periods := make([]Period, 0, len(events))
+	for _, e := range events {
+		p := Period{StartTime: e.StartTime, EndTime: e.end()}
+		if p.StartTime.Before(startTime) {
+			p.StartTime = startTime
+		}
+		if p.EndTime.After(endTime) {
+			p.EndTime = endTime
+		}
+		if p.EndTime.After(p.StartTime) {
+			periods = append(periods, p)
+		}
+	}
+	return MergePeriods(periods)
+}
+
+// MergePeriods returns the periods sorted by start time with overlapping and adjacent ones merged.
+func MergePeriods(periods []Period) []Period {
+	sorted := make([]Period, len(periods))
+	copy(sorted, periods)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartTime.Before(sorted[j].StartTime) })
+	merged := make([]Period, 0, len(sorted))
+	for _, p := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && !p.StartTime.After(merged[last].EndTime) {
+			if p.EndTime.After(merged[last].EndTime) {
+				merged[last].EndTime = p.EndTime
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+// MeetingQuery is a query of free slots for a meeting of the users.
+type MeetingQuery struct {
+	UserIDs   []int64
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	Hours     WorkingHours
+	// Limit is a maximum number of the slots.
+	Limit int
+}
+
+// Validate returns ErrFreeBusyPeriod or ErrWorkingHours for an invalid query.
+func (q *MeetingQuery) Validate() error {
+	if err := ValidateFreeBusyPeriod(q.StartTime, q.EndTime); err != nil {
+		return err
+	}
+	if q.Duration <= 0 || q.Limit <= 0 {
+		return fmt.Errorf("%w: duration and limit must be positive", ErrFreeBusyPeriod)
+	}
+	return q.Hours.Validate()
+}
+
+// WorkingHours is a daily period of working time in a time zone.
+type WorkingHours struct {
+	// Start and End are offsets of the wall clock time from midnight.
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+	// Weekends allows Saturdays and Sundays to be working days.
+	Weekends bool
+}
+
+// Validate returns ErrWorkingHours unless the working hours are a non-empty period of a day.
+func (h *WorkingHours) Validate() error {
+	if h.Location == nil {
+		return fmt.Errorf("%w: time zone must be set", ErrWorkingHours)
+	}
+	if h.Start < 0 || h.End > 24*time.Hour || h.End <= h.Start {
+		return fmt.Errorf("%w: end must be greater than start within a day", ErrWorkingHours)
+	}
+	return nil
+}
+
+// dayOff reports whether the date is a day off.
+func (h *WorkingHours) dayOff(date time.Time) bool {
+	return !h.Weekends && (date.Weekday() == time.Saturday || date.Weekday() == time.Sunday)
+}
+
+// day returns the working period of the day of the date.
+func (h *WorkingHours) day(date time.Time) Period {
+	year, month, day := date.Date()
+	// Minutes of the wall clock keep working hours on days of a daylight saving time change.
+	return Period{
+		StartTime: time.Date(year, month, day, 0, int(h.Start/time.Minute), 0, 0, h.Location),
+		EndTime:   time.Date(year, month, day, 0, int(h.End/time.Minute), 0, 0, h.Location),
+	}
+}
+
+// FreeSlots returns up to limit slots of the duration within the working hours of the period [startTime, endTime),
+// which don't overlap the busy periods sorted by start time. Slots start at multiples of 15 minutes
+// and follow each other in free periods.
+func (h *WorkingHours) FreeSlots(
+	busy []Period, startTime, endTime time.Time, duration time.Duration, limit int,
+) []Period {
+	var slots []Period
+	date := startTime.In(h.Location)
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, h.Location)
+	for ; date.Before(endTime) && len(slots) < limit; date = date.AddDate(0, 0, 1) {
+		if h.dayOff(date) {
+			continue
+		}
+		working := h.day(date)
+		if working.StartTime.Before(startTime) {
+			working.StartTime = startTime
+		}
+		if working.EndTime.After(endTime) {
+			working.EndTime = endTime
+		}
+		t := alignSlot(working.StartTime)
+		for len(slots) < limit && !t.Add(duration).After(working.EndTime) {
+			for len(busy) > 0 && !busy[0].EndTime.After(t) {
+				busy = busy[1:]
+			}
+			if len(busy) > 0 && busy[0].StartTime.Before(t.Add(duration)) {
+				t = alignSlot(busy[0].EndTime)
+				continue
+			}
+			slots = append(slots, Period{StartTime: t, EndTime: t.Add(duration)})
+			t = t.Add(duration)
+		}
+	}
+	return slots
+}
+
+// alignSlot rounds the time up to a multiple of meetingSlotStep.
+func alignSlot(t time.Time) time.Time {
+	return t.Add(meetingSlotStep - 1).Truncate(meetingSlotStep)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func period(start, end string) Period {
+	return Period{StartTime: parseTime(start), EndTime: parseTime(end)}
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestValidateFreeBusyPeriod(t *testing.T) {
+	start := parseTime("2024-01-01T00:00:00Z")
+	require.NoError(t, ValidateFreeBusyPeriod(start, start.Add(FreeBusyMaxPeriod)))
+	require.ErrorIs(t, ValidateFreeBusyPeriod(start, start), ErrFreeBusyPeriod)
+	require.ErrorIs(t, ValidateFreeBusyPeriod(start, start.Add(-time.Hour)), ErrFreeBusyPeriod)
+	require.ErrorIs(t, ValidateFreeBusyPeriod(start, start.Add(FreeBusyMaxPeriod+time.Hour)), ErrFreeBusyPeriod)
+}
+
+func TestMergePeriods(t *testing.T) {
+	require.Equal(t, []Period{}, MergePeriods(nil))
+	require.Equal(t, []Period{
+		period("2024-01-01T09:00:00Z", "2024-01-01T11:00:00Z"),
+		period("2024-01-01T12:00:00Z", "2024-01-01T13:00:00Z"),
+	}, MergePeriods([]Period{
+		period("2024-01-01T12:00:00Z", "2024-01-01T13:00:00Z"),
+		period("2024-01-01T10:00:00Z", "2024-01-01T11:00:00Z"),
+		period("2024-01-01T09:00:00Z", "2024-01-01T10:00:00Z"),
+		period("2024-01-01T09:30:00Z", "2024-01-01T10:30:00Z"),
+	}))
+}
+
+func TestBusyPeriods(t *testing.T) {
+	event := func(start, end string) *Event {
+		e := &Event{StartTime: parseTime(start)}
+		if end != "" {
+			endTime := parseTime(end)
+			e.EndTime = &endTime
+		}
+		return e
+	}
+	busy := BusyPeriods([]*Event{
+		event("2024-01-01T08:00:00Z", "2024-01-01T09:30:00Z"),
+		event("2024-01-01T12:00:00Z", ""),
+		event("2024-01-01T13:00:00Z", "2024-01-01T14:00:00Z"),
+		event("2024-01-01T13:30:00Z", "2024-01-01T19:00:00Z"),
+	}, parseTime("2024-01-01T09:00:00Z"), parseTime("2024-01-01T18:00:00Z"))
+	require.Equal(t, []Period{
+		period("2024-01-01T09:00:00Z", "2024-01-01T09:30:00Z"),
+		period("2024-01-01T13:00:00Z", "2024-01-01T18:00:00Z"),
+	}, busy)
+}
+
+func TestWorkingHoursValidate(t *testing.T) {
+	h := WorkingHours{Start: 9 * time.Hour, End: 18 * time.Hour, Location: time.UTC}
+	require.NoError(t, h.Validate())
+	for _, change := range []func(h *WorkingHours){
+		func(h *WorkingHours) { h.Location = nil },
+		func(h *WorkingHours) { h.End = h.Start },
+		func(h *WorkingHours) { h.Start = -time.Hour },
+		func(h *WorkingHours) { h.End = 25 * time.Hour },
+	} {
+		invalid := h
+		change(&invalid)
+		require.ErrorIs(t, invalid.Validate(), ErrWorkingHours)
+	}
+}
+
+func TestWorkingHoursFreeSlots(t *testing.T) {
+	h := WorkingHours{Start: 9 * time.Hour, End: 18 * time.Hour, Location: time.UTC}
+	busy := []Period{
+		period("2024-01-05T08:00:00Z", "2024-01-05T10:10:00Z"),
+		period("2024-01-05T11:00:00Z", "2024-01-05T17:00:00Z"),
+	}
+	// 2024-01-05 is Friday, the weekend is skipped.
+	slots := h.FreeSlots(busy, parseTime("2024-01-05T00:00:00Z"), parseTime("2024-01-09T00:00:00Z"), 30*time.Minute, 5)
+	require.Equal(t, []Period{
+		period("2024-01-05T10:15:00Z", "2024-01-05T10:45:00Z"),
+		period("2024-01-05T17:00:00Z", "2024-01-05T17:30:00Z"),
+		period("2024-01-05T17:30:00Z", "2024-01-05T18:00:00Z"),
+		period("2024-01-08T09:00:00Z", "2024-01-08T09:30:00Z"),
+		period("2024-01-08T09:30:00Z", "2024-01-08T10:00:00Z"),
+	}, slots)
+
+	h.Weekends = true
+	slots = h.FreeSlots(busy, parseTime("2024-01-05T17:10:00Z"), parseTime("2024-01-07T00:00:00Z"), 8*time.Hour, 5)
+	require.Equal(t, []Period{period("2024-01-06T09:00:00Z", "2024-01-06T17:00:00Z")}, slots)
+	slots = h.FreeSlots(nil, parseTime("2024-01-06T00:00:00Z"), parseTime("2024-01-07T00:00:00Z"), 10*time.Hour, 5)
+	require.Empty(t, slots)
+}
+
+func TestWorkingHoursFreeSlotsInTimeZone(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	h := WorkingHours{Start: 9 * time.Hour, End: 17 * time.Hour, Location: location, Weekends: true}
+	// Clocks go forward on 2024-03-31 in Berlin, working hours follow the wall clock.
+	slots := h.FreeSlots(nil, parseTime("2024-03-30T00:00:00Z"), parseTime("2024-04-01T00:00:00Z"), 8*time.Hour, 5)
+	require.Equal(t, []Period{
+		period("2024-03-30T08:00:00Z", "2024-03-30T16:00:00Z"),
+		period("2024-03-31T07:00:00Z", "2024-03-31T15:00:00Z"),
+	}, utcPeriods(slots))
+}
+
+func utcPeriods(periods []Period) []Period {
+	for i, p := range periods {
+		periods[i] = Period{StartTime: p.StartTime.UTC(), EndTime: p.EndTime.UTC()}
+	}
+	return periods
+}
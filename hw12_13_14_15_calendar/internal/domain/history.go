@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Actions of event changes.
+const (
+	EventActionCreate            = "create"
+	EventActionUpdate            = "update"
+	EventActionDelete            = "delete"
+	EventActionDeletePermanently = "delete_permanently"
+	EventActionRestore           = "restore"
+	// EventActionInvite is an invitation of attendees by the owner.
+	EventActionInvite = "invite"
+	// EventActionRespond is a response of an attendee to the invitation.
+	EventActionRespond = "respond"
+)
+
+// EventChange is a record of the event change history.
+type EventChange struct {
+	ID      int64
+	EventID string
+	Action  string
+	// ActorID is an ID of the user who made the change, zero for changes made by the service itself.
+	ActorID int64
+	// RequestID is an ID of the client request which made the change.
+	RequestID string
+	// Before is the event before the change, nil for a created event.
+	Before *Event
+	// After is the event after the change, nil for a permanently deleted event.
+	After *Event
+	Time  time.Time
+}
+
+// NewEventChange returns a change of the event made by the actor and the request of the context.
+func NewEventChange(ctx context.Context, action string, before, after *Event) *EventChange {
+	change := &EventChange{
+		Action:    action,
+		ActorID:   ActorFromContext(ctx),
+		RequestID: RequestIDFromContext(ctx),
+		Before:    before,
+		After:     after,
+		Time:      time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if after != nil {
+		change.EventID = after.ID
+	} else if before != nil {
+		change.EventID = before.ID
+	}
+	return change
+}
+
+// OwnerID returns an ID of the owner of the changed event.
+func (c *EventChange) OwnerID() int64 {
+	if c.After != nil {
+		return c.After.UserID
+	}
+	if c.Before != nil {
+		return c.Before.UserID
+	}
+	return 0
+}
+
+type (
+	actorKey     struct{}
+	requestIDKey struct{}
+)
+
+// WithActor returns a copy of the context with ID of the user making changes.
+func WithActor(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns ID of the user making changes, zero if it's not set.
+func ActorFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(actorKey{}).(int64)
+	return userID
+}
+
+// WithRequestID returns a copy of the context with ID of the client request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns ID of the client request, empty if it's not set.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
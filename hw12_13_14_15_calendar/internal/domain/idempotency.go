@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// IdempotentRequest is a write request of the user identified by the client request ID.
+type IdempotentRequest struct {
+	UserID    int64
+	RequestID string
+	// Hash is a hash of the request method and payload, a request ID can't be reused for another request.
+	Hash string
+}
+
+// NewIdempotentRequest returns a request of the user to the method with the payload.
+func NewIdempotentRequest(userID int64, requestID, method string, payload []byte) *IdempotentRequest {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write(payload)
+	return &IdempotentRequest{UserID: userID, RequestID: requestID, Hash: hex.EncodeToString(hash.Sum(nil))}
+}
+
+// IdempotencyRecord is a stored result of a request.
+type IdempotencyRecord struct {
+	Hash string
+	// Completed is false while the request is processed.
+	Completed bool
+	Response  []byte
+}
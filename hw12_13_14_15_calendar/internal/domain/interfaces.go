@@ -7,35 +7,164 @@ import (
 )
 
 // EventRepository is an interface for event repository.
+// User scoped methods return ErrPermission when an event belongs to another user unless the user is granted
+// the write access to its calendar, Get and listings for a period return events the user is invited to
+// and events of calendars shared with the user to read them as well. Events in the trash are ignored
+// by all methods except the trash ones. Changes of events are recorded to the event history with the actor
+// and the request ID of the context.
 type EventRepository interface {
-	// Add adds a new event.
-	Add(event *Event) error
+	// Add adds a new event owned by event.UserID and enqueues the next notifications of its reminders to the outbox,
+	// the event is added to the default calendar of the owner if its calendar isn't set.
+	Add(ctx context.Context, event *Event) error
 
-	// Update updates an existing event.
-	Update(event *Event) error
+	// Update updates an existing event of the user owned by event.UserID and replaces its pending notifications
+	// in the outbox, the event keeps its calendar if the calendar isn't set.
+	Update(ctx context.Context, userID int64, event *Event) error
 
-	// Delete removes an event by ID.
-	Delete(eventID string) error
+	// Delete moves an event of the user to the trash and removes its pending notifications from the outbox.
+	Delete(ctx context.Context, userID int64, eventID string) error
 
-	// DeleteEventBeforeDate removes an event before date.
-	DeleteEventBeforeDate(date time.Time) error
+	// DeletePermanently removes an event of the user by ID, the event may be in the trash.
+	DeletePermanently(ctx context.Context, userID int64, eventID string) error
 
-	// Get gets an event by ID.
-	Get(eventID string) (*Event, error)
+	// Restore restores an event of the user from the trash and enqueues its next notifications to the outbox.
+	Restore(ctx context.Context, userID int64, eventID string) (*Event, error)
 
-	// GetEventsByPeriod get a list of events for a period.
-	GetEventsByPeriod(startTime, endTime time.Time) ([]*Event, error)
+	// GetDeletedEvents gets a list of the user events in the trash, the latest deleted go first.
+	GetDeletedEvents(ctx context.Context, userID int64) ([]*Event, error)
 
-	// GetEventsByNotifyTime gets a list of events by notify time.
-	GetEventsByNotifyTime(startTime, endTime time.Time) ([]*Event, error)
+	// PurgeEvents removes events of all users which were deleted before deletedBefore
+	// or ended before expiredBefore.
+	PurgeEvents(ctx context.Context, expiredBefore, deletedBefore time.Time) error
+
+	// Get gets an event of the user by ID.
+	Get(ctx context.Context, userID int64, eventID string) (*Event, error)
+
+	// GetEventsByPeriod get a list of the user events for a period, all-day events are listed for the wall clock
+	// of the period in their time zone.
+	GetEventsByPeriod(ctx context.Context, userID int64, startTime, endTime time.Time) ([]*Event, error)
+
+	// GetEventsPage gets a page of the filter user events for the filter period.
+	GetEventsPage(ctx context.Context, filter *EventFilter) (*EventPage, error)
+
+	// IterateEvents calls fn for each of the filter user events for the filter period until fn returns an error,
+	// the page size and the cursor of the filter are ignored.
+	IterateEvents(ctx context.Context, filter *EventFilter, fn func(e *Event) error) error
+
+	// GetOverlappingEvents gets a list of the user events which overlap a period.
+	GetOverlappingEvents(ctx context.Context, userID int64, startTime, endTime time.Time) ([]*Event, error)
+
+	// InviteAttendees invites the users to an event of the user and replaces its pending notifications in the outbox,
+	// so the attendees are notified too.
+	InviteAttendees(ctx context.Context, userID int64, eventID string, userIDs []int64) (*Event, error)
+
+	// RespondInvitation sets the response of the user to an event the user is invited to
+	// and replaces its pending notifications in the outbox.
+	RespondInvitation(ctx context.Context, userID int64, eventID string, status AttendeeStatus) (*Event, error)
+
+	// GetInvitations gets a list of events the user is invited to ordered by start time, only the events
+	// of the response status if it's set. Recurring events are not expanded.
+	GetInvitations(ctx context.Context, userID int64, status AttendeeStatus) ([]*Event, error)
+
+	// GetHistory gets changes of an event of the user in the order they were made,
+	// the history is kept after the event is deleted permanently.
+	GetHistory(ctx context.Context, userID int64, eventID string) ([]*EventChange, error)
+}
+
+// CalendarRepository is an interface for repository of calendars and their shares.
+// Calendars shared with a user are visible to the user, only their owners can change them.
+type CalendarRepository interface {
+	// Add adds a new calendar owned by calendar.UserID.
+	Add(ctx context.Context, calendar *Calendar) error
+
+	// Update updates the name, the color and the time zone of a calendar owned by calendar.UserID.
+	Update(ctx context.Context, calendar *Calendar) error
+
+	// Delete removes a calendar of the user with its shares, it returns ErrCalendarNotEmpty if the calendar
+	// has events and ErrDefaultCalendar for the default calendar.
+	Delete(ctx context.Context, userID int64, calendarID string) error
+
+	// Get gets a calendar of the user or a calendar shared with the user with the access of the user.
+	Get(ctx context.Context, userID int64, calendarID string) (*Calendar, error)
+
+	// GetCalendars gets a list of the user calendars and calendars shared with the user ordered by name,
+	// the default calendar of the user is created if it doesn't exist yet.
+	GetCalendars(ctx context.Context, userID int64) ([]*Calendar, error)
+
+	// Share grants the access to a calendar of the owner to the user of the share replacing the previous one.
+	Share(ctx context.Context, ownerID int64, calendarID string, share *CalendarShare) error
+
+	// Unshare revokes the access of the user to a calendar of the owner.
+	Unshare(ctx context.Context, ownerID int64, calendarID string, userID int64) error
+
+	// GetShares gets a list of shares of a calendar of the owner ordered by the user ID.
+	GetShares(ctx context.Context, ownerID int64, calendarID string) ([]*CalendarShare, error)
+}
+
+// NotificationOutbox is an interface for the transactional outbox of event notifications,
+// messages are written by EventRepository together with the events.
+type NotificationOutbox interface {
+	// ProcessNotifications claims up to limit messages due at now, which are not claimed by other schedulers,
+	// and calls fn for each of them. A message is marked sent if fn succeeds or retried with the backoff otherwise,
+	// the next notification of a recurring event by the same reminder is enqueued after a sent one.
+	// It returns a number of the claimed messages and errors of recording their results, an error of a message
+	// doesn't stop processing of the others.
+	ProcessNotifications(
+		ctx context.Context, now time.Time, limit int, backoff Backoff, fn func(m *OutboxMessage) error,
+	) (int, error)
+}
+
+// NotificationTargetRepository is an interface for repository of notification targets of users.
+type NotificationTargetRepository interface {
+	// Get gets the notification target of the user, ErrNotificationTargetNotExist is returned if it's not set.
+	Get(ctx context.Context, userID int64) (*NotificationTarget, error)
+
+	// Set sets the notification target of the user.
+	Set(ctx context.Context, userID int64, target *NotificationTarget) error
+}
+
+// IdempotencyRepository is an interface for repository of write request results keyed on the user and
+// the request ID, records expire after their TTL.
+type IdempotencyRepository interface {
+	// Reserve reserves the request ID for the request for ttl. It returns nil if the ID is reserved
+	// or the record of an earlier request with the ID otherwise.
+	Reserve(ctx context.Context, request *IdempotentRequest, ttl time.Duration) (*IdempotencyRecord, error)
+
+	// Complete stores the response of the reserved request for ttl.
+	Complete(ctx context.Context, request *IdempotentRequest, response []byte, ttl time.Duration) error
+
+	// Release removes the reservation of the request which failed, so it can be retried.
+	Release(ctx context.Context, request *IdempotentRequest) error
+
+	// Purge removes records of all users expired before now.
+	Purge(ctx context.Context, now time.Time) error
+}
+
+// NotificationChannel is an interface of a notification delivery channel.
+type NotificationChannel interface {
+	// Name returns the channel name of notification targets.
+	Name() string
+
+	// Send delivers the notification to the address of the channel.
+	Send(ctx context.Context, notification *Notification, address string) error
 }
 
 type EventConsumer interface {
 	io.Closer
-	Consume(name string) (<-chan []byte, error)
+	// Consume returns messages of the queue, each of them must be acked, retried or rejected.
+	Consume(name string) (<-chan Delivery, error)
 }
 
 type EventProducer interface {
 	io.Closer
 	Publish(ctx context.Context, queueName string, data []byte) error
 }
+
+// DeadLetterQueue is an interface for dead-letter queues of messages which failed handling.
+type DeadLetterQueue interface {
+	// DeadLetters returns up to limit dead letters of the queue, they are left in the dead-letter queue.
+	DeadLetters(queue string, limit int) ([]*DeadLetter, error)
+
+	// Replay moves up to limit dead letters back to the queue with reset attempts and returns their number.
+	Replay(ctx context.Context, queue string, limit int) (int, error)
+}
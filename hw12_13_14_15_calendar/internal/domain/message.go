@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Delivery is a message consumed from a queue, it must be acked, retried or rejected after handling.
+type Delivery interface {
+	Body() []byte
+
+	// Timestamp returns the time the message was published to the queue.
+	Timestamp() time.Time
+
+	// Context returns a copy of the parent context continuing the trace of the message publisher.
+	Context(parent context.Context) context.Context
+
+	// Attempt returns a number of the delivery attempt starting from 1.
+	Attempt() int
+
+	// Ack acknowledges the handled message.
+	Ack() error
+
+	// Retry returns the message to the queue, it's moved to the dead-letter queue after the last attempt.
+	Retry(reason error) error
+
+	// Reject moves the message, which can't be handled, to the dead-letter queue.
+	Reject(reason error) error
+}
+
+// DeadLetter is a message moved to the dead-letter queue of a queue.
+type DeadLetter struct {
+	Queue    string
+	Body     []byte
+	Attempts int
+	Error    string
+	Time     time.Time
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// Names of notification channels.
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	// ChannelLog writes notifications to a file or stdout.
+	ChannelLog = "log"
+)
+
+// NotificationTarget is a channel and an address (e-mail, webhook URL) of notification delivery.
+type NotificationTarget struct {
+	Channel string
+	Address string
+}
+
+// Validate checks the channel and the address format of the channel.
+func (t *NotificationTarget) Validate() error {
+	switch t.Channel {
+	case ChannelEmail:
+		if _, err := mail.ParseAddress(t.Address); err != nil {
+			return fmt.Errorf("%w: %w", ErrNotificationTarget, err)
+		}
+	case ChannelWebhook:
+		u, err := url.Parse(t.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: webhook address must be an absolute HTTP URL", ErrNotificationTarget)
+		}
+	case ChannelLog:
+	default:
+		return fmt.Errorf("%w: unknown channel %q", ErrNotificationTarget, t.Channel)
+	}
+	return nil
+}
+
+// DeliveryResult is a result of the notification delivery published to the result queue.
+type DeliveryResult struct {
+	EventID   string
+	UserID    int64
+	EventDate time.Time
+	Channel   string
+	Address   string
+	Delivered bool
+	Error     string `json:",omitempty"`
+	// Attempt is a number of the delivery attempt starting from 1.
+	Attempt int
+	Time    time.Time
+}
@@ -0,0 +1,143 @@
+package domain
+
+import "time"
+
+// NotificationGracePeriod is how long a notification, which became due before the event was written, is still sent.
+const NotificationGracePeriod = time.Minute
+
+// OutboxMessage is a notification of an event occurrence stored in the transactional outbox.
+type OutboxMessage struct {
+	ID      int64
+	EventID string
+	// Notifications hold the notification of a single recipient, the owner or an attendee who hasn't declined,
+	// so retries of the message don't notify other recipients again.
+	Notifications []*Notification
+	// NotifyTime is a time of the reminder of the occurrence.
+	NotifyTime      time.Time
+	Attempts        int
+	NextAttemptTime time.Time
+	LastError       string
+	SentTime        *time.Time
+	// FailedTime is set when delivery is given up after the last attempt.
+	FailedTime *time.Time
+	// TraceContext is a propagated trace context of the write which enqueued the message.
+	TraceContext map[string]string
+}
+
+// Backoff is an exponential backoff of notification delivery retries.
+type Backoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxAttempts is unlimited if zero.
+	MaxAttempts int
+}
+
+// Delay returns a delay of the retry after the failed attempt (starting from 1).
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.BaseDelay
+	for i := 1; i < attempt && (b.MaxDelay <= 0 || delay < b.MaxDelay); i++ {
+		delay *= 2
+	}
+	if b.MaxDelay > 0 && delay > b.MaxDelay {
+		delay = b.MaxDelay
+	}
+	return delay
+}
+
+// NewOutboxMessages returns messages of the first notifications of each event reminder at or after the time
+// for each recipient, reminders which have no more notifications are skipped.
+func NewOutboxMessages(e *Event, after time.Time) []*OutboxMessage {
+	var messages []*OutboxMessage
+	for _, r := range e.Reminders {
+		messages = append(messages, newOutboxMessages(e, r, after)...)
+	}
+	return messages
+}
+
+// NextOutboxMessage returns a message of the notification of the recurring event following the sent or given up
+// message by the same reminder to the same recipient, nil if the event has no more notifications, the reminder
+// was removed or the recipient is no longer notified.
+func NextOutboxMessage(e *Event, prev *OutboxMessage) *OutboxMessage {
+	if e.Recurrence == nil || len(prev.Notifications) == 0 {
+		return nil
+	}
+	r := e.reminder(prev.NotifyTime.Sub(prev.Notifications[0].EventDate))
+	if r == nil {
+		return nil
+	}
+	for _, m := range newOutboxMessages(e, r, prev.NotifyTime.Add(time.Nanosecond)) {
+		if m.Notifications[0].UserToSend == prev.Notifications[0].UserToSend {
+			return m
+		}
+	}
+	return nil
+}
+
+// newOutboxMessages returns messages of the first notification of the event reminder at or after the time
+// for each recipient, nil if the reminder has no more notifications.
+func newOutboxMessages(e *Event, r *Reminder, after time.Time) []*OutboxMessage {
+	start := e.StartTime
+	if e.Recurrence != nil {
+		// Occurrences notified by the offset reminder before the time are skipped without the expansion.
+		from := e.StartTime
+		if r.Time == nil {
+			from = after.Add(-r.Offset)
+		}
+		found := false
+		e.eachStart(from, time.Unix(1<<62, 0), func(t time.Time) bool {
+			if !r.At(t).Before(after) {
+				start, found = t, true
+				return false
+			}
+			return true
+		})
+		if !found {
+			return nil
+		}
+	} else if r.At(start).Before(after) {
+		return nil
+	}
+	notifyTime := r.At(start)
+	notification := &Notification{
+		EventID:    e.ID,
+		EventTitle: e.Title,
+		EventDate:  start,
+		TimeZone:   e.TimeZone,
+		AllDay:     e.AllDay,
+		UserToSend: e.UserID,
+		Target:     e.NotificationTarget,
+	}
+	notifications := []*Notification{notification}
+	for _, a := range e.Attendees {
+		if a.Status == AttendeeDeclined {
+			continue
+		}
+		// The target of the event is the owner one, attendees are notified via their own targets.
+		attendee := *notification
+		attendee.UserToSend = a.UserID
+		attendee.Target = nil
+		notifications = append(notifications, &attendee)
+	}
+	messages := make([]*OutboxMessage, len(notifications))
+	for i, n := range notifications {
+		messages[i] = &OutboxMessage{
+			EventID:         e.ID,
+			Notifications:   []*Notification{n},
+			NotifyTime:      notifyTime,
+			NextAttemptTime: notifyTime,
+		}
+	}
+	return messages
+}
+
+// Fail records a failed delivery attempt, the message is retried after the backoff delay
+// or given up after the last attempt.
+func (m *OutboxMessage) Fail(now time.Time, err error, backoff Backoff) {
+	m.Attempts++
+	m.LastError = err.Error()
+	if backoff.MaxAttempts > 0 && m.Attempts >= backoff.MaxAttempts {
+		m.FailedTime = &now
+		return
+	}
+	m.NextAttemptTime = now.Add(backoff.Delay(m.Attempts))
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutboxMessages(t *testing.T) {
+	event := &Event{
+		ID: "id", Title: "title", UserID: 1, StartTime: date(1, 10), Reminders: []*Reminder{{Offset: time.Hour}},
+	}
+
+	messages := NewOutboxMessages(event, date(1, 11))
+	require.Len(t, messages, 1)
+	m := messages[0]
+	require.Equal(t, "id", m.EventID)
+	require.Equal(
+		t,
+		[]*Notification{{EventID: "id", EventTitle: "title", EventDate: date(1, 10), UserToSend: 1}},
+		m.Notifications,
+	)
+	require.Equal(t, date(1, 11), m.NotifyTime)
+	require.Equal(t, date(1, 11), m.NextAttemptTime)
+	require.Empty(t, NewOutboxMessages(event, date(1, 12)))
+	require.Empty(t, NewOutboxMessages(&Event{StartTime: date(1, 10)}, date(1, 0)))
+
+	event.Recurrence = &Recurrence{Frequency: FrequencyDaily, Count: 3, Exceptions: []time.Time{date(2, 10)}}
+	messages = NewOutboxMessages(event, date(1, 12))
+	require.Len(t, messages, 1)
+	require.Equal(t, date(3, 10), messages[0].Notifications[0].EventDate)
+	require.Equal(t, date(3, 11), messages[0].NotifyTime)
+	require.Empty(t, NewOutboxMessages(event, date(3, 12)))
+}
+
+func TestNewOutboxMessagesReminders(t *testing.T) {
+	remindTime := date(1, 8)
+	event := &Event{
+		ID:        "id",
+		StartTime: date(2, 10),
+		Reminders: []*Reminder{{Offset: -24 * time.Hour}, {Time: &remindTime}, {Offset: -10 * time.Minute}},
+	}
+	messages := NewOutboxMessages(event, date(1, 9))
+	require.Len(t, messages, 2)
+	require.Equal(t, date(1, 10), messages[0].NotifyTime)
+	require.Equal(t, date(2, 10).Add(-10*time.Minute), messages[1].NotifyTime)
+	for _, m := range messages {
+		require.Equal(t, date(2, 10), m.Notifications[0].EventDate)
+		require.Nil(t, NextOutboxMessage(event, m))
+	}
+
+	event.Reminders = []*Reminder{{Offset: -24 * time.Hour}, {Offset: -time.Hour}}
+	event.Recurrence = &Recurrence{Frequency: FrequencyDaily, Count: 3}
+	messages = NewOutboxMessages(event, date(1, 9))
+	require.Len(t, messages, 2)
+	next := NextOutboxMessage(event, messages[0])
+	require.Equal(t, date(3, 10), next.Notifications[0].EventDate)
+	require.Equal(t, date(2, 10), next.NotifyTime)
+	next = NextOutboxMessage(event, messages[1])
+	require.Equal(t, date(3, 10), next.Notifications[0].EventDate)
+	require.Equal(t, date(3, 9), next.NotifyTime)
+	next = NextOutboxMessage(event, next)
+	require.Equal(t, date(4, 9), next.NotifyTime)
+	require.Nil(t, NextOutboxMessage(event, next))
+
+	// The next notification isn't enqueued for a removed reminder.
+	event.Reminders = event.Reminders[:1]
+	require.Nil(t, NextOutboxMessage(event, messages[1]))
+}
+
+func TestNewOutboxMessagesAttendees(t *testing.T) {
+	target := &NotificationTarget{Channel: ChannelLog}
+	event := &Event{
+		ID:                 "id",
+		UserID:             1,
+		StartTime:          date(1, 10),
+		Reminders:          []*Reminder{{Offset: -time.Hour}},
+		NotificationTarget: target,
+		Attendees: []*Attendee{
+			{UserID: 2, Status: AttendeeInvited},
+			{UserID: 3, Status: AttendeeDeclined},
+			{UserID: 4, Status: AttendeeAccepted},
+		},
+	}
+
+	messages := NewOutboxMessages(event, date(1, 0))
+	require.Len(t, messages, 3)
+	for _, m := range messages {
+		require.Len(t, m.Notifications, 1)
+	}
+	require.Equal(t, int64(1), messages[0].Notifications[0].UserToSend)
+	require.Equal(t, target, messages[0].Notifications[0].Target)
+	for i, userID := range []int64{2, 4} {
+		require.Equal(t, userID, messages[i+1].Notifications[0].UserToSend)
+		require.Equal(t, date(1, 10), messages[i+1].Notifications[0].EventDate)
+		require.Nil(t, messages[i+1].Notifications[0].Target)
+	}
+
+	// Next notifications follow their recipients, the attendee who declined is no longer notified.
+	event.Recurrence = &Recurrence{Frequency: FrequencyDaily}
+	event.Attendees[0].Status = AttendeeDeclined
+	next := NextOutboxMessage(event, messages[0])
+	require.Equal(t, int64(1), next.Notifications[0].UserToSend)
+	require.Equal(t, date(2, 9), next.NotifyTime)
+	require.Nil(t, NextOutboxMessage(event, messages[1]))
+	next = NextOutboxMessage(event, messages[2])
+	require.Equal(t, int64(4), next.Notifications[0].UserToSend)
+	require.Equal(t, date(2, 9), next.NotifyTime)
+}
+
+func TestBackoff(t *testing.T) {
+	backoff := Backoff{BaseDelay: time.Second, MaxDelay: 5 * time.Second, MaxAttempts: 3}
+	require.Equal(t, time.Second, backoff.Delay(1))
+	require.Equal(t, 2*time.Second, backoff.Delay(2))
+	require.Equal(t, 4*time.Second, backoff.Delay(3))
+	require.Equal(t, 5*time.Second, backoff.Delay(10))
+
+	m := &OutboxMessage{}
+	now := date(1, 10)
+	err := errors.New("publish failed")
+	m.Fail(now, err, backoff)
+	require.Equal(t, 1, m.Attempts)
+	require.Equal(t, err.Error(), m.LastError)
+	require.Equal(t, now.Add(time.Second), m.NextAttemptTime)
+	m.Fail(now, err, backoff)
+	require.Equal(t, now.Add(2*time.Second), m.NextAttemptTime)
+	require.Nil(t, m.FailedTime)
+	m.Fail(now, err, backoff)
+	require.Equal(t, &now, m.FailedTime)
+}
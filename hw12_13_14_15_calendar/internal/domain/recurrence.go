@@ -0,0 +1,425 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is a recurrence frequency (RFC 5545 FREQ).
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+const (
+	recurrenceTimeFormat = "20060102T150405Z"
+	recurrenceDateFormat = "20060102"
+	// maxRecurrencePeriods limits expansion of infinite rules.
+	maxRecurrencePeriods = 100000
+)
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Recurrence is a recurrence rule of an event (subset of RFC 5545 RRULE).
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int
+	ByDay     []time.Weekday
+	Count     int
+	Until     *time.Time
+	// Exceptions are start times of excluded occurrences (RFC 5545 EXDATE).
+	Exceptions []time.Time
+}
+
+// ParseRecurrenceRule parses RRULE value, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+func ParseRecurrenceRule(rule string) (*Recurrence, error) {
+	r := &Recurrence{Interval: 1}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrRecurrenceRule, part)
+		}
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Frequency = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRecurrenceTime(value, true)
+			r.Until = &until
+		case "BYDAY":
+			r.ByDay, err = parseWeekdays(value)
+		case "WKST":
+			if strings.ToUpper(value) != weekdayNames[time.Monday] {
+				err = errors.New("only WKST=MO is supported")
+			}
+		default:
+			err = errors.New("unsupported part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrRecurrenceRule, name, err)
+		}
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rule returns RRULE value of the recurrence.
+func (r *Recurrence) Rule() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = weekdayNames[d]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(recurrenceTimeFormat))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Validate checks the recurrence rule.
+func (r *Recurrence) Validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return fmt.Errorf("%w: unsupported frequency %q", ErrRecurrenceRule, r.Frequency)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: interval must be positive", ErrRecurrenceRule)
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("%w: count must be positive", ErrRecurrenceRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: count and until are mutually exclusive", ErrRecurrenceRule)
+	}
+	for _, d := range r.ByDay {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("%w: invalid weekday %d", ErrRecurrenceRule, d)
+		}
+	}
+	return nil
+}
+
+// FormatRecurrenceTimes formats times as a RFC 5545 date-time list (EXDATE value).
+func FormatRecurrenceTimes(times []time.Time) string {
+	values := make([]string, len(times))
+	for i, t := range times {
+		values[i] = t.UTC().Format(recurrenceTimeFormat)
+	}
+	return strings.Join(values, ",")
+}
+
+// ParseRecurrenceTimes parses a RFC 5545 date-time list (EXDATE value).
+func ParseRecurrenceTimes(value string) ([]time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	values := strings.Split(value, ",")
+	times := make([]time.Time, len(values))
+	for i, v := range values {
+		t, err := parseRecurrenceTime(v, false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRecurrenceRule, err)
+		}
+		times[i] = t
+	}
+	return times, nil
+}
+
+func parseRecurrenceTime(value string, endOfDay bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(recurrenceDateFormat, value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		return t, nil
+	}
+	return time.Parse(recurrenceTimeFormat, strings.TrimSuffix(value, "Z")+"Z")
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		found := false
+		for d, n := range weekdayNames {
+			if n == name {
+				days = append(days, time.Weekday(d))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid weekday %q", name)
+		}
+	}
+	return days, nil
+}
+
+func (r *Recurrence) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+func (r *Recurrence) matchesDay(d time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// isException reports whether the occurrence is excluded, RFC 5545 times have a second precision.
+func (r *Recurrence) isException(t time.Time) bool {
+	t = t.Truncate(time.Second)
+	for _, e := range r.Exceptions {
+		if e.Truncate(time.Second).Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayOffset returns number of days since Monday (weeks start on Monday, WKST=MO).
+func mondayOffset(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+// periodStarts returns sorted occurrence candidates of the n-th period of the series.
+func (r *Recurrence) periodStarts(dtstart time.Time, n int) []time.Time {
+	step := n * r.interval()
+	year, month, day := dtstart.Date()
+	hour, minute, sec := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, sec, dtstart.Nanosecond(), dtstart.Location())
+	}
+	matching := func(first, end time.Time) []time.Time {
+		var result []time.Time
+		for t := first; t.Before(end); t = t.AddDate(0, 0, 1) {
+			if r.matchesDay(t.Weekday()) {
+				result = append(result, t)
+			}
+		}
+		return result
+	}
+	switch r.Frequency {
+	case FrequencyDaily:
+		if t := at(year, month, day+step); r.matchesDay(t.Weekday()) {
+			return []time.Time{t}
+		}
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(year, month, day+7*step)}
+		}
+		monday := day - mondayOffset(dtstart.Weekday()) + 7*step
+		days := append([]time.Weekday(nil), r.ByDay...)
+		sort.Slice(days, func(i, j int) bool { return mondayOffset(days[i]) < mondayOffset(days[j]) })
+		result := make([]time.Time, 0, len(days))
+		for _, d := range days {
+			result = append(result, at(year, month, monday+mondayOffset(d)))
+		}
+		return result
+	case FrequencyMonthly:
+		first := at(year, month+time.Month(step), 1)
+		if len(r.ByDay) > 0 {
+			return matching(first, first.AddDate(0, 1, 0))
+		}
+		// Months without the day of the series start are skipped.
+		if t := at(year, month+time.Month(step), day); t.Month() == first.Month() {
+			return []time.Time{t}
+		}
+	case FrequencyYearly:
+		if len(r.ByDay) > 0 {
+			first := at(year+step, time.January, 1)
+			return matching(first, first.AddDate(1, 0, 0))
+		}
+		if t := at(year+step, month, day); t.Month() == month {
+			return []time.Time{t}
+		}
+	}
+	return nil
+}
+
+// firstPeriod returns the period of the series beginning at dtstart to expand the series from, the periods
+// before it have no occurrences at or after from. Series limited by COUNT are expanded from the start
+// since the skipped occurrences are counted.
+func (r *Recurrence) firstPeriod(dtstart, from time.Time) int {
+	if r.Count > 0 || !from.After(dtstart) {
+		return 0
+	}
+	from = from.In(dtstart.Location())
+	periods := 0
+	switch r.Frequency {
+	case FrequencyDaily:
+		periods = daysBetween(dtstart, from)
+	case FrequencyWeekly:
+		periods = (daysBetween(dtstart, from) + mondayOffset(dtstart.Weekday())) / 7
+	case FrequencyMonthly:
+		periods = (from.Year()-dtstart.Year())*12 + int(from.Month()-dtstart.Month())
+	case FrequencyYearly:
+		periods = from.Year() - dtstart.Year()
+	}
+	// The previous period is expanded too, its occurrences may be at or after from by the time of day.
+	return max(periods/r.interval()-1, 0)
+}
+
+// daysBetween returns the number of calendar days from the date of a to the date of b.
+func daysBetween(a, b time.Time) int {
+	dateA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dateB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	// Durations are limited to ~290 years, so the days are counted by Unix seconds.
+	return int((dateB.Unix() - dateA.Unix()) / (24 * 60 * 60))
+}
+
+// each calls fn for every occurrence start of the series beginning at dtstart which is at or after from
+// until fn returns false, the series ends or an occurrence is after limit. DTSTART is the first occurrence
+// even if it doesn't match the rule (RFC 5545), so it's counted by COUNT too.
+func (r *Recurrence) each(dtstart, from, limit time.Time, fn func(time.Time) bool) {
+	if dtstart.After(limit) || (r.Until != nil && dtstart.After(*r.Until)) {
+		return
+	}
+	if !dtstart.Before(from) && !r.isException(dtstart) && !fn(dtstart) {
+		return
+	}
+	count := 1
+	first := r.firstPeriod(dtstart, from)
+	for n := first; n < first+maxRecurrencePeriods; n++ {
+		for _, t := range r.periodStarts(dtstart, n) {
+			if !t.After(dtstart) {
+				continue
+			}
+			if t.After(limit) || (r.Until != nil && t.After(*r.Until)) {
+				return
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+			if t.Before(from) || r.isException(t) {
+				continue
+			}
+			if !fn(t) {
+				return
+			}
+		}
+	}
+}
+
+// RecurrenceEnd returns the upper bound of occurrence start times, nil for an infinite series.
+func (e *Event) RecurrenceEnd() *time.Time {
+	r := e.Recurrence
+	if r == nil || (r.Until == nil && r.Count == 0) {
+		return nil
+	}
+	if r.Until != nil {
+		end := *r.Until
+		return &end
+	}
+	// The series ends after COUNT occurrences, so the expansion isn't limited by time.
+	end := e.StartTime
+	e.eachStart(e.StartTime, time.Unix(1<<62, 0), func(t time.Time) bool {
+		end = t
+		return true
+	})
+	return &end
+}
+
+// eachStart calls fn for every occurrence start of the recurring event at or after from until fn returns false,
+// the series ends or an occurrence is after limit. The series is expanded in the event time zone, so occurrences
+// keep their wall clock across daylight saving time changes.
+func (e *Event) eachStart(from, limit time.Time, fn func(time.Time) bool) {
+	e.Recurrence.each(e.StartTime.In(e.Location()), from, limit, func(t time.Time) bool {
+		return fn(t.UTC())
+	})
+}
+
+// occurrence returns a copy of the event moved to the occurrence start time,
+// occurrences of all-day events take the same number of days.
+func (e *Event) occurrence(start time.Time) *Event {
+	o := *e
+	shift := start.Sub(e.StartTime)
+	o.StartTime = start
+	if e.EndTime != nil {
+		t := e.EndTime.Add(shift)
+		if e.AllDay {
+			t = start.In(e.Location()).AddDate(0, 0, e.days()).UTC()
+		}
+		o.EndTime = &t
+	}
+	o.RecurrenceID = &start
+	return &o
+}
+
+// occurrences returns event occurrences starting within the period.
+func (e *Event) occurrences(startTime, endTime time.Time) []*Event {
+	if e.Recurrence == nil {
+		if e.StartTime.Before(startTime) || e.StartTime.After(endTime) {
+			return nil
+		}
+		return []*Event{e}
+	}
+	var result []*Event
+	e.eachStart(startTime, endTime, func(t time.Time) bool {
+		result = append(result, e.occurrence(t))
+		return true
+	})
+	return result
+}
+
+// OccurrencesByPeriod returns event occurrences which start and end within the period,
+// all-day events are matched against the period in their time zone.
+func (e *Event) OccurrencesByPeriod(startTime, endTime time.Time) []*Event {
+	startTime, endTime = e.listingPeriod(startTime, endTime)
+	var result []*Event
+	for _, o := range e.occurrences(startTime, endTime) {
+		if o.EndTime != nil && !o.EndTime.After(endTime) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// OccurrencesByRange returns event occurrences which overlap the period.
+func (e *Event) OccurrencesByRange(startTime, endTime time.Time) []*Event {
+	var result []*Event
+	for _, o := range e.occurrences(startTime.Add(-e.end().Sub(e.StartTime)), endTime) {
+		if o.overlapsPeriod(startTime, endTime) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(day int, hour int) time.Time {
+	return time.Date(2024, time.January, day, hour, 0, 0, 0, time.UTC)
+}
+
+func occurrenceStarts(events []*Event) []time.Time {
+	result := make([]time.Time, len(events))
+	for i, e := range events {
+		result[i] = e.StartTime
+	}
+	return result
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	r, err := ParseRecurrenceRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10")
+	require.NoError(t, err)
+	require.Equal(t, FrequencyWeekly, r.Frequency)
+	require.Equal(t, 2, r.Interval)
+	require.Equal(t, []time.Weekday{time.Monday, time.Wednesday}, r.ByDay)
+	require.Equal(t, 10, r.Count)
+	require.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", r.Rule())
+
+	r, err = ParseRecurrenceRule("FREQ=DAILY;UNTIL=20240110")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.January, 10, 23, 59, 59, 0, time.UTC), *r.Until)
+
+	for _, rule := range []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240110T000000Z",
+		"FREQ=DAILY;BYMONTH=1",
+	} {
+		_, err := ParseRecurrenceRule(rule)
+		require.ErrorIs(t, err, ErrRecurrenceRule, rule)
+	}
+}
+
+func TestRecurrenceTimes(t *testing.T) {
+	times := []time.Time{date(1, 10), date(2, 10)}
+	value := FormatRecurrenceTimes(times)
+	require.Equal(t, "20240101T100000Z,20240102T100000Z", value)
+	parsed, err := ParseRecurrenceTimes(value)
+	require.NoError(t, err)
+	require.Equal(t, times, parsed)
+}
+
+func TestOccurrencesByPeriod(t *testing.T) {
+	cases := []struct {
+		name     string
+		start    time.Time
+		rule     string
+		from, to time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "daily with count",
+			start:    date(1, 10),
+			rule:     "FREQ=DAILY;COUNT=3",
+			from:     date(1, 0),
+			to:       date(31, 0),
+			expected: []time.Time{date(1, 10), date(2, 10), date(3, 10)},
+		},
+		{
+			name:     "daily with interval inside window",
+			start:    date(1, 10),
+			rule:     "FREQ=DAILY;INTERVAL=2",
+			from:     date(4, 0),
+			to:       date(9, 0),
+			expected: []time.Time{date(5, 10), date(7, 10)},
+		},
+		{
+			name:     "weekly by day until",
+			start:    date(3, 10), // Wednesday
+			rule:     "FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20240110T100000Z",
+			from:     date(1, 0),
+			to:       date(31, 0),
+			expected: []time.Time{date(3, 10), date(5, 10), date(8, 10), date(10, 10)},
+		},
+		{
+			name:     "weekly count includes the start not matching the rule",
+			start:    date(3, 10), // Wednesday
+			rule:     "FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+			from:     date(1, 0),
+			to:       date(31, 0),
+			expected: []time.Time{date(3, 10), date(8, 10)},
+		},
+		{
+			name:  "daily far from the start",
+			start: time.Date(1900, time.January, 1, 10, 0, 0, 0, time.UTC),
+			rule:  "FREQ=DAILY",
+			from:  time.Date(2200, time.January, 1, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2200, time.January, 3, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2200, time.January, 1, 10, 0, 0, 0, time.UTC),
+				time.Date(2200, time.January, 2, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly skips short months",
+			start: time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC),
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			from:  date(1, 0),
+			to:    time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 31, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 31, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "yearly",
+			start: time.Date(2024, time.February, 29, 10, 0, 0, 0, time.UTC),
+			rule:  "FREQ=YEARLY",
+			from:  date(1, 0),
+			to:    time.Date(2029, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, time.February, 29, 10, 0, 0, 0, time.UTC),
+				time.Date(2028, time.February, 29, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := ParseRecurrenceRule(c.rule)
+			require.NoError(t, err)
+			end := c.start.Add(time.Hour)
+			e := &Event{StartTime: c.start, EndTime: &end, Recurrence: r}
+			require.Equal(t, c.expected, occurrenceStarts(e.OccurrencesByPeriod(c.from, c.to)))
+		})
+	}
+}
+
+func TestRecurrenceEachFrom(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	dtstart := time.Date(2023, time.March, 31, 23, 30, 0, 0, location)
+	limit := dtstart.AddDate(3, 0, 0)
+	for _, rule := range []string{
+		"FREQ=DAILY;INTERVAL=3",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU",
+		"FREQ=MONTHLY;BYDAY=FR",
+		"FREQ=MONTHLY;INTERVAL=5",
+		"FREQ=YEARLY;BYDAY=TU",
+	} {
+		r, err := ParseRecurrenceRule(rule)
+		require.NoError(t, err)
+		var all []time.Time
+		r.each(dtstart, dtstart, limit, func(t time.Time) bool {
+			all = append(all, t)
+			return true
+		})
+		for from := dtstart; from.Before(limit); from = from.Add(241 * time.Hour) {
+			var expected, actual []time.Time
+			for _, t := range all {
+				if !t.Before(from) {
+					expected = append(expected, t)
+				}
+			}
+			r.each(dtstart, from, limit, func(t time.Time) bool {
+				actual = append(actual, t)
+				return true
+			})
+			require.Equal(t, expected, actual, "%s from %s", rule, from)
+		}
+	}
+}
+
+func TestOccurrencesExceptions(t *testing.T) {
+	r, err := ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	require.NoError(t, err)
+	r.Exceptions = []time.Time{date(2, 10)}
+	end := date(1, 11)
+	e := &Event{StartTime: date(1, 10), EndTime: &end, Recurrence: r}
+	occurrences := e.OccurrencesByPeriod(date(1, 0), date(31, 0))
+	require.Equal(t, []time.Time{date(1, 10), date(3, 10)}, occurrenceStarts(occurrences))
+	require.Equal(t, date(3, 11), *occurrences[1].EndTime)
+	require.Equal(t, date(3, 10), *occurrences[1].RecurrenceID)
+	require.Equal(t, date(3, 10), *e.RecurrenceEnd())
+}
+
+func TestOccurrencesByRange(t *testing.T) {
+	r, err := ParseRecurrenceRule("FREQ=DAILY;COUNT=5")
+	require.NoError(t, err)
+	end := date(1, 12)
+	e := &Event{StartTime: date(1, 10), EndTime: &end, Recurrence: r}
+	occurrences := e.OccurrencesByRange(date(2, 11), date(4, 10))
+	require.Equal(t, []time.Time{date(2, 10), date(3, 10)}, occurrenceStarts(occurrences))
+}
+
+func TestEventOverlaps(t *testing.T) {
+	event := func(start, end int) *Event {
+		endTime := date(1, end)
+		return &Event{StartTime: date(1, start), EndTime: &endTime}
+	}
+	moment := &Event{StartTime: date(1, 11)}
+	cases := []struct {
+		name     string
+		a, b     *Event
+		expected bool
+	}{
+		{name: "intersecting", a: event(10, 12), b: event(11, 13), expected: true},
+		{name: "nested", a: event(10, 14), b: event(11, 12), expected: true},
+		{name: "back-to-back", a: event(10, 11), b: event(11, 12), expected: false},
+		{name: "separate", a: event(10, 11), b: event(12, 13), expected: false},
+		{name: "moment inside", a: event(10, 12), b: moment, expected: true},
+		{name: "moment at end", a: event(10, 11), b: moment, expected: false},
+		{name: "same moment", a: moment, b: moment, expected: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, c.a.Overlaps(c.b))
+			require.Equal(t, c.expected, c.b.Overlaps(c.a))
+		})
+	}
+}
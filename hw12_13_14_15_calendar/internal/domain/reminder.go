@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxReminders limits reminders of an event.
+	MaxReminders = 10
+	// MaxReminderOffset limits offsets of reminders from the event start time in both directions.
+	MaxReminderOffset = 366 * 24 * time.Hour
+)
+
+// Reminder is a notification of the event at an offset from its start time or at an absolute time,
+// reminders with offsets follow the event when it's moved.
+type Reminder struct {
+	// Offset from the start time of the event or its occurrence, negative offsets remind before the start.
+	Offset time.Duration
+	// Time is an absolute time of the reminder used instead of the offset, recurring events can't have them.
+	Time *time.Time `json:",omitempty"`
+}
+
+// At returns the time of the reminder of the event or occurrence starting at the time.
+func (r *Reminder) At(start time.Time) time.Time {
+	if r.Time != nil {
+		return *r.Time
+	}
+	return start.Add(r.Offset)
+}
+
+// validateReminders returns ErrReminder for too many, duplicate or invalid reminders of the event.
+func (e *Event) validateReminders() error {
+	if len(e.Reminders) > MaxReminders {
+		return fmt.Errorf("%w: event can't have more than %d reminders", ErrReminder, MaxReminders)
+	}
+	seen := make(map[time.Time]bool, len(e.Reminders))
+	for _, r := range e.Reminders {
+		switch {
+		case r.Time != nil && r.Offset != 0:
+			return fmt.Errorf("%w: reminder must have either an offset or a time", ErrReminder)
+		case r.Time != nil && e.Recurrence != nil:
+			return fmt.Errorf("%w: reminders of recurring events must have offsets", ErrReminder)
+		case r.Offset > MaxReminderOffset || r.Offset < -MaxReminderOffset:
+			return fmt.Errorf("%w: offset must not exceed %v", ErrReminder, MaxReminderOffset)
+		}
+		at := r.At(e.StartTime)
+		if seen[at] {
+			return fmt.Errorf("%w: reminders must not repeat", ErrReminder)
+		}
+		seen[at] = true
+	}
+	return nil
+}
+
+// normalizeReminders truncates reminders to the precision and sorts them by their time.
+func (e *Event) normalizeReminders(precision time.Duration) {
+	for _, r := range e.Reminders {
+		r.Offset = r.Offset.Truncate(precision)
+		if r.Time != nil {
+			t := r.Time.UTC().Truncate(precision)
+			r.Time = &t
+		}
+	}
+	sort.SliceStable(e.Reminders, func(i, j int) bool {
+		return e.Reminders[i].At(e.StartTime).Before(e.Reminders[j].At(e.StartTime))
+	})
+}
+
+// reminder returns the reminder of the event with the offset, nil if there is no such reminder.
+func (e *Event) reminder(offset time.Duration) *Reminder {
+	for _, r := range e.Reminders {
+		if r.Time == nil && r.Offset == offset {
+			return r
+		}
+	}
+	return nil
+}
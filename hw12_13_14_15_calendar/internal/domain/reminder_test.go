@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderAt(t *testing.T) {
+	require.Equal(t, date(1, 9), (&Reminder{Offset: -time.Hour}).At(date(1, 10)))
+	remindTime := date(1, 8)
+	require.Equal(t, remindTime, (&Reminder{Time: &remindTime}).At(date(1, 10)))
+}
+
+func TestEventValidateReminders(t *testing.T) {
+	remindTime := date(1, 8)
+	event := &Event{
+		ID:        uuid.New().String(),
+		StartTime: date(1, 10),
+		Reminders: []*Reminder{{Offset: -time.Hour}, {Time: &remindTime}, {Offset: -MaxReminderOffset}},
+	}
+	require.NoError(t, event.Validate())
+
+	for _, reminders := range [][]*Reminder{
+		{{Offset: -time.Hour, Time: &remindTime}},
+		{{Offset: MaxReminderOffset + time.Hour}},
+		{{Offset: -2 * time.Hour}, {Time: &remindTime}},
+		make([]*Reminder, MaxReminders+1),
+	} {
+		e := *event
+		e.Reminders = reminders
+		require.ErrorIs(t, e.Validate(), ErrReminder)
+	}
+
+	event.Recurrence = &Recurrence{Frequency: FrequencyDaily, Interval: 1}
+	require.ErrorIs(t, event.Validate(), ErrReminder)
+	event.Reminders = event.Reminders[:1]
+	require.NoError(t, event.Validate())
+}
+
+func TestEventNormalizeReminders(t *testing.T) {
+	remindTime := date(1, 9).Add(time.Microsecond)
+	event := &Event{
+		StartTime: date(1, 10),
+		Reminders: []*Reminder{{Offset: time.Hour}, {Offset: -time.Minute - time.Microsecond}, {Time: &remindTime}},
+	}
+	event.NormalizeTime()
+	require.Equal(t, date(1, 9), *event.Reminders[0].Time)
+	require.Equal(t, -time.Minute, event.Reminders[1].Offset)
+	require.Equal(t, time.Hour, event.Reminders[2].Offset)
+	require.Nil(t, event.reminder(time.Minute))
+	require.Equal(t, event.Reminders[2], event.reminder(time.Hour))
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// allDayFormat is a format of dates of all-day events.
+const allDayFormat = "Mon, 02 Jan 2006"
+
+// locations caches loaded time zones by their names.
+var locations sync.Map
+
+// loadLocation returns the time zone of the name, UTC for an empty or unknown name.
+func loadLocation(name string) *time.Location {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(name, location)
+	return location
+}
+
+// validateTimeZone returns ErrTimeZone for an unknown time zone of the event.
+func (e *Event) validateTimeZone() error {
+	if _, err := time.LoadLocation(e.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrTimeZone, e.TimeZone)
+	}
+	return nil
+}
+
+// Location returns the time zone of the event, UTC for an empty or unknown zone.
+func (e *Event) Location() *time.Location {
+	return loadLocation(e.TimeZone)
+}
+
+// startOfDay returns the midnight of the day of the time in its location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// inLocation returns the time with the same wall clock in the location.
+func inLocation(t time.Time, location *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
+}
+
+// alignDays moves the start and end times of an all-day event to midnights of the event time zone,
+// the end time is the midnight after the last day and an event without end time takes one day.
+func (e *Event) alignDays() {
+	location := e.Location()
+	start := startOfDay(e.StartTime.In(location))
+	end := start.AddDate(0, 0, 1)
+	if e.EndTime != nil && e.EndTime.After(end) {
+		end = startOfDay(e.EndTime.Add(-time.Nanosecond).In(location)).AddDate(0, 0, 1)
+	}
+	e.StartTime = start
+	e.EndTime = &end
+}
+
+// days returns a number of days of an all-day event.
+func (e *Event) days() int {
+	location := e.Location()
+	start, end := e.StartTime.In(location), e.end().In(location)
+	// Dates are compared in UTC, days of a daylight saving time change aren't 24 hours long.
+	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(endDate.Sub(startDate) / (24 * time.Hour))
+}
+
+// listingPeriod returns the listing period the event is matched against. Boundaries of the period keep
+// their wall clock in the time zone of an all-day event, so all-day events of a date are listed for the date
+// in any zone.
+func (e *Event) listingPeriod(startTime, endTime time.Time) (time.Time, time.Time) {
+	if !e.AllDay {
+		return startTime, endTime
+	}
+	location := e.Location()
+	return inLocation(startTime, location).UTC(), inLocation(endTime, location).UTC()
+}
+
+// LocalEventDate returns the date of the event of the notification in the event time zone.
+func (n *Notification) LocalEventDate() time.Time {
+	return n.EventDate.In(loadLocation(n.TimeZone))
+}
+
+// FormatEventDate formats the date of the event of the notification in the event time zone,
+// all-day events are formatted without time.
+func (n *Notification) FormatEventDate() string {
+	if n.AllDay {
+		return n.LocalEventDate().Format(allDayFormat)
+	}
+	return n.LocalEventDate().Format(time.RFC1123)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return location
+}
+
+func TestEventValidateTimeZone(t *testing.T) {
+	event := &Event{ID: uuid.New().String(), StartTime: parseTime("2024-12-25T10:00:00Z"), TimeZone: "Europe/Berlin"}
+	require.NoError(t, event.Validate())
+	event.TimeZone = "Mars/Olympus"
+	require.ErrorIs(t, event.Validate(), ErrTimeZone)
+	require.Equal(t, time.UTC, event.Location())
+}
+
+func TestEventNormalizeAllDay(t *testing.T) {
+	event := &Event{StartTime: parseTime("2024-12-25T10:00:00Z"), TimeZone: "Europe/Berlin", AllDay: true}
+	event.NormalizeTime()
+	require.Equal(t, parseTime("2024-12-24T23:00:00Z"), event.StartTime)
+	require.Equal(t, parseTime("2024-12-25T23:00:00Z"), *event.EndTime)
+
+	endTime := parseTime("2024-12-26T12:00:00Z")
+	event = &Event{StartTime: parseTime("2024-12-25T10:00:00Z"), EndTime: &endTime, AllDay: true}
+	event.NormalizeTime()
+	require.Equal(t, "UTC", event.TimeZone)
+	require.Equal(t, parseTime("2024-12-25T00:00:00Z"), event.StartTime)
+	require.Equal(t, parseTime("2024-12-27T00:00:00Z"), *event.EndTime)
+}
+
+func TestOccurrencesByPeriodOfAllDayEvent(t *testing.T) {
+	event := &Event{StartTime: parseTime("2024-12-25T00:00:00Z"), TimeZone: "Europe/Berlin", AllDay: true}
+	event.NormalizeTime()
+	newYork := loadTestLocation(t, "America/New_York")
+	day := time.Date(2024, time.December, 25, 0, 0, 0, 0, newYork)
+	// The holiday is listed for the date in New York and not for the day before.
+	require.Len(t, event.OccurrencesByPeriod(day, day.AddDate(0, 0, 1)), 1)
+	require.Empty(t, event.OccurrencesByPeriod(day.AddDate(0, 0, -1), day))
+
+	event.AllDay = false
+	require.Empty(t, event.OccurrencesByPeriod(day, day.AddDate(0, 0, 1)))
+}
+
+func TestOccurrencesInTimeZone(t *testing.T) {
+	// Clocks go forward on 2024-03-31 in Berlin, occurrences keep their wall clock.
+	endTime := parseTime("2024-03-30T10:00:00Z")
+	event := &Event{
+		StartTime:  parseTime("2024-03-30T09:00:00Z"),
+		EndTime:    &endTime,
+		TimeZone:   "Europe/Berlin",
+		Recurrence: &Recurrence{Frequency: FrequencyDaily, Count: 2},
+	}
+	occurrences := event.OccurrencesByRange(event.StartTime, event.StartTime.AddDate(0, 0, 2))
+	require.Len(t, occurrences, 2)
+	require.Equal(t, parseTime("2024-03-31T08:00:00Z"), occurrences[1].StartTime)
+	require.Equal(t, parseTime("2024-03-31T09:00:00Z"), *occurrences[1].EndTime)
+	require.Equal(t, parseTime("2024-03-31T08:00:00Z"), *event.RecurrenceEnd())
+
+	event = &Event{
+		StartTime:  parseTime("2024-03-30T00:00:00Z"),
+		TimeZone:   "Europe/Berlin",
+		AllDay:     true,
+		Recurrence: &Recurrence{Frequency: FrequencyDaily, Count: 2},
+	}
+	event.NormalizeTime()
+	occurrences = event.OccurrencesByRange(event.StartTime, event.StartTime.AddDate(0, 0, 2))
+	require.Len(t, occurrences, 2)
+	// The day of the change takes 23 hours.
+	require.Equal(t, parseTime("2024-03-30T23:00:00Z"), occurrences[1].StartTime)
+	require.Equal(t, parseTime("2024-03-31T22:00:00Z"), *occurrences[1].EndTime)
+}
+
+func TestNotificationFormatEventDate(t *testing.T) {
+	n := &Notification{EventDate: parseTime("2024-12-24T23:00:00Z"), TimeZone: "Europe/Berlin"}
+	require.Equal(t, "Wed, 25 Dec 2024 00:00:00 CET", n.FormatEventDate())
+	n.AllDay = true
+	require.Equal(t, "Wed, 25 Dec 2024", n.FormatEventDate())
+	n.TimeZone = ""
+	require.Equal(t, "Tue, 24 Dec 2024", n.FormatEventDate())
+}
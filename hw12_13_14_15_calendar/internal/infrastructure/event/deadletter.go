@@ -0,0 +1,183 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// DeadLetterExchange routes dead letters to the dead-letter queues by names of the source queues.
+	DeadLetterExchange = "dead-letter"
+	// AttemptHeader is a number of the delivery attempt, the first attempt has no header.
+	AttemptHeader = "x-attempt"
+	// ErrorHeader is an error of the last failed attempt.
+	ErrorHeader = "x-error"
+)
+
+// DeadLetterQueueName returns a name of the dead-letter queue of the queue.
+func DeadLetterQueueName(queue string) string {
+	return queue + ".dlq"
+}
+
+// retryQueueName returns a name of the queue holding retried messages for the retry delay.
+func retryQueueName(queue string) string {
+	return queue + ".retry"
+}
+
+// headerInt returns an integer header value, 0 if it's not set.
+func headerInt(headers amqp.Table, key string) int {
+	switch v := headers[key].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
+
+// attempt returns a number of the delivery attempt of the message.
+func attempt(headers amqp.Table) int {
+	if n := headerInt(headers, AttemptHeader); n > 0 {
+		return n
+	}
+	return 1
+}
+
+type rabbitDelivery struct {
+	client   *rabbitClient
+	queue    string
+	delivery amqp.Delivery
+}
+
+// Body returns the message body.
+func (d *rabbitDelivery) Body() []byte {
+	return d.delivery.Body
+}
+
+// Timestamp returns the time the message was published to the queue.
+func (d *rabbitDelivery) Timestamp() time.Time {
+	return d.delivery.Timestamp
+}
+
+// Context returns a copy of the parent context continuing the trace of the message headers.
+func (d *rabbitDelivery) Context(parent context.Context) context.Context {
+	return otel.GetTextMapPropagator().Extract(parent, headersCarrier(d.delivery.Headers))
+}
+
+// Attempt returns a number of the delivery attempt starting from 1.
+func (d *rabbitDelivery) Attempt() int {
+	return attempt(d.delivery.Headers)
+}
+
+// Ack acknowledges the handled message.
+func (d *rabbitDelivery) Ack() error {
+	return d.delivery.Ack(false)
+}
+
+// Retry publishes the message with the next attempt number to the retry queue, which returns it to the queue
+// after the retry delay. The message is moved to the dead-letter queue after the last attempt.
+func (d *rabbitDelivery) Retry(reason error) error {
+	if d.Attempt() >= common.Config.Broker.MaxAttempts {
+		return d.Reject(reason)
+	}
+	headers := amqp.Table{AttemptHeader: int64(d.Attempt() + 1), ErrorHeader: reason.Error()}
+	err := d.client.publish(d.Context(context.Background()), "", retryQueueName(d.queue), d.delivery.Body, headers)
+	if common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to publish a retry, requeue the message: %v", err)
+		return d.delivery.Nack(false, true)
+	}
+	return d.delivery.Ack(false)
+}
+
+// Reject publishes the message with the error to the dead-letter queue. If it fails, the message is rejected
+// and the broker dead-letters it without the error.
+func (d *rabbitDelivery) Reject(reason error) error {
+	headers := amqp.Table{AttemptHeader: int64(d.Attempt()), ErrorHeader: reason.Error()}
+	err := d.client.publish(d.Context(context.Background()), DeadLetterExchange, d.queue, d.delivery.Body, headers)
+	if common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to publish a dead letter, reject the message: %v", err)
+		return d.delivery.Nack(false, false)
+	}
+	common.Logger.Warn().Msgf("message of queue %q moved to the dead-letter queue: %v", d.queue, reason)
+	return d.delivery.Ack(false)
+}
+
+// DeadLetters returns up to limit dead letters of the queue, they are left in the dead-letter queue.
+func (client *rabbitClient) DeadLetters(queue string, limit int) ([]*domain.DeadLetter, error) {
+	if err := client.declareQueue(queue); common.IsErr(err) {
+		return nil, err
+	}
+	var (
+		letters    []*domain.DeadLetter
+		deliveries []amqp.Delivery
+	)
+	defer func() {
+		for _, d := range deliveries {
+			if err := d.Nack(false, true); common.IsErr(err) {
+				common.Logger.Error().Msgf("failed to return a dead letter: %v", err)
+			}
+		}
+	}()
+	for len(deliveries) < limit {
+		d, ok, err := client.channel.Get(DeadLetterQueueName(queue), false)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		deliveries = append(deliveries, d)
+		letter := &domain.DeadLetter{
+			Queue:    queue,
+			Body:     d.Body,
+			Attempts: attempt(d.Headers),
+			Time:     d.Timestamp,
+		}
+		letter.Error, _ = d.Headers[ErrorHeader].(string)
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
+
+// Replay moves up to limit dead letters back to the queue with reset attempts and returns their number.
+func (client *rabbitClient) Replay(ctx context.Context, queue string, limit int) (int, error) {
+	if err := client.declareQueue(queue); common.IsErr(err) {
+		return 0, err
+	}
+	count := 0
+	for count < limit {
+		d, ok, err := client.channel.Get(DeadLetterQueueName(queue), false)
+		if common.IsErr(err) {
+			return count, err
+		}
+		if !ok {
+			break
+		}
+		replayCtx := otel.GetTextMapPropagator().Extract(ctx, headersCarrier(d.Headers))
+		err = client.publish(replayCtx, "", queue, d.Body, nil)
+		if common.IsErr(err) {
+			if nackErr := d.Nack(false, true); common.IsErr(nackErr) {
+				common.Logger.Error().Msgf("failed to return a dead letter: %v", nackErr)
+			}
+			return count, fmt.Errorf("failed to replay a dead letter: %w", err)
+		}
+		if err = d.Ack(false); common.IsErr(err) {
+			return count, err
+		}
+		count++
+	}
+	common.Logger.Info().Msgf("replayed %d dead letters to queue %q", count, queue)
+	return count, nil
+}
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttempt(t *testing.T) {
+	require.Equal(t, 1, attempt(nil))
+	require.Equal(t, 1, attempt(amqp.Table{AttemptHeader: int32(0)}))
+	require.Equal(t, 2, attempt(amqp.Table{AttemptHeader: int32(2)}))
+	require.Equal(t, 3, attempt(amqp.Table{AttemptHeader: int64(3)}))
+	require.Equal(t, 4, attempt(amqp.Table{AttemptHeader: "4"}))
+	require.Equal(t, 1, attempt(amqp.Table{AttemptHeader: "x"}))
+}
+
+func TestQueueNames(t *testing.T) {
+	require.Equal(t, "events.dlq", DeadLetterQueueName("events"))
+	require.Equal(t, "events.retry", retryQueueName("events"))
+}
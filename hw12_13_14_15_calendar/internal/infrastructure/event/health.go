@@ -0,0 +1,34 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var errClientsConnecting = errors.New("broker clients are connecting")
+
+// ClientsCheck is a readiness check of broker clients, it fails until the clients are set
+// since the rabbitmq clients are created when they are connected.
+type ClientsCheck struct {
+	clients atomic.Pointer[[]Client]
+}
+
+// Set sets the clients to check.
+func (c *ClientsCheck) Set(clients ...Client) {
+	c.clients.Store(&clients)
+}
+
+// Check returns an error if the clients aren't set or any of them can't publish or consume messages.
+func (c *ClientsCheck) Check(ctx context.Context) error {
+	clients := c.clients.Load()
+	if clients == nil {
+		return errClientsConnecting
+	}
+	for _, client := range *clients {
+		if err := client.Check(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientsCheck(t *testing.T) {
+	broker, err := newMemoryBroker("")
+	require.NoError(t, err)
+	check := &ClientsCheck{}
+	require.ErrorIs(t, check.Check(context.Background()), errClientsConnecting)
+
+	consumer, producer := newMemoryClient(broker), newMemoryClient(broker)
+	check.Set(consumer, producer)
+	require.NoError(t, check.Check(context.Background()))
+	require.NoError(t, producer.Close())
+	require.ErrorIs(t, check.Check(context.Background()), errClientClosed)
+	require.NoError(t, consumer.Close())
+}
@@ -0,0 +1,547 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// Operations of the memory broker log.
+const (
+	opPublish = "publish"
+	opAck     = "ack"
+	opDead    = "dead"
+	opReplay  = "replay"
+)
+
+type memoryMessage struct {
+	ID      uint64
+	Queue   string
+	Body    []byte
+	Attempt int
+	Error   string `json:",omitempty"`
+	Time    time.Time
+	// TraceContext is a trace context of the publisher.
+	TraceContext map[string]string `json:",omitempty"`
+}
+
+// memoryLogRecord is a record of the memory broker log, the message is set for the publish operation only.
+type memoryLogRecord struct {
+	Op      string
+	Queue   string
+	ID      uint64
+	Error   string         `json:",omitempty"`
+	Message *memoryMessage `json:",omitempty"`
+}
+
+type memoryQueue struct {
+	ready   []*memoryMessage
+	unacked map[uint64]*memoryMessage
+	dead    []*memoryMessage
+	// delayed are retried messages which become ready after the retry delay.
+	delayed map[uint64]*memoryMessage
+	// signal is closed and replaced when a message becomes ready.
+	signal chan struct{}
+}
+
+func newMemoryQueue() *memoryQueue {
+	return &memoryQueue{
+		unacked: make(map[uint64]*memoryMessage),
+		delayed: make(map[uint64]*memoryMessage),
+		signal:  make(chan struct{}),
+	}
+}
+
+// logCompactionRecords is a number of the log records after which the log is compacted
+// once most of them are records of settled messages.
+const logCompactionRecords = 1000
+
+// memoryBroker is an in-process broker with competing consumers and redelivery of unacked messages,
+// it's durable if it's backed by the log file.
+type memoryBroker struct {
+	mu     sync.Mutex
+	lastID uint64
+	queues map[string]*memoryQueue
+	path   string
+	log    *os.File
+	// records is a number of the log records since the log was compacted.
+	records int
+}
+
+var (
+	sharedBroker     *memoryBroker
+	sharedBrokerOnce sync.Once
+	errClientClosed  = errors.New("memory broker client is closed")
+)
+
+// newMemoryBroker returns a new broker, its state is restored from the log file if the path is set.
+func newMemoryBroker(path string) (*memoryBroker, error) {
+	broker := &memoryBroker{queues: make(map[string]*memoryQueue)}
+	if path == "" {
+		return broker, nil
+	}
+	broker.path = filepath.Clean(path)
+	if err := broker.restore(); common.IsErr(err) {
+		return nil, err
+	}
+	return broker, nil
+}
+
+func (b *memoryBroker) queue(name string) *memoryQueue {
+	q, ok := b.queues[name]
+	if !ok {
+		q = newMemoryQueue()
+		b.queues[name] = q
+	}
+	return q
+}
+
+// restore replays the log file and compacts it to the records of the restored messages.
+func (b *memoryBroker) restore() error {
+	messages := make(map[uint64]*memoryMessage)
+	dead := make(map[uint64]bool)
+	f, err := os.Open(b.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			var r memoryLogRecord
+			if err = json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				f.Close()
+				return fmt.Errorf("invalid broker log record: %w", err)
+			}
+			switch r.Op {
+			case opPublish:
+				messages[r.Message.ID] = r.Message
+			case opAck:
+				delete(messages, r.ID)
+				delete(dead, r.ID)
+			case opDead:
+				if m, ok := messages[r.ID]; ok {
+					m.Error = r.Error
+					dead[r.ID] = true
+				}
+			case opReplay:
+				if m, ok := messages[r.ID]; ok {
+					m.Attempt, m.Error = 1, ""
+					delete(dead, r.ID)
+				}
+			}
+		}
+		f.Close()
+		if err = scanner.Err(); err != nil {
+			return err
+		}
+	}
+	ids := make([]uint64, 0, len(messages))
+	for id := range messages {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		m := messages[id]
+		q := b.queue(m.Queue)
+		if dead[id] {
+			q.dead = append(q.dead, m)
+		} else {
+			q.ready = append(q.ready, m)
+		}
+		b.lastID = id
+	}
+	return b.compact()
+}
+
+// compact replaces the log with the records of the messages which are not settled, the broker lock
+// must be held. The current log is kept if the compacted one can't be written.
+func (b *memoryBroker) compact() error {
+	var messages []*memoryMessage
+	dead := make(map[uint64]bool)
+	for _, q := range b.queues {
+		messages = append(messages, q.ready...)
+		for _, m := range q.unacked {
+			messages = append(messages, m)
+		}
+		for _, m := range q.delayed {
+			messages = append(messages, m)
+		}
+		for _, m := range q.dead {
+			messages = append(messages, m)
+			dead[m.ID] = true
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	tmp := b.path + ".tmp"
+	w, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	records := 0
+	encoder := json.NewEncoder(w)
+	for _, m := range messages {
+		err = encoder.Encode(memoryLogRecord{Op: opPublish, Queue: m.Queue, ID: m.ID, Message: m})
+		records++
+		if err == nil && dead[m.ID] {
+			err = encoder.Encode(memoryLogRecord{Op: opDead, Queue: m.Queue, ID: m.ID, Error: m.Error})
+			records++
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmp, b.path)
+	}
+	if err != nil {
+		w.Close()
+		os.Remove(tmp)
+		return err
+	}
+	// The renamed file stays open for appending.
+	if b.log != nil {
+		b.log.Close()
+	}
+	b.log, b.records = w, records
+	return nil
+}
+
+// size returns a number of the messages which are not settled, the broker lock must be held.
+func (b *memoryBroker) size() int {
+	size := 0
+	for _, q := range b.queues {
+		size += len(q.ready) + len(q.unacked) + len(q.delayed) + len(q.dead)
+	}
+	return size
+}
+
+// write appends the record to the log, the broker lock must be held. The log is compacted after the record
+// if most of its records are of settled messages, a failed compaction keeps appending to the current log.
+func (b *memoryBroker) write(r memoryLogRecord) error {
+	if b.log == nil {
+		return nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err = b.log.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	b.records++
+	if r.Op == opAck && b.records >= logCompactionRecords && b.records > 2*b.size() {
+		if err := b.compact(); common.IsErr(err) {
+			common.Logger.Error().Msgf("failed to compact the broker log: %v", err)
+		}
+	}
+	return nil
+}
+
+// push makes the message ready, the broker lock must be held.
+func (b *memoryBroker) push(m *memoryMessage) {
+	q := b.queue(m.Queue)
+	q.ready = append(q.ready, m)
+	close(q.signal)
+	q.signal = make(chan struct{})
+}
+
+// add writes the new message to the log and makes it ready if needed.
+func (b *memoryBroker) add(m *memoryMessage, ready bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastID++
+	m.ID, m.Time = b.lastID, time.Now().UTC()
+	if err := b.write(memoryLogRecord{Op: opPublish, Queue: m.Queue, ID: m.ID, Message: m}); common.IsErr(err) {
+		return err
+	}
+	if ready {
+		b.push(m)
+	} else {
+		b.queue(m.Queue).delayed[m.ID] = m
+	}
+	return nil
+}
+
+// next returns the next ready message of the queue marking it unacked,
+// or a channel which is closed when a message becomes ready.
+func (b *memoryBroker) next(queue string) (*memoryMessage, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q := b.queue(queue)
+	if len(q.ready) == 0 {
+		return nil, q.signal
+	}
+	m := q.ready[0]
+	q.ready[0] = nil
+	q.ready = q.ready[1:]
+	q.unacked[m.ID] = m
+	return m, nil
+}
+
+// settle removes the unacked message, it returns false if the message is already settled.
+func (b *memoryBroker) settle(m *memoryMessage) bool {
+	q := b.queue(m.Queue)
+	if _, ok := q.unacked[m.ID]; !ok {
+		return false
+	}
+	delete(q.unacked, m.ID)
+	return true
+}
+
+func (b *memoryBroker) ack(m *memoryMessage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.settle(m) {
+		return nil
+	}
+	return b.write(memoryLogRecord{Op: opAck, Queue: m.Queue, ID: m.ID})
+}
+
+// requeue returns the unacked message to the queue for redelivery.
+func (b *memoryBroker) requeue(m *memoryMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.settle(m) {
+		b.push(m)
+	}
+}
+
+// retry replaces the unacked message with its next attempt, which becomes ready after the delay.
+func (b *memoryBroker) retry(m *memoryMessage, reason error, delay time.Duration) error {
+	next := &memoryMessage{
+		Queue: m.Queue, Body: m.Body, Attempt: m.Attempt + 1, Error: reason.Error(), TraceContext: m.TraceContext,
+	}
+	if err := b.add(next, false); common.IsErr(err) {
+		return err
+	}
+	if err := b.ack(m); common.IsErr(err) {
+		return err
+	}
+	time.AfterFunc(delay, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.queue(next.Queue).delayed, next.ID)
+		b.push(next)
+	})
+	return nil
+}
+
+func (b *memoryBroker) reject(m *memoryMessage, reason error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.settle(m) {
+		return nil
+	}
+	m.Error = reason.Error()
+	q := b.queue(m.Queue)
+	q.dead = append(q.dead, m)
+	return b.write(memoryLogRecord{Op: opDead, Queue: m.Queue, ID: m.ID, Error: m.Error})
+}
+
+func (b *memoryBroker) deadLetters(queue string, limit int) []*domain.DeadLetter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q := b.queue(queue)
+	letters := make([]*domain.DeadLetter, 0, min(limit, len(q.dead)))
+	for _, m := range q.dead {
+		if len(letters) == limit {
+			break
+		}
+		letters = append(letters, &domain.DeadLetter{
+			Queue: queue, Body: m.Body, Attempts: m.Attempt, Error: m.Error, Time: m.Time,
+		})
+	}
+	return letters
+}
+
+func (b *memoryBroker) replay(queue string, limit int) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q := b.queue(queue)
+	count := 0
+	for len(q.dead) > 0 && count < limit {
+		m := q.dead[0]
+		if err := b.write(memoryLogRecord{Op: opReplay, Queue: queue, ID: m.ID}); common.IsErr(err) {
+			return count, err
+		}
+		q.dead = q.dead[1:]
+		m.Attempt, m.Error = 1, ""
+		b.push(m)
+		count++
+	}
+	return count, nil
+}
+
+func (b *memoryBroker) close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.log == nil {
+		return nil
+	}
+	return b.log.Close()
+}
+
+type memoryClient struct {
+	broker    *memoryBroker
+	done      chan struct{}
+	closeOnce sync.Once
+	mu        sync.Mutex
+	// delivered are messages delivered to the consumers of the client which are not settled yet.
+	delivered map[uint64]*memoryMessage
+}
+
+// NewMemoryClient returns a client of the in-process broker shared by all clients of the process.
+func NewMemoryClient() Client {
+	sharedBrokerOnce.Do(func() {
+		var err error
+		sharedBroker, err = newMemoryBroker(common.Config.Broker.FilePath)
+		if common.IsErr(err) {
+			common.Logger.Fatal().Msgf("failed to open the broker log: %v", err)
+		}
+	})
+	return newMemoryClient(sharedBroker)
+}
+
+func newMemoryClient(broker *memoryBroker) *memoryClient {
+	return &memoryClient{broker: broker, done: make(chan struct{}), delivered: make(map[uint64]*memoryMessage)}
+}
+
+// Check returns an error if the client is closed.
+func (client *memoryClient) Check(context.Context) error {
+	select {
+	case <-client.done:
+		return errClientClosed
+	default:
+		return nil
+	}
+}
+
+// Publish publishes a message to the queue.
+func (client *memoryClient) Publish(ctx context.Context, queueName string, data []byte) error {
+	m := &memoryMessage{Queue: queueName, Body: data, Attempt: 1, TraceContext: common.TraceCarrier(ctx)}
+	err := client.broker.add(m, true)
+	if common.IsErr(err) {
+		return fmt.Errorf("failed to publish to queue: %w", err)
+	}
+	return nil
+}
+
+// Consume consumes messages from the queue, they must be acked, retried or rejected after handling.
+// Messages of the client which are not settled are redelivered to other consumers after the client is closed.
+func (client *memoryClient) Consume(name string) (<-chan domain.Delivery, error) {
+	ch := make(chan domain.Delivery)
+	go func() {
+		for {
+			m, signal := client.broker.next(name)
+			if m == nil {
+				select {
+				case <-signal:
+					continue
+				case <-client.done:
+					return
+				}
+			}
+			client.mu.Lock()
+			client.delivered[m.ID] = m
+			client.mu.Unlock()
+			select {
+			case ch <- &memoryDelivery{client: client, message: m}:
+			case <-client.done:
+				client.forget(m)
+				client.broker.requeue(m)
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (client *memoryClient) forget(m *memoryMessage) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	delete(client.delivered, m.ID)
+}
+
+// DeadLetters returns up to limit dead letters of the queue, they are left in the dead-letter queue.
+func (client *memoryClient) DeadLetters(queue string, limit int) ([]*domain.DeadLetter, error) {
+	return client.broker.deadLetters(queue, limit), nil
+}
+
+// Replay moves up to limit dead letters back to the queue with reset attempts and returns their number.
+func (client *memoryClient) Replay(_ context.Context, queue string, limit int) (int, error) {
+	return client.broker.replay(queue, limit)
+}
+
+// Close stops the consumers of the client and returns its unsettled messages to the queues.
+func (client *memoryClient) Close() error {
+	client.closeOnce.Do(func() {
+		close(client.done)
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		for id, m := range client.delivered {
+			client.broker.requeue(m)
+			delete(client.delivered, id)
+		}
+	})
+	return nil
+}
+
+type memoryDelivery struct {
+	client  *memoryClient
+	message *memoryMessage
+}
+
+// Body returns the message body.
+func (d *memoryDelivery) Body() []byte {
+	return d.message.Body
+}
+
+// Timestamp returns the time the message was published to the queue.
+func (d *memoryDelivery) Timestamp() time.Time {
+	return d.message.Time
+}
+
+// Context returns a copy of the parent context continuing the trace of the message publisher.
+func (d *memoryDelivery) Context(parent context.Context) context.Context {
+	return common.ContextWithTrace(parent, d.message.TraceContext)
+}
+
+// Attempt returns a number of the delivery attempt starting from 1.
+func (d *memoryDelivery) Attempt() int {
+	return d.message.Attempt
+}
+
+// Ack acknowledges the handled message.
+func (d *memoryDelivery) Ack() error {
+	d.client.forget(d.message)
+	return d.client.broker.ack(d.message)
+}
+
+// Retry returns the message to the queue after the retry delay,
+// it's moved to the dead-letter queue after the last attempt.
+func (d *memoryDelivery) Retry(reason error) error {
+	if d.Attempt() >= common.Config.Broker.MaxAttempts {
+		return d.Reject(reason)
+	}
+	d.client.forget(d.message)
+	return d.client.broker.retry(d.message, reason, time.Duration(common.Config.Broker.RetryDelay)*time.Second)
+}
+
+// Reject moves the message to the dead-letter queue.
+func (d *memoryDelivery) Reject(reason error) error {
+	d.client.forget(d.message)
+	common.Logger.Warn().Msgf("message of queue %q moved to the dead-letter queue: %v", d.message.Queue, reason)
+	return d.client.broker.reject(d.message, reason)
+}
@@ -0,0 +1,200 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, ch <-chan domain.Delivery) domain.Delivery {
+	t.Helper()
+	select {
+	case d := <-ch:
+		return d
+	case <-time.After(time.Second):
+		require.FailNow(t, "message is not received")
+		return nil
+	}
+}
+
+func requireNoMessage(t *testing.T, ch <-chan domain.Delivery) {
+	t.Helper()
+	select {
+	case d := <-ch:
+		require.FailNowf(t, "unexpected message", "%s", d.Body())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMemoryClient_CompetingConsumers(t *testing.T) {
+	broker, err := newMemoryBroker("")
+	require.NoError(t, err)
+	producer, first, second := newMemoryClient(broker), newMemoryClient(broker), newMemoryClient(broker)
+	defer first.Close()
+	defer second.Close()
+	firstCh, err := first.Consume("events")
+	require.NoError(t, err)
+	secondCh, err := second.Consume("events")
+	require.NoError(t, err)
+
+	require.NoError(t, producer.Publish(context.Background(), "events", []byte("1")))
+	require.NoError(t, producer.Publish(context.Background(), "events", []byte("2")))
+	received := map[string]int{}
+	for i := 0; i < 2; i++ {
+		var d domain.Delivery
+		select {
+		case d = <-firstCh:
+		case d = <-secondCh:
+		case <-time.After(time.Second):
+			require.FailNow(t, "message is not received")
+		}
+		require.Equal(t, 1, d.Attempt())
+		require.NoError(t, d.Ack())
+		received[string(d.Body())]++
+	}
+	require.Equal(t, map[string]int{"1": 1, "2": 1}, received)
+	requireNoMessage(t, firstCh)
+	requireNoMessage(t, secondCh)
+}
+
+func TestMemoryClient_RedeliveryAfterClose(t *testing.T) {
+	broker, err := newMemoryBroker("")
+	require.NoError(t, err)
+	client := newMemoryClient(broker)
+	ch, err := client.Consume("events")
+	require.NoError(t, err)
+	require.NoError(t, client.Publish(context.Background(), "events", []byte("1")))
+	receive(t, ch)
+	require.NoError(t, client.Check(context.Background()))
+	require.NoError(t, client.Close())
+	require.ErrorIs(t, client.Check(context.Background()), errClientClosed)
+
+	other := newMemoryClient(broker)
+	defer other.Close()
+	otherCh, err := other.Consume("events")
+	require.NoError(t, err)
+	d := receive(t, otherCh)
+	require.Equal(t, []byte("1"), d.Body())
+	require.NoError(t, d.Ack())
+}
+
+func TestMemoryClient_RetryAndDeadLetters(t *testing.T) {
+	maxAttempts, retryDelay := common.Config.Broker.MaxAttempts, common.Config.Broker.RetryDelay
+	common.Config.Broker.MaxAttempts, common.Config.Broker.RetryDelay = 2, 0
+	defer func() {
+		common.Config.Broker.MaxAttempts, common.Config.Broker.RetryDelay = maxAttempts, retryDelay
+	}()
+	broker, err := newMemoryBroker("")
+	require.NoError(t, err)
+	client := newMemoryClient(broker)
+	defer client.Close()
+	ch, err := client.Consume("events")
+	require.NoError(t, err)
+	require.NoError(t, client.Publish(context.Background(), "events", []byte("1")))
+
+	require.NoError(t, receive(t, ch).Retry(errors.New("timeout")))
+	d := receive(t, ch)
+	require.Equal(t, 2, d.Attempt())
+	require.NoError(t, d.Retry(errors.New("timeout")))
+	requireNoMessage(t, ch)
+
+	letters, err := client.DeadLetters("events", 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Equal(t, []byte("1"), letters[0].Body)
+	require.Equal(t, 2, letters[0].Attempts)
+	require.Equal(t, "timeout", letters[0].Error)
+
+	count, err := client.Replay(context.Background(), "events", 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	d = receive(t, ch)
+	require.Equal(t, 1, d.Attempt())
+	require.NoError(t, d.Reject(errors.New("invalid")))
+	letters, err = client.DeadLetters("events", 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Equal(t, "invalid", letters[0].Error)
+}
+
+func TestMemoryBroker_Log(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broker.log")
+	broker, err := newMemoryBroker(path)
+	require.NoError(t, err)
+	client := newMemoryClient(broker)
+	ch, err := client.Consume("events")
+	require.NoError(t, err)
+	for _, body := range []string{"acked", "dead", "unacked"} {
+		require.NoError(t, client.Publish(context.Background(), "events", []byte(body)))
+	}
+	require.NoError(t, client.Publish(context.Background(), "events_result", []byte("ready")))
+	require.NoError(t, receive(t, ch).Ack())
+	require.NoError(t, receive(t, ch).Reject(errors.New("invalid")))
+	receive(t, ch)
+	require.NoError(t, client.Check(context.Background()))
+	require.NoError(t, client.Close())
+	require.ErrorIs(t, client.Check(context.Background()), errClientClosed)
+	require.NoError(t, broker.close())
+
+	for i := 0; i < 2; i++ {
+		broker, err = newMemoryBroker(path)
+		require.NoError(t, err)
+		client = newMemoryClient(broker)
+		letters, err := client.DeadLetters("events", 10)
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+		require.Equal(t, "invalid", letters[0].Error)
+		for queue, body := range map[string]string{"events": "unacked", "events_result": "ready"} {
+			ch, err = client.Consume(queue)
+			require.NoError(t, err)
+			require.Equal(t, []byte(body), receive(t, ch).Body())
+			requireNoMessage(t, ch)
+		}
+		require.NoError(t, client.Close())
+		require.NoError(t, broker.close())
+	}
+}
+
+func TestMemoryBroker_LogCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broker.log")
+	broker, err := newMemoryBroker(path)
+	require.NoError(t, err)
+	client := newMemoryClient(broker)
+	ch, err := client.Consume("events")
+	require.NoError(t, err)
+	require.NoError(t, client.Publish(context.Background(), "events", []byte("unacked")))
+	receive(t, ch)
+	for i := 0; i < logCompactionRecords; i++ {
+		require.NoError(t, client.Publish(context.Background(), "events", []byte("acked")))
+		require.NoError(t, receive(t, ch).Ack())
+	}
+	require.NoError(t, client.Publish(context.Background(), "events", []byte("ready")))
+
+	// The log is compacted while the broker is running, the records of acked messages are dropped.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Less(t, bytes.Count(data, []byte("\n")), logCompactionRecords)
+	require.NoError(t, client.Close())
+	require.NoError(t, broker.close())
+
+	broker, err = newMemoryBroker(path)
+	require.NoError(t, err)
+	client = newMemoryClient(broker)
+	defer client.Close()
+	ch, err = client.Consume("events")
+	require.NoError(t, err)
+	for _, body := range []string{"unacked", "ready"} {
+		d := receive(t, ch)
+		require.Equal(t, []byte(body), d.Body())
+		require.NoError(t, d.Ack())
+	}
+	requireNoMessage(t, ch)
+}
@@ -2,13 +2,16 @@ package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
 )
 
 type rabbitClient struct {
@@ -16,17 +19,36 @@ type rabbitClient struct {
 	channel    *amqp.Channel
 	initConnCh chan interface{}
 	done       chan interface{}
-	active     bool
+	active     atomic.Bool
 }
 
-// RabbitClient is the interface for the rabbitmq client.
-type RabbitClient interface {
+var errClientInactive = errors.New("rabbitmq client is not active")
+
+// Client is the interface for message broker clients.
+type Client interface {
 	domain.EventProducer
 	domain.EventConsumer
+	domain.DeadLetterQueue
+	// Check returns an error if the client can't publish or consume messages.
+	Check(ctx context.Context) error
+}
+
+// Broker types of the config.
+const (
+	BrokerRabbitMQ = "rabbitmq"
+	BrokerMemory   = "memory"
+)
+
+// NewClient returns a new client of the broker selected in the config.
+func NewClient() Client {
+	if common.Config.Broker.Type == BrokerMemory {
+		return NewMemoryClient()
+	}
+	return NewRabbitClient()
 }
 
 // NewRabbitClient returns a new instance of the rabbitmq client.
-func NewRabbitClient() RabbitClient {
+func NewRabbitClient() Client {
 	client := &rabbitClient{}
 	client.done = make(chan interface{})
 	client.initConnCh = make(chan interface{}, 1)
@@ -89,14 +111,16 @@ func (client *rabbitClient) listenNotify() {
 	}()
 }
 
+// initClient connects the client until it succeeds or the client is closed, reconnections are counted.
 func (client *rabbitClient) initClient() {
+	reconnect := client.conn != nil
 initLoop:
 	for {
 		select {
 		case <-client.done:
 			return
 		default:
-			client.active = false
+			client.active.Store(false)
 			err := client.setConnect()
 			if common.IsErr(err) {
 				common.Logger.Error().Msgf("rabbitmq conntection error: %v", err)
@@ -109,7 +133,10 @@ initLoop:
 				time.Sleep(time.Second)
 				continue
 			}
-			client.active = true
+			client.active.Store(true)
+			if reconnect {
+				common.RabbitReconnects.Inc()
+			}
 			break initLoop
 		}
 	}
@@ -118,13 +145,20 @@ initLoop:
 	common.Logger.Info().Msg("rabbitmq client is active")
 }
 
-// Consume consumes messages from the queue.
-func (client *rabbitClient) Consume(name string) (<-chan []byte, error) {
-	ch := make(chan []byte)
+// Consume consumes messages from the queue, they must be acked, retried or rejected after handling.
+// The queue is declared again after reconnections.
+func (client *rabbitClient) Consume(name string) (<-chan domain.Delivery, error) {
+	if !client.active.Load() {
+		return nil, errClientInactive
+	}
+	if err := client.declareQueue(name); common.IsErr(err) {
+		return nil, fmt.Errorf("failed to declare a queue: %w", err)
+	}
+	ch := make(chan domain.Delivery)
 	var msg <-chan amqp.Delivery
 	go func() {
 		for {
-			if !client.active {
+			if !client.active.Load() {
 				continue
 			}
 			select {
@@ -132,19 +166,16 @@ func (client *rabbitClient) Consume(name string) (<-chan []byte, error) {
 				return
 			case <-client.initConnCh:
 				for {
-					q, err := client.channel.QueueDeclare(
-						name,
-						true,
-						false,
-						false,
-						false,
-						amqp.Table{"x-queue-mode": "lazy"},
-					)
+					err := client.declareQueue(name)
 					if common.IsErr(err) {
 						common.Logger.Error().Msgf("failed to declare a queue: %v", err)
 					}
+					err = client.channel.Qos(common.Config.RabbitMQ.PrefetchCount, 0, false)
+					if common.IsErr(err) {
+						common.Logger.Error().Msgf("failed to set prefetch count: %v", err)
+					}
 					msg, err = client.channel.Consume(
-						q.Name,
+						name,
 						uuid.New().String(),
 						false,
 						false,
@@ -158,12 +189,16 @@ func (client *rabbitClient) Consume(name string) (<-chan []byte, error) {
 					}
 					break
 				}
-			case d := <-msg:
+			case d, ok := <-msg:
+				if !ok {
+					msg = nil
+					continue
+				}
 				common.Logger.Info().Msgf("received a message: %s", d.Body)
-				ch <- d.Body
-				err := d.Ack(false)
-				if common.IsErr(err) {
-					common.Logger.Error().Msgf("failed to ack: %v", err)
+				select {
+				case ch <- &rabbitDelivery{client: client, queue: name, delivery: d}:
+				case <-client.done:
+					return
 				}
 			}
 		}
@@ -171,34 +206,82 @@ func (client *rabbitClient) Consume(name string) (<-chan []byte, error) {
 	return ch, nil
 }
 
-// Publish publishes a message to the queue.
-func (client *rabbitClient) Publish(ctx context.Context, queueName string, data []byte) error {
-	if !client.active {
-		return fmt.Errorf("rabbitmq client is not active")
+// declareQueue declares the durable queue with its retry queue, dead-letter exchange and dead-letter queue,
+// expired retries return to the queue. The queue keeps the arguments it was created with, redeclaring it
+// with other ones fails, so the dead-letter exchange of messages rejected by the broker is set by the policy
+// of the deployment (see the rabbitmq-policy target of the Makefile).
+func (client *rabbitClient) declareQueue(name string) error {
+	err := client.channel.ExchangeDeclare(DeadLetterExchange, amqp.ExchangeDirect, true, false, false, false, nil)
+	if common.IsErr(err) {
+		return fmt.Errorf("failed to declare the dead-letter exchange: %w", err)
+	}
+	_, err = client.channel.QueueDeclare(
+		DeadLetterQueueName(name), true, false, false, false, amqp.Table{"x-queue-mode": "lazy"},
+	)
+	if common.IsErr(err) {
+		return fmt.Errorf("failed to declare the dead-letter queue: %w", err)
+	}
+	err = client.channel.QueueBind(DeadLetterQueueName(name), name, DeadLetterExchange, false, nil)
+	if common.IsErr(err) {
+		return fmt.Errorf("failed to bind the dead-letter queue: %w", err)
 	}
-	q, err := client.channel.QueueDeclare(
-		queueName,
+	_, err = client.channel.QueueDeclare(
+		retryQueueName(name),
 		true,
 		false,
 		false,
 		false,
-		amqp.Table{"x-queue-mode": "lazy"},
+		amqp.Table{
+			"x-queue-mode":              "lazy",
+			"x-message-ttl":             int64(common.Config.Broker.RetryDelay) * 1000,
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": name,
+		},
 	)
 	if common.IsErr(err) {
-		return fmt.Errorf("failed to declare a queue: %w", err)
+		return fmt.Errorf("failed to declare the retry queue: %w", err)
+	}
+	_, err = client.channel.QueueDeclare(name, true, false, false, false, amqp.Table{"x-queue-mode": "lazy"})
+	return err
+}
+
+// publish publishes the message with the headers and the trace context of the context span.
+func (client *rabbitClient) publish(
+	ctx context.Context, exchange, key string, data []byte, headers amqp.Table,
+) error {
+	if !client.active.Load() {
+		return errClientInactive
+	}
+	if headers == nil {
+		headers = amqp.Table{}
 	}
-	err = client.channel.PublishWithContext(
+	otel.GetTextMapPropagator().Inject(ctx, headersCarrier(headers))
+	return client.channel.PublishWithContext(
 		ctx,
-		"",
-		q.Name,
+		exchange,
+		key,
 		false,
 		false,
 		amqp.Publishing{
+			Headers:      headers,
 			MessageId:    uuid.New().String(),
+			Timestamp:    time.Now().UTC(),
 			DeliveryMode: amqp.Persistent,
 			ContentType:  "text/plain",
 			Body:         data,
 		})
+}
+
+// Publish publishes a message to the queue.
+func (client *rabbitClient) Publish(ctx context.Context, queueName string, data []byte) error {
+	if !client.active.Load() {
+		return errClientInactive
+	}
+	err := client.declareQueue(queueName)
+	if common.IsErr(err) {
+		return fmt.Errorf("failed to declare a queue: %w", err)
+	}
+	err = client.publish(ctx, "", queueName, data, nil)
 	if common.IsErr(err) {
 		return fmt.Errorf("failed to publish to queue: %w", err)
 	}
@@ -206,16 +289,20 @@ func (client *rabbitClient) Publish(ctx context.Context, queueName string, data
 	return nil
 }
 
+// Check returns an error if the client isn't connected or its channel is closed.
+func (client *rabbitClient) Check(context.Context) error {
+	if !client.active.Load() || client.channel.IsClosed() {
+		return errClientInactive
+	}
+	return nil
+}
+
 // Close closes the rabbitmq client.
 func (client *rabbitClient) Close() error {
+	close(client.done)
 	err := client.channel.Close()
 	if common.IsErr(err) {
 		return err
 	}
-	err = client.conn.Close()
-	if common.IsErr(err) {
-		return err
-	}
-	close(client.done)
-	return nil
+	return client.conn.Close()
 }
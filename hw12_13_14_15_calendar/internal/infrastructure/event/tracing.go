@@ -0,0 +1,28 @@
+package event
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// headersCarrier adapts AMQP message headers to the trace context propagation.
+type headersCarrier amqp.Table
+
+// Get returns the string value of the header.
+func (c headersCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// Set sets the value of the header.
+func (c headersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys returns the header keys.
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// NewChannels returns the channels enabled in the config, the log channel is always enabled.
+func NewChannels() ([]domain.NotificationChannel, error) {
+	config := common.Config.Notification
+	logChannel, err := OpenFileChannel(config.FilePath)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	channels := []domain.NotificationChannel{logChannel}
+	if config.SMTPHost != "" {
+		channels = append(channels, NewEmailChannel(EmailConfig{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+			From:     config.SMTPFrom,
+		}))
+	}
+	if config.WebhookSecret != "" {
+		timeout := time.Duration(config.WebhookTimeout) * time.Second
+		channels = append(channels, NewWebhookChannel(config.WebhookSecret, timeout))
+	}
+	return channels, nil
+}
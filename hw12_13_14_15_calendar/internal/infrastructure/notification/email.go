@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// EmailConfig is an SMTP server config of the email channel.
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type emailChannel struct {
+	config EmailConfig
+}
+
+// NewEmailChannel returns the channel sending notifications by SMTP.
+func NewEmailChannel(config EmailConfig) domain.NotificationChannel {
+	return &emailChannel{config: config}
+}
+
+// Name returns the name of the channel.
+func (c *emailChannel) Name() string {
+	return domain.ChannelEmail
+}
+
+// message returns the email message of the notification.
+func (c *emailChannel) message(n *domain.Notification, to string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", c.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Reminder: "+n.EventTitle))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	if n.AllDay {
+		fmt.Fprintf(&b, "%s is on %s.\r\n", n.EventTitle, n.FormatEventDate())
+	} else {
+		fmt.Fprintf(&b, "%s starts at %s.\r\n", n.EventTitle, n.FormatEventDate())
+	}
+	return b.Bytes()
+}
+
+// Send sends the notification to the email address.
+func (c *emailChannel) Send(ctx context.Context, n *domain.Notification, address string) error {
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: c.config.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if c.config.Username != "" {
+		auth := smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+		if err = client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err = client.Mail(c.config.From); err != nil {
+		return err
+	}
+	if err = client.Rcpt(address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(c.message(n, address)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+type fileChannel struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileChannel returns the log channel writing notifications as JSON lines to the writer.
+func NewFileChannel(w io.Writer) domain.NotificationChannel {
+	return &fileChannel{w: w}
+}
+
+// OpenFileChannel returns the log channel appending notifications to the file, to stdout if the path is empty.
+func OpenFileChannel(path string) (domain.NotificationChannel, error) {
+	if path == "" {
+		return NewFileChannel(os.Stdout), nil
+	}
+	f, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileChannel(f), nil
+}
+
+// Name returns the name of the channel.
+func (c *fileChannel) Name() string {
+	return domain.ChannelLog
+}
+
+// Send writes the notification.
+func (c *fileChannel) Send(_ context.Context, n *domain.Notification, _ string) error {
+	data, err := json.Marshal(struct {
+		*domain.Notification
+		Time time.Time
+	}{n, time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.w.Write(append(data, '\n'))
+	return err
+}
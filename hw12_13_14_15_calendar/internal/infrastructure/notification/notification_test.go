@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+var testNotification = &domain.Notification{
+	EventID:    "6f2a8f8c-4a7a-4b7c-9b36-0fd1f0a7d3b1",
+	EventTitle: "Daily standup",
+	EventDate:  time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC),
+	UserToSend: 7,
+}
+
+func TestFileChannel(t *testing.T) {
+	var buf bytes.Buffer
+	channel := NewFileChannel(&buf)
+	require.Equal(t, domain.ChannelLog, channel.Name())
+	require.NoError(t, channel.Send(context.Background(), testNotification, ""))
+	require.NoError(t, channel.Send(context.Background(), testNotification, ""))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	var n domain.Notification
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &n))
+	require.Equal(t, *testNotification, n)
+}
+
+func TestWebhookChannel(t *testing.T) {
+	secret := []byte("secret")
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, Sign(secret, r.Header.Get(TimestampHeader), body), r.Header.Get(SignatureHeader))
+		var n domain.Notification
+		require.NoError(t, json.Unmarshal(body, &n))
+		require.Equal(t, *testNotification, n)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	channel := NewWebhookChannel(string(secret), time.Second)
+	require.Equal(t, domain.ChannelWebhook, channel.Name())
+	require.NoError(t, channel.Send(context.Background(), testNotification, server.URL))
+
+	status = http.StatusInternalServerError
+	require.ErrorContains(t, channel.Send(context.Background(), testNotification, server.URL), "500")
+}
+
+func TestSign(t *testing.T) {
+	require.Equal(
+		t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign([]byte("secret"), "1700000000", []byte(`{}`)),
+	)
+}
+
+func TestEmailMessage(t *testing.T) {
+	channel := &emailChannel{config: EmailConfig{From: "calendar@example.com"}}
+	require.Equal(t, domain.ChannelEmail, channel.Name())
+	message := string(channel.message(testNotification, "user@example.com"))
+	require.Contains(t, message, "From: calendar@example.com\r\n")
+	require.Contains(t, message, "To: user@example.com\r\n")
+	require.Contains(t, message, "Subject: Reminder: Daily standup\r\n")
+	require.Contains(t, message, "\r\n\r\nDaily standup starts at Mon, 19 Oct 2026 09:30:00 UTC.\r\n")
+}
+
+func TestEmailMessageOfAllDayEvent(t *testing.T) {
+	channel := &emailChannel{config: EmailConfig{From: "calendar@example.com"}}
+	n := *testNotification
+	n.EventDate = time.Date(2026, time.October, 18, 22, 0, 0, 0, time.UTC)
+	n.TimeZone = "Europe/Berlin"
+	n.AllDay = true
+	message := string(channel.message(&n, "user@example.com"))
+	require.Contains(t, message, "\r\n\r\nDaily standup is on Mon, 19 Oct 2026.\r\n")
+}
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// Headers of webhook requests.
+const (
+	TimestampHeader = "X-Calendar-Timestamp"
+	// SignatureHeader is "sha256=" followed by hex HMAC-SHA256 of the timestamp, a dot and the body.
+	SignatureHeader = "X-Calendar-Signature"
+)
+
+type webhookChannel struct {
+	client *http.Client
+	secret []byte
+}
+
+// NewWebhookChannel returns the channel posting notifications as JSON to webhook URLs.
+func NewWebhookChannel(secret string, timeout time.Duration) domain.NotificationChannel {
+	return &webhookChannel{client: &http.Client{Timeout: timeout}, secret: []byte(secret)}
+}
+
+// Sign returns the signature of the webhook request body.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Name returns the name of the channel.
+func (c *webhookChannel) Name() string {
+	return domain.ChannelWebhook
+}
+
+// Send posts the notification to the URL.
+func (c *webhookChannel) Send(ctx context.Context, n *domain.Notification, address string) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(c.secret, timestamp, body))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
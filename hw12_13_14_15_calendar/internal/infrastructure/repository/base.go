@@ -2,6 +2,8 @@
 package repository
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
@@ -13,8 +15,18 @@ import (
 
 // Use singleton pattern for DB connection.
 var (
-	db      *sqlx.DB
-	cacheDB *freecache.CacheDB
+	db           *sqlx.DB
+	cacheDB      *freecache.CacheDB
+	cacheOutbox  = newMemoryOutbox()
+	cacheHistory = newMemoryHistory(cacheHistorySize)
+	// cacheEventsMu serializes changes of cacheDB events, so their versions are checked and incremented atomically.
+	cacheEventsMu sync.Mutex
+	// cacheCalendars is shared by the calendar and the event cache repositories checking accesses to calendars.
+	cacheCalendars = newCalendarCacheRepository()
+	// cacheTargets is shared by the API and the sender running in a single process.
+	cacheTargets = NewNotificationTargetCacheRepository()
+	// cacheIdempotency keeps request results apart from the events of cacheDB.
+	cacheIdempotency domain.IdempotencyRepository
 )
 
 func init() {
@@ -29,6 +41,7 @@ func init() {
 		db.SetConnMaxLifetime(5 * time.Minute)
 	} else {
 		cacheDB = freecache.NewCacheDB(1024 * 1024 * 100)
+		cacheIdempotency = NewIdempotencyCacheRepository(freecache.NewCacheDB(1024 * 1024 * 10))
 	}
 }
 
@@ -39,5 +52,60 @@ func GetEventRepository() domain.EventRepository {
 	} else {
 		eventRepository = NewEventDBRepository()
 	}
-	return eventRepository
+	return newEventInstrumentedRepository(eventRepository)
+}
+
+func GetCalendarRepository() domain.CalendarRepository {
+	var calendarRepository domain.CalendarRepository
+	if common.Config.UseCacheDB {
+		calendarRepository = NewCalendarCacheRepository()
+	} else {
+		calendarRepository = NewCalendarDBRepository()
+	}
+	return newCalendarInstrumentedRepository(calendarRepository)
+}
+
+func GetNotificationOutbox() domain.NotificationOutbox {
+	var outbox domain.NotificationOutbox
+	if common.Config.UseCacheDB {
+		outbox = NewNotificationOutboxCacheRepository()
+	} else {
+		outbox = NewNotificationOutboxDBRepository()
+	}
+	return newOutboxInstrumentedRepository(outbox)
+}
+
+// inTx runs fn in a transaction which is committed if fn succeeds.
+func inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if common.IsErr(err) {
+		return err
+	}
+	if err := fn(tx); common.IsErr(err) {
+		if rollbackErr := tx.Rollback(); common.IsErr(rollbackErr) {
+			common.Logger.Error().Msgf("failed to rollback transaction: %v", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func GetNotificationTargetRepository() domain.NotificationTargetRepository {
+	var targetRepository domain.NotificationTargetRepository
+	if common.Config.UseCacheDB {
+		targetRepository = cacheTargets
+	} else {
+		targetRepository = NewNotificationTargetDBRepository()
+	}
+	return newNotificationTargetInstrumentedRepository(targetRepository)
+}
+
+func GetIdempotencyRepository() domain.IdempotencyRepository {
+	var idempotencyRepository domain.IdempotencyRepository
+	if common.Config.UseCacheDB {
+		idempotencyRepository = cacheIdempotency
+	} else {
+		idempotencyRepository = NewIdempotencyDBRepository()
+	}
+	return newIdempotencyInstrumentedRepository(idempotencyRepository)
 }
@@ -0,0 +1,516 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// calendarFields selects calendar columns and the access to the calendar granted to the user $1.
+const calendarFields = `id, user_id, name, color, time_zone, is_default, created_time,
+			  COALESCE((SELECT s.access FROM calendar_share s WHERE s.calendar_id = calendar.id AND s.user_id = $1), '')`
+
+// scanCalendar scans a calendar row selected with calendarFields for the user.
+func scanCalendar(row rowScanner, userID int64) (*domain.Calendar, error) {
+	var c domain.Calendar
+	err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.Color, &c.TimeZone, &c.Default, &c.CreatedTime, &c.Access)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if c.UserID == userID {
+		c.Access = domain.CalendarAccessOwner
+	}
+	createdTime := c.CreatedTime.UTC()
+	c.CreatedTime = &createdTime
+	return &c, nil
+}
+
+// calendarAccess returns the access to the calendar granted to the user, it's empty if the calendar
+// isn't shared with the user.
+func calendarAccess(
+	ctx context.Context, q sqlx.QueryerContext, userID int64, calendarID string,
+) (domain.CalendarAccess, error) {
+	var access domain.CalendarAccess
+	err := q.QueryRowxContext(
+		ctx, "SELECT access FROM calendar_share WHERE calendar_id = $1 AND user_id = $2", calendarID, userID,
+	).Scan(&access)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return access, err
+}
+
+// defaultCalendarID returns ID of the default calendar of the user creating the calendar if it doesn't exist.
+func defaultCalendarID(ctx context.Context, q sqlx.QueryerContext, userID int64) (string, error) {
+	var id string
+	err := q.QueryRowxContext(ctx, "SELECT id FROM calendar WHERE user_id = $1 AND is_default", userID).Scan(&id)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return id, err
+	}
+	c := domain.NewDefaultCalendar(userID)
+	// The update makes a calendar created by a concurrent request returned instead of the conflict.
+	err = q.QueryRowxContext(
+		ctx,
+		`INSERT INTO calendar (id, user_id, name, time_zone, is_default, created_time, updated_time)
+		 VALUES ($1, $2, $3, $4, true, $5, $5)
+		 ON CONFLICT (user_id) WHERE is_default DO UPDATE SET is_default = EXCLUDED.is_default RETURNING id`,
+		c.ID,
+		c.UserID,
+		c.Name,
+		c.TimeZone,
+		time.Now().UTC(),
+	).Scan(&id)
+	return id, err
+}
+
+type calendarDBRepository struct{}
+
+// NewCalendarDBRepository returns a new instance of a calendarDBRepository.
+func NewCalendarDBRepository() domain.CalendarRepository {
+	return &calendarDBRepository{}
+}
+
+// Add adds a new calendar to the database.
+func (repo *calendarDBRepository) Add(ctx context.Context, calendar *domain.Calendar) error {
+	createdTime := time.Now().UTC().Truncate(time.Millisecond)
+	calendar.CreatedTime = &createdTime
+	calendar.Access = domain.CalendarAccessOwner
+	_, err := db.ExecContext(
+		ctx,
+		`INSERT INTO calendar (id, user_id, name, color, time_zone, is_default, created_time, updated_time)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		calendar.ID,
+		calendar.UserID,
+		calendar.Name,
+		calendar.Color,
+		calendar.TimeZone,
+		calendar.Default,
+		createdTime,
+	)
+	return err
+}
+
+// lockCalendar locks a calendar of the owner for the transaction and returns it.
+func (repo *calendarDBRepository) lockCalendar(
+	ctx context.Context, tx *sqlx.Tx, ownerID int64, calendarID string,
+) (*domain.Calendar, error) {
+	c, err := scanCalendar(
+		tx.QueryRowContext(ctx, `SELECT `+calendarFields+` FROM calendar WHERE id = $2 FOR UPDATE`, ownerID, calendarID),
+		ownerID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCalendarNotExist
+	}
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if c.UserID != ownerID {
+		return nil, domain.ErrPermission
+	}
+	return c, nil
+}
+
+// Update updates a calendar of the calendar owner in the database.
+func (repo *calendarDBRepository) Update(ctx context.Context, calendar *domain.Calendar) error {
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		before, err := repo.lockCalendar(ctx, tx, calendar.UserID, calendar.ID)
+		if common.IsErr(err) {
+			return err
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE calendar SET (name, color, time_zone, updated_time) = ($2, $3, $4, $5) WHERE id = $1",
+			calendar.ID,
+			calendar.Name,
+			calendar.Color,
+			calendar.TimeZone,
+			time.Now().UTC(),
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		calendar.Default = before.Default
+		calendar.CreatedTime = before.CreatedTime
+		calendar.Access = before.Access
+		return nil
+	})
+}
+
+// Delete removes a calendar of the user without events from the database, its shares are removed
+// by the foreign key.
+func (repo *calendarDBRepository) Delete(ctx context.Context, userID int64, calendarID string) error {
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		c, err := repo.lockCalendar(ctx, tx, userID, calendarID)
+		if common.IsErr(err) {
+			return err
+		}
+		if c.Default {
+			return domain.ErrDefaultCalendar
+		}
+		var hasEvents bool
+		err = tx.QueryRowContext(
+			ctx, "SELECT EXISTS (SELECT 1 FROM event WHERE calendar_id = $1)", calendarID,
+		).Scan(&hasEvents)
+		if common.IsErr(err) {
+			return err
+		}
+		if hasEvents {
+			return domain.ErrCalendarNotEmpty
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM calendar WHERE id = $1", calendarID)
+		return err
+	})
+}
+
+// Get returns a calendar of the user or a calendar shared with the user by ID.
+func (repo *calendarDBRepository) Get(ctx context.Context, userID int64, calendarID string) (*domain.Calendar, error) {
+	c, err := scanCalendar(
+		db.QueryRowContext(ctx, `SELECT `+calendarFields+` FROM calendar WHERE id = $2`, userID, calendarID), userID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCalendarNotExist
+	}
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if c.Access == "" {
+		return nil, domain.ErrPermission
+	}
+	return c, nil
+}
+
+// GetCalendars returns a list of the user calendars and calendars shared with the user.
+func (repo *calendarDBRepository) GetCalendars(ctx context.Context, userID int64) ([]*domain.Calendar, error) {
+	if _, err := defaultCalendarID(ctx, db, userID); common.IsErr(err) {
+		return nil, err
+	}
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT `+calendarFields+` FROM calendar
+		 WHERE user_id = $1 OR id IN (SELECT calendar_id FROM calendar_share WHERE user_id = $1)
+		 ORDER BY name, id`,
+		userID,
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if common.IsErr(err) {
+			common.Logger.Error().Err(err).Msg("error closing rows")
+		}
+	}(rows)
+	var calendars []*domain.Calendar
+	for rows.Next() {
+		c, err := scanCalendar(rows, userID)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		calendars = append(calendars, c)
+	}
+	if err := rows.Err(); common.IsErr(err) {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// Share grants the access to a calendar of the owner to the user of the share.
+func (repo *calendarDBRepository) Share(
+	ctx context.Context, ownerID int64, calendarID string, share *domain.CalendarShare,
+) error {
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		c, err := repo.lockCalendar(ctx, tx, ownerID, calendarID)
+		if common.IsErr(err) {
+			return err
+		}
+		if err := c.ValidateShare(share); common.IsErr(err) {
+			return err
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO calendar_share (calendar_id, user_id, access, created_time, updated_time)
+			 VALUES ($1, $2, $3, $4, $4)
+			 ON CONFLICT (calendar_id, user_id) DO UPDATE SET (access, updated_time)
+			 = (EXCLUDED.access, EXCLUDED.updated_time)`,
+			calendarID,
+			share.UserID,
+			share.Access,
+			time.Now().UTC(),
+		)
+		return err
+	})
+}
+
+// Unshare revokes the access of the user to a calendar of the owner.
+func (repo *calendarDBRepository) Unshare(ctx context.Context, ownerID int64, calendarID string, userID int64) error {
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := repo.lockCalendar(ctx, tx, ownerID, calendarID); common.IsErr(err) {
+			return err
+		}
+		_, err := tx.ExecContext(
+			ctx, "DELETE FROM calendar_share WHERE calendar_id = $1 AND user_id = $2", calendarID, userID,
+		)
+		return err
+	})
+}
+
+// GetShares returns a list of shares of a calendar of the owner.
+func (repo *calendarDBRepository) GetShares(
+	ctx context.Context, ownerID int64, calendarID string,
+) ([]*domain.CalendarShare, error) {
+	c, err := repo.Get(ctx, ownerID, calendarID)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if c.UserID != ownerID {
+		return nil, domain.ErrPermission
+	}
+	rows, err := db.QueryContext(
+		ctx, "SELECT user_id, access FROM calendar_share WHERE calendar_id = $1 ORDER BY user_id", calendarID,
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if common.IsErr(err) {
+			common.Logger.Error().Err(err).Msg("error closing rows")
+		}
+	}(rows)
+	var shares []*domain.CalendarShare
+	for rows.Next() {
+		var share domain.CalendarShare
+		if err := rows.Scan(&share.UserID, &share.Access); common.IsErr(err) {
+			return nil, err
+		}
+		shares = append(shares, &share)
+	}
+	if err := rows.Err(); common.IsErr(err) {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// calendarCacheRepository keeps calendars and their shares in memory, it's shared by the cache repositories.
+type calendarCacheRepository struct {
+	mu        sync.RWMutex
+	calendars map[string]domain.Calendar
+	shares    map[string]map[int64]domain.CalendarAccess
+}
+
+func newCalendarCacheRepository() *calendarCacheRepository {
+	return &calendarCacheRepository{
+		calendars: make(map[string]domain.Calendar),
+		shares:    make(map[string]map[int64]domain.CalendarAccess),
+	}
+}
+
+// NewCalendarCacheRepository returns the in-memory calendar repository shared with the event cache repository.
+func NewCalendarCacheRepository() domain.CalendarRepository {
+	return cacheCalendars
+}
+
+// Add adds a new calendar to the cache.
+func (repo *calendarCacheRepository) Add(_ context.Context, calendar *domain.Calendar) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if _, ok := repo.calendars[calendar.ID]; ok {
+		return errors.New("calendar already exists")
+	}
+	createdTime := time.Now().UTC().Truncate(time.Millisecond)
+	calendar.CreatedTime = &createdTime
+	calendar.Access = domain.CalendarAccessOwner
+	repo.calendars[calendar.ID] = *calendar
+	return nil
+}
+
+// owned returns a calendar of the owner, the caller must hold the lock.
+func (repo *calendarCacheRepository) owned(ownerID int64, calendarID string) (*domain.Calendar, error) {
+	c, ok := repo.calendars[calendarID]
+	if !ok {
+		return nil, domain.ErrCalendarNotExist
+	}
+	if c.UserID != ownerID {
+		return nil, domain.ErrPermission
+	}
+	c.Access = domain.CalendarAccessOwner
+	return &c, nil
+}
+
+// Update updates a calendar of the calendar owner in the cache.
+func (repo *calendarCacheRepository) Update(_ context.Context, calendar *domain.Calendar) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	before, err := repo.owned(calendar.UserID, calendar.ID)
+	if common.IsErr(err) {
+		return err
+	}
+	calendar.Default = before.Default
+	calendar.CreatedTime = before.CreatedTime
+	calendar.Access = before.Access
+	repo.calendars[calendar.ID] = *calendar
+	return nil
+}
+
+// Delete removes a calendar of the user without events from the cache.
+func (repo *calendarCacheRepository) Delete(_ context.Context, userID int64, calendarID string) error {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	c, err := repo.owned(userID, calendarID)
+	if common.IsErr(err) {
+		return err
+	}
+	if c.Default {
+		return domain.ErrDefaultCalendar
+	}
+	events, err := (&eventCacheRepository{}).getAllEvents()
+	if common.IsErr(err) {
+		return err
+	}
+	for _, e := range events {
+		if e.CalendarID == calendarID {
+			return domain.ErrCalendarNotEmpty
+		}
+	}
+	delete(repo.calendars, calendarID)
+	delete(repo.shares, calendarID)
+	return nil
+}
+
+// Get returns a calendar of the user or a calendar shared with the user by ID.
+func (repo *calendarCacheRepository) Get(_ context.Context, userID int64, calendarID string) (*domain.Calendar, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	c, ok := repo.calendars[calendarID]
+	if !ok {
+		return nil, domain.ErrCalendarNotExist
+	}
+	c.Access = repo.accessLocked(userID, &c)
+	if c.Access == "" {
+		return nil, domain.ErrPermission
+	}
+	return &c, nil
+}
+
+// accessLocked returns the access of the user to the calendar, the caller must hold the lock.
+func (repo *calendarCacheRepository) accessLocked(userID int64, c *domain.Calendar) domain.CalendarAccess {
+	if c.UserID == userID {
+		return domain.CalendarAccessOwner
+	}
+	return repo.shares[c.ID][userID]
+}
+
+// access returns the access of the user to the calendar, it's empty if the calendar isn't shared with the user.
+func (repo *calendarCacheRepository) access(userID int64, calendarID string) domain.CalendarAccess {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	c, ok := repo.calendars[calendarID]
+	if !ok {
+		return ""
+	}
+	return repo.accessLocked(userID, &c)
+}
+
+// defaultCalendarID returns ID of the default calendar of the user creating the calendar if it doesn't exist.
+func (repo *calendarCacheRepository) defaultCalendarID(userID int64) string {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for _, c := range repo.calendars {
+		if c.UserID == userID && c.Default {
+			return c.ID
+		}
+	}
+	c := domain.NewDefaultCalendar(userID)
+	createdTime := time.Now().UTC().Truncate(time.Millisecond)
+	c.CreatedTime = &createdTime
+	repo.calendars[c.ID] = *c
+	return c.ID
+}
+
+// GetCalendars returns a list of the user calendars and calendars shared with the user.
+func (repo *calendarCacheRepository) GetCalendars(_ context.Context, userID int64) ([]*domain.Calendar, error) {
+	repo.defaultCalendarID(userID)
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	var calendars []*domain.Calendar
+	for _, c := range repo.calendars {
+		c := c
+		if c.Access = repo.accessLocked(userID, &c); c.Access != "" {
+			calendars = append(calendars, &c)
+		}
+	}
+	sort.Slice(calendars, func(i, j int) bool {
+		if calendars[i].Name != calendars[j].Name {
+			return calendars[i].Name < calendars[j].Name
+		}
+		return calendars[i].ID < calendars[j].ID
+	})
+	return calendars, nil
+}
+
+// Share grants the access to a calendar of the owner to the user of the share.
+func (repo *calendarCacheRepository) Share(
+	_ context.Context, ownerID int64, calendarID string, share *domain.CalendarShare,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	c, err := repo.owned(ownerID, calendarID)
+	if common.IsErr(err) {
+		return err
+	}
+	if err := c.ValidateShare(share); common.IsErr(err) {
+		return err
+	}
+	if repo.shares[calendarID] == nil {
+		repo.shares[calendarID] = make(map[int64]domain.CalendarAccess)
+	}
+	repo.shares[calendarID][share.UserID] = share.Access
+	return nil
+}
+
+// Unshare revokes the access of the user to a calendar of the owner.
+func (repo *calendarCacheRepository) Unshare(_ context.Context, ownerID int64, calendarID string, userID int64) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if _, err := repo.owned(ownerID, calendarID); common.IsErr(err) {
+		return err
+	}
+	delete(repo.shares[calendarID], userID)
+	return nil
+}
+
+// GetShares returns a list of shares of a calendar of the owner.
+func (repo *calendarCacheRepository) GetShares(
+	_ context.Context, ownerID int64, calendarID string,
+) ([]*domain.CalendarShare, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	if _, err := repo.owned(ownerID, calendarID); common.IsErr(err) {
+		return nil, err
+	}
+	var shares []*domain.CalendarShare
+	for userID, access := range repo.shares[calendarID] {
+		shares = append(shares, &domain.CalendarShare{UserID: userID, Access: access})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].UserID < shares[j].UserID
+	})
+	return shares, nil
+}
+
+// clear removes all calendars and shares of the cache.
+func (repo *calendarCacheRepository) clear() {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.calendars = make(map[string]domain.Calendar)
+	repo.shares = make(map[string]map[int64]domain.CalendarAccess)
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/freecache"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
+	"github.com/go-faker/faker/v4"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+var calendarColumns = []string{"id", "user_id", "name", "color", "time_zone", "is_default", "created_time", "access"}
+
+func calendarRow(c *domain.Calendar, access domain.CalendarAccess) []driver.Value {
+	return []driver.Value{c.ID, c.UserID, c.Name, c.Color, c.TimeZone, c.Default, time.Now(), string(access)}
+}
+
+func TestCalendarDBRepository(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	db = sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewCalendarDBRepository()
+	ctx := context.Background()
+	calendar := &domain.Calendar{ID: faker.UUIDHyphenated(), UserID: 7, Name: "Work", TimeZone: "UTC"}
+	lock := "^SELECT (.+) FROM calendar WHERE id = \\$2 FOR UPDATE$"
+
+	mock.ExpectExec("^INSERT INTO calendar (.+) VALUES (.+)$").
+		WithArgs(calendar.ID, 7, "Work", "", "UTC", false, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	require.NoError(t, repo.Add(ctx, calendar))
+	require.Equal(t, domain.CalendarAccessOwner, calendar.Access)
+
+	mock.ExpectQuery("^SELECT (.+) FROM calendar WHERE id = \\$2$").
+		WithArgs(8, calendar.ID).
+		WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow(calendarRow(calendar, domain.CalendarAccessRead)...))
+	result, err := repo.Get(ctx, 8, calendar.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.CalendarAccessRead, result.Access)
+
+	mock.ExpectQuery("^SELECT (.+) FROM calendar WHERE id = \\$2$").
+		WithArgs(9, calendar.ID).
+		WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow(calendarRow(calendar, "")...))
+	_, err = repo.Get(ctx, 9, calendar.ID)
+	require.ErrorIs(t, err, domain.ErrPermission)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).
+		WithArgs(7, calendar.ID).
+		WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow(calendarRow(calendar, "")...))
+	mock.ExpectExec("^INSERT INTO calendar_share (.+) ON CONFLICT (.+)$").
+		WithArgs(calendar.ID, 8, "read", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	share := &domain.CalendarShare{UserID: 8, Access: domain.CalendarAccessRead}
+	require.NoError(t, repo.Share(ctx, 7, calendar.ID, share))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).
+		WithArgs(8, calendar.ID).
+		WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow(calendarRow(calendar, domain.CalendarAccessWrite)...))
+	mock.ExpectRollback()
+	require.ErrorIs(t, repo.Share(ctx, 8, calendar.ID, share), domain.ErrPermission)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).
+		WithArgs(7, calendar.ID).
+		WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow(calendarRow(calendar, "")...))
+	mock.ExpectQuery("^SELECT EXISTS \\(SELECT 1 FROM event WHERE calendar_id = \\$1\\)$").
+		WithArgs(calendar.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+	require.ErrorIs(t, repo.Delete(ctx, 7, calendar.ID), domain.ErrCalendarNotEmpty)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalendarDBRepositoryGetCalendars(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	db = sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewCalendarDBRepository()
+	defaultCalendar := domain.NewDefaultCalendar(7)
+	shared := &domain.Calendar{ID: faker.UUIDHyphenated(), UserID: 8, Name: "Team", TimeZone: "UTC"}
+
+	mock.ExpectQuery("^SELECT id FROM calendar WHERE user_id = \\$1 AND is_default$").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("^INSERT INTO calendar (.+) ON CONFLICT (.+) RETURNING id$").
+		WithArgs(sqlmock.AnyArg(), 7, domain.DefaultCalendarName, "UTC", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(defaultCalendar.ID))
+	mock.ExpectQuery("^SELECT (.+) FROM calendar WHERE user_id = \\$1 OR id IN (.+) ORDER BY name, id$").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(calendarColumns).
+			AddRow(calendarRow(defaultCalendar, "")...).
+			AddRow(calendarRow(shared, domain.CalendarAccessFreeBusy)...))
+
+	calendars, err := repo.GetCalendars(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, calendars, 2)
+	require.True(t, calendars[0].Default)
+	require.Equal(t, domain.CalendarAccessOwner, calendars[0].Access)
+	require.Equal(t, domain.CalendarAccessFreeBusy, calendars[1].Access)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalendarCacheRepository(t *testing.T) {
+	cacheDB = freecache.NewCacheDB(1024 * 1024)
+	cacheCalendars.clear()
+	defer cacheCalendars.clear()
+	repo := NewCalendarCacheRepository()
+	ctx := context.Background()
+	calendar := &domain.Calendar{ID: faker.UUIDHyphenated(), UserID: 7, Name: "Work", TimeZone: "UTC"}
+	require.NoError(t, repo.Add(ctx, calendar))
+
+	calendars, err := repo.GetCalendars(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, calendars, 2)
+	require.Equal(t, domain.DefaultCalendarName, calendars[0].Name)
+	require.True(t, calendars[0].Default)
+	require.Equal(t, calendar.ID, calendars[1].ID)
+	require.ErrorIs(t, repo.Delete(ctx, 7, calendars[0].ID), domain.ErrDefaultCalendar)
+
+	_, err = repo.Get(ctx, 8, calendar.ID)
+	require.ErrorIs(t, err, domain.ErrPermission)
+	share := &domain.CalendarShare{UserID: 8, Access: domain.CalendarAccessWrite}
+	require.ErrorIs(t, repo.Share(ctx, 8, calendar.ID, share), domain.ErrPermission)
+	require.ErrorIs(
+		t, repo.Share(ctx, 7, calendar.ID, &domain.CalendarShare{UserID: 7, Access: domain.CalendarAccessRead}),
+		domain.ErrCalendarShare,
+	)
+	require.NoError(t, repo.Share(ctx, 7, calendar.ID, share))
+	result, err := repo.Get(ctx, 8, calendar.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.CalendarAccessWrite, result.Access)
+	shares, err := repo.GetShares(ctx, 7, calendar.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.CalendarShare{share}, shares)
+	_, err = repo.GetShares(ctx, 8, calendar.ID)
+	require.ErrorIs(t, err, domain.ErrPermission)
+
+	update := &domain.Calendar{ID: calendar.ID, UserID: 8, Name: "Renamed", TimeZone: "UTC"}
+	require.ErrorIs(t, repo.Update(ctx, update), domain.ErrPermission)
+	update.UserID = 7
+	require.NoError(t, repo.Update(ctx, update))
+	require.Equal(t, calendar.CreatedTime, update.CreatedTime)
+
+	require.NoError(t, repo.Unshare(ctx, 7, calendar.ID, 8))
+	_, err = repo.Get(ctx, 8, calendar.ID)
+	require.ErrorIs(t, err, domain.ErrPermission)
+	require.NoError(t, repo.Delete(ctx, 7, calendar.ID))
+	_, err = repo.Get(ctx, 7, calendar.ID)
+	require.ErrorIs(t, err, domain.ErrCalendarNotExist)
+}
+
+func (s *eventCacheTestSuite) TestCalendarShares() {
+	ctx := context.Background()
+	calendars := NewCalendarCacheRepository()
+	event := tests.GenerateTestEvent()
+	s.NoError(s.repo.Add(ctx, event))
+	s.NotEmpty(event.CalendarID)
+	reader, writer := event.UserID+1, event.UserID+2
+
+	s.ErrorIs(calendars.Delete(ctx, event.UserID, event.CalendarID), domain.ErrDefaultCalendar)
+	_, err := s.repo.Get(ctx, reader, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+	share := func(userID int64, access domain.CalendarAccess) {
+		share := &domain.CalendarShare{UserID: userID, Access: access}
+		s.NoError(calendars.Share(ctx, event.UserID, event.CalendarID, share))
+	}
+	share(reader, domain.CalendarAccessFreeBusy)
+	_, err = s.repo.Get(ctx, reader, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+
+	share(reader, domain.CalendarAccessRead)
+	share(writer, domain.CalendarAccessWrite)
+	result, err := s.repo.Get(ctx, reader, event.ID)
+	s.NoError(err)
+	s.Equal(event.ID, result.ID)
+	events, err := s.repo.GetEventsByPeriod(ctx, reader, event.StartTime.Add(-time.Hour), event.StartTime.Add(time.Hour))
+	s.NoError(err)
+	s.Len(events, 1)
+	page, err := s.repo.GetEventsPage(ctx, &domain.EventFilter{
+		UserID:     reader,
+		StartTime:  event.StartTime.Add(-time.Hour),
+		EndTime:    event.StartTime.Add(time.Hour),
+		CalendarID: event.CalendarID,
+	})
+	s.NoError(err)
+	s.Len(page.Events, 1)
+
+	update := *result
+	update.Title = "Shared"
+	s.ErrorIs(s.repo.Update(ctx, reader, &update), domain.ErrPermission)
+	s.NoError(s.repo.Update(ctx, writer, &update))
+	s.Equal(event.UserID, update.UserID)
+	s.Equal(event.CalendarID, update.CalendarID)
+	s.ErrorIs(s.repo.Delete(ctx, reader, event.ID), domain.ErrPermission)
+	s.NoError(s.repo.Delete(ctx, writer, event.ID))
+
+	s.NoError(calendars.Unshare(ctx, event.UserID, event.CalendarID, reader))
+	_, err = s.repo.Restore(ctx, reader, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+}
@@ -1,15 +1,182 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+const eventFields = `id, title, start_time, end_time, description, user_id, created_time, calendar_id,
+			  recurrence_rule,
+			  (SELECT json_agg(to_char(x.t AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"') ORDER BY x.n)
+			  FROM unnest(recurrence_exceptions) WITH ORDINALITY x(t, n)),
+			  notification_channel, notification_address, deleted_time, version, time_zone, all_day,
+			  (SELECT json_agg(json_build_object('UserID', a.user_id, 'Status', a.status) ORDER BY a.user_id)
+			  FROM event_attendee a WHERE a.event_id = event.id),
+			  (SELECT json_agg(json_build_object('Offset', r.offset_ms * 1000000,
+			  'Time', to_char(r.remind_time AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')) ORDER BY r.id)
+			  FROM event_reminder r WHERE r.event_id = event.id)`
+
+// periodStart and periodEnd are boundaries of the listing period $2 and $3 for an event row, boundaries
+// of all-day events are the wall clocks of the period $4 and $5 in the event time zone
+// as in domain.Event.OccurrencesByPeriod.
+const (
+	periodStart = `(CASE WHEN all_day THEN $4::timestamp AT TIME ZONE time_zone ELSE $2 END)`
+	periodEnd   = `(CASE WHEN all_day THEN $5::timestamp AT TIME ZONE time_zone ELSE $3 END)`
+)
+
+// periodCondition selects single events within the listing period and recurring events
+// which may have occurrences within it.
+const periodCondition = `((recurrence_rule IS NULL AND start_time >= ` + periodStart + `
+						AND end_time <= ` + periodEnd + `)
+						OR (recurrence_rule IS NOT NULL AND start_time <= ` + periodEnd + `
+						AND (recurrence_end IS NULL OR recurrence_end >= ` + periodStart + `)))`
+
+// visibleCondition selects events of the user $1, events the user is invited to unless the user declined them
+// and events of calendars shared with the user to read them.
+const visibleCondition = `(user_id = $1 OR id IN (SELECT event_id FROM event_attendee
+						  WHERE user_id = $1 AND status <> 'declined')
+						  OR calendar_id IN (SELECT calendar_id FROM calendar_share
+						  WHERE user_id = $1 AND access IN ('read', 'write')))`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEvent scans an event row selected with eventFields.
+func scanEvent(row rowScanner) (*domain.Event, error) {
+	var (
+		e          domain.Event
+		rule       sql.NullString
+		exceptions []byte
+		channel    sql.NullString
+		address    sql.NullString
+		attendees  []byte
+		reminders  []byte
+	)
+	err := row.Scan(
+		&e.ID,
+		&e.Title,
+		&e.StartTime,
+		&e.EndTime,
+		&e.Description,
+		&e.UserID,
+		&e.CreatedTime,
+		&e.CalendarID,
+		&rule,
+		&exceptions,
+		&channel,
+		&address,
+		&e.DeletedTime,
+		&e.Version,
+		&e.TimeZone,
+		&e.AllDay,
+		&attendees,
+		&reminders,
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if attendees != nil {
+		if err := json.Unmarshal(attendees, &e.Attendees); common.IsErr(err) {
+			return nil, err
+		}
+	}
+	if reminders != nil {
+		if err := json.Unmarshal(reminders, &e.Reminders); common.IsErr(err) {
+			return nil, err
+		}
+	}
+	if channel.Valid {
+		e.NotificationTarget = &domain.NotificationTarget{Channel: channel.String, Address: address.String}
+	}
+	if rule.Valid {
+		e.Recurrence, err = domain.ParseRecurrenceRule(rule.String)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		if exceptions != nil {
+			if err := json.Unmarshal(exceptions, &e.Recurrence.Exceptions); common.IsErr(err) {
+				return nil, err
+			}
+		}
+	}
+	e.NormalizeTime()
+	return &e, nil
+}
+
+// insertReminders adds reminders of a new event.
+func insertReminders(ctx context.Context, tx *sqlx.Tx, e *domain.Event) error {
+	for _, r := range e.Reminders {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO event_reminder (event_id, offset_ms, remind_time) VALUES ($1, $2, $3)",
+			e.ID,
+			r.Offset.Milliseconds(),
+			r.Time,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceReminders replaces reminders of an updated event.
+func replaceReminders(ctx context.Context, tx *sqlx.Tx, e *domain.Event) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM event_reminder WHERE event_id = $1", e.ID); common.IsErr(err) {
+		return err
+	}
+	return insertReminders(ctx, tx, e)
+}
+
+// recurrenceValues returns values of the recurrence columns of an event, exceptions are a timestamptz array.
+func recurrenceValues(e *domain.Event) (rule sql.NullString, exceptions interface{}, end *time.Time) {
+	if e.Recurrence == nil {
+		return rule, nil, nil
+	}
+	rule = sql.NullString{String: e.Recurrence.Rule(), Valid: true}
+	if len(e.Recurrence.Exceptions) > 0 {
+		times := make(pq.StringArray, len(e.Recurrence.Exceptions))
+		for i, t := range e.Recurrence.Exceptions {
+			times[i] = t.UTC().Format(time.RFC3339Nano)
+		}
+		exceptions = times
+	}
+	return rule, exceptions, e.RecurrenceEnd()
+}
+
+// notificationTargetValues returns values of the notification target columns of an event.
+func notificationTargetValues(e *domain.Event) (channel, address sql.NullString) {
+	if e.NotificationTarget == nil {
+		return channel, address
+	}
+	return sql.NullString{String: e.NotificationTarget.Channel, Valid: true},
+		sql.NullString{String: e.NotificationTarget.Address, Valid: true}
+}
+
+// expandEvents replaces events with their occurrences and sorts them by start time.
+func expandEvents(events []*domain.Event, expand func(e *domain.Event) []*domain.Event) []*domain.Event {
+	var result []*domain.Event
+	for _, e := range events {
+		result = append(result, expand(e)...)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result
+}
+
 type eventDBRepository struct{}
 
 // NewEventDBRepository returns a new instance of a eventDBRepository.
@@ -17,139 +184,409 @@ func NewEventDBRepository() domain.EventRepository {
 	return &eventDBRepository{}
 }
 
-// Add adds a new event to the database.
-func (repo *eventDBRepository) Add(event *domain.Event) error {
+// Add adds a new event with its reminders to the database and enqueues their notifications in the same transaction.
+func (repo *eventDBRepository) Add(ctx context.Context, event *domain.Event) error {
 	createdTime := time.Now().UTC()
 	event.CreatedTime = &createdTime
+	event.Version = 1
 	event.NormalizeTime()
-	query := `INSERT INTO event (id, title, start_time, end_time, notify_time, description, user_id, 
-              created_time, updated_time) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	result, err := db.Exec(
-		query,
-		event.ID,
-		event.Title,
-		event.StartTime,
-		event.EndTime,
-		event.NotifyTime,
-		event.Description,
-		event.UserID,
-		event.CreatedTime,
-		event.CreatedTime,
-	)
-	if common.IsErr(err) {
-		return err
-	}
-	count, err := result.RowsAffected()
+	rule, exceptions, recurrenceEnd := recurrenceValues(event)
+	channel, address := notificationTargetValues(event)
+	query := `INSERT INTO event (id, title, start_time, end_time, description, user_id, 
+              created_time, updated_time, recurrence_rule, recurrence_exceptions, recurrence_end,
+              notification_channel, notification_address, version, calendar_id, time_zone, all_day)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		if event.CalendarID == "" {
+			calendarID, err := defaultCalendarID(ctx, tx, event.UserID)
+			if common.IsErr(err) {
+				return err
+			}
+			event.CalendarID = calendarID
+		}
+		result, err := tx.ExecContext(
+			ctx,
+			query,
+			event.ID,
+			event.Title,
+			event.StartTime,
+			event.EndTime,
+			event.Description,
+			event.UserID,
+			event.CreatedTime,
+			event.CreatedTime,
+			rule,
+			exceptions,
+			recurrenceEnd,
+			channel,
+			address,
+			event.Version,
+			event.CalendarID,
+			event.TimeZone,
+			event.AllDay,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		count, err := result.RowsAffected()
+		if common.IsErr(err) {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrEventCreate
+		}
+		if err := insertReminders(ctx, tx, event); common.IsErr(err) {
+			return err
+		}
+		if err := enqueueNotifications(ctx, tx, event, createdTime.Add(-domain.NotificationGracePeriod)); common.IsErr(err) {
+			return err
+		}
+		return recordChange(ctx, tx, domain.NewEventChange(ctx, domain.EventActionCreate, nil, event))
+	})
+}
+
+// Update updates an existing event of the user in the database if its version matches,
+// replaces its reminders and pending notifications and records the change in the same transaction.
+func (repo *eventDBRepository) Update(ctx context.Context, userID int64, event *domain.Event) error {
+	now := time.Now().UTC()
+	rule, exceptions, recurrenceEnd := recurrenceValues(event)
+	channel, address := notificationTargetValues(event)
+	query := `UPDATE event SET (
+                  title, start_time, end_time, description, user_id, updated_time,
+                  recurrence_rule, recurrence_exceptions, recurrence_end, notification_channel, notification_address,
+                  version, calendar_id, time_zone, all_day
+              ) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $11, $12, $13, $14, $15, $16) WHERE id = $10`
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		before, err := repo.lockEvent(ctx, tx, userID, event.ID)
+		if common.IsErr(err) {
+			return err
+		}
+		if before.UserID != event.UserID {
+			return domain.ErrPermission
+		}
+		if before.DeletedTime != nil {
+			return domain.ErrEventNotExist
+		}
+		if before.Version != event.Version {
+			return domain.ErrVersionConflict
+		}
+		if event.CalendarID == "" {
+			event.CalendarID = before.CalendarID
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			event.Title,
+			event.StartTime,
+			event.EndTime,
+			event.Description,
+			event.UserID,
+			now,
+			rule,
+			exceptions,
+			recurrenceEnd,
+			event.ID,
+			channel,
+			address,
+			before.Version+1,
+			event.CalendarID,
+			event.TimeZone,
+			event.AllDay,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		event.CreatedTime = before.CreatedTime
+		event.Version = before.Version + 1
+		event.Attendees = before.Attendees
+		if err := replaceReminders(ctx, tx, event); common.IsErr(err) {
+			return err
+		}
+		if err := replaceNotifications(ctx, tx, event, now.Add(-domain.NotificationGracePeriod)); common.IsErr(err) {
+			return err
+		}
+		return recordChange(ctx, tx, domain.NewEventChange(ctx, domain.EventActionUpdate, before, event))
+	})
+}
+
+// lockEvent locks an event in the trash or out of it which the user may write to for the transaction
+// and returns it.
+func (repo *eventDBRepository) lockEvent(
+	ctx context.Context, tx *sqlx.Tx, userID int64, eventID string,
+) (*domain.Event, error) {
+	e, err := repo.lockAnyEvent(ctx, tx, eventID)
 	if common.IsErr(err) {
-		return err
+		return nil, err
 	}
-	if count == 0 {
-		return domain.ErrEventCreate
+	if err := authorizeEvent(ctx, tx, userID, e, domain.CalendarAccessWrite); common.IsErr(err) {
+		return nil, err
 	}
-	return nil
+	return e, nil
 }
 
-// Update updates an existing event in the database.
-func (repo *eventDBRepository) Update(event *domain.Event) error {
-	now := time.Now()
-	query := `UPDATE event SET (
-                  title, start_time, end_time, notify_time, description, user_id, updated_time
-              ) = ($1, $2, $3, $4, $5, $6, $7) WHERE id = $8`
-	result, err := db.Exec(
-		query,
-		event.Title,
-		event.StartTime,
-		event.EndTime,
-		event.NotifyTime,
-		event.Description,
-		event.UserID,
-		now,
-		event.ID,
-	)
-	if common.IsErr(err) {
-		return err
+// authorizeEvent returns ErrPermission unless the user owns the event or is granted the access to its calendar.
+func authorizeEvent(
+	ctx context.Context, q sqlx.QueryerContext, userID int64, e *domain.Event, access domain.CalendarAccess,
+) error {
+	if e.UserID == userID {
+		return nil
 	}
-	rowsAffected, err := result.RowsAffected()
+	granted, err := calendarAccess(ctx, q, userID, e.CalendarID)
 	if common.IsErr(err) {
 		return err
 	}
-	if rowsAffected == 0 {
-		return domain.ErrEventNotExist
+	if !granted.Allows(access) {
+		return domain.ErrPermission
 	}
-	e, err := repo.Get(event.ID)
-	if common.IsErr(err) {
-		return err
+	return nil
+}
+
+// lockAnyEvent locks an event of any user for the transaction and returns it.
+func (repo *eventDBRepository) lockAnyEvent(ctx context.Context, tx *sqlx.Tx, eventID string) (*domain.Event, error) {
+	e, err := scanEvent(tx.QueryRowContext(ctx, `SELECT `+eventFields+` FROM event WHERE id = $1 FOR UPDATE`, eventID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrEventNotExist
 	}
-	event.CreatedTime = e.CreatedTime
-	return err
+	return e, err
 }
 
-// Get returns an event by ID.
-func (repo *eventDBRepository) Get(eventID string) (*domain.Event, error) {
-	var e domain.Event
-	query := `SELECT id, title, start_time, end_time, notify_time,
-			  description, user_id, created_time FROM event WHERE id = $1`
-	row := db.QueryRow(query, eventID)
+// Get returns an event of the user, an event the user is invited to or an event of a calendar shared
+// with the user by ID.
+func (repo *eventDBRepository) Get(ctx context.Context, userID int64, eventID string) (*domain.Event, error) {
+	query := `SELECT ` + eventFields + ` FROM event WHERE id = $1 AND deleted_time IS NULL`
+	row := db.QueryRowContext(ctx, query, eventID)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
-	err := row.Scan(
-		&e.ID,
-		&e.Title,
-		&e.StartTime,
-		&e.EndTime,
-		&e.NotifyTime,
-		&e.Description,
-		&e.UserID,
-		&e.CreatedTime,
-	)
+	e, err := scanEvent(row)
 	if common.IsErr(err) {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrEventNotExist
 		}
 		return nil, err
 	}
-	e.NormalizeTime()
+	if !e.CanView(userID) {
+		if err := authorizeEvent(ctx, db, userID, e, domain.CalendarAccessRead); common.IsErr(err) {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Delete moves an event of the user to the trash, removes its pending notifications
+// and records the change in the same transaction.
+func (repo *eventDBRepository) Delete(ctx context.Context, userID int64, eventID string) error {
+	now := time.Now().UTC()
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		before, err := repo.lockEvent(ctx, tx, userID, eventID)
+		if common.IsErr(err) {
+			return err
+		}
+		if before.DeletedTime != nil {
+			return domain.ErrEventNotExist
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE event SET deleted_time = $2, updated_time = $2, version = version + 1 WHERE id = $1",
+			eventID,
+			now,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		if err := removeNotifications(ctx, tx, eventID); common.IsErr(err) {
+			return err
+		}
+		after := *before
+		after.DeletedTime = &now
+		after.Version++
+		after.NormalizeTime()
+		return recordChange(ctx, tx, domain.NewEventChange(ctx, domain.EventActionDelete, before, &after))
+	})
+}
+
+// DeletePermanently removes an event of the user by ID and records the change in the same transaction,
+// its notifications are removed by the foreign key.
+func (repo *eventDBRepository) DeletePermanently(ctx context.Context, userID int64, eventID string) error {
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		before, err := repo.lockEvent(ctx, tx, userID, eventID)
+		if common.IsErr(err) {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM event WHERE id = $1", eventID); common.IsErr(err) {
+			return err
+		}
+		return recordChange(ctx, tx, domain.NewEventChange(ctx, domain.EventActionDeletePermanently, before, nil))
+	})
+}
+
+// Restore restores an event of the user from the trash, enqueues its notifications
+// and records the change in the same transaction.
+func (repo *eventDBRepository) Restore(ctx context.Context, userID int64, eventID string) (*domain.Event, error) {
+	now := time.Now().UTC()
+	var after domain.Event
+	err := inTx(ctx, func(tx *sqlx.Tx) error {
+		before, err := repo.lockEvent(ctx, tx, userID, eventID)
+		if common.IsErr(err) {
+			return err
+		}
+		if before.DeletedTime == nil {
+			return domain.ErrEventNotExist
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE event SET deleted_time = NULL, updated_time = $2, version = version + 1 WHERE id = $1",
+			eventID,
+			now,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		after = *before
+		after.DeletedTime = nil
+		after.Version++
+		if err := enqueueNotifications(ctx, tx, &after, now.Add(-domain.NotificationGracePeriod)); common.IsErr(err) {
+			return err
+		}
+		return recordChange(ctx, tx, domain.NewEventChange(ctx, domain.EventActionRestore, before, &after))
+	})
 	if common.IsErr(err) {
 		return nil, err
 	}
-	return &e, nil
+	return &after, nil
 }
 
-// Delete removes an event by ID.
-func (repo *eventDBRepository) Delete(eventID string) error {
-	result, err := db.Exec(
-		"DELETE FROM event WHERE id = $1", eventID,
+// InviteAttendees invites the users to an event of the user, replaces its pending notifications
+// and records the change in the same transaction.
+func (repo *eventDBRepository) InviteAttendees(
+	ctx context.Context, userID int64, eventID string, userIDs []int64,
+) (*domain.Event, error) {
+	return repo.changeAttendees(
+		ctx, eventID, domain.EventActionInvite, func(tx *sqlx.Tx, e *domain.Event, now time.Time) error {
+			if err := authorizeEvent(ctx, tx, userID, e, domain.CalendarAccessWrite); common.IsErr(err) {
+				return err
+			}
+			added, err := e.Invite(userIDs)
+			if common.IsErr(err) {
+				return err
+			}
+			for _, a := range added {
+				_, err := tx.ExecContext(
+					ctx,
+					`INSERT INTO event_attendee (event_id, user_id, status, created_time, updated_time)
+					 VALUES ($1, $2, $3, $4, $4)`,
+					e.ID,
+					a.UserID,
+					a.Status,
+					now,
+				)
+				if common.IsErr(err) {
+					return err
+				}
+			}
+			return nil
+		},
 	)
-	if common.IsErr(err) {
-		return err
-	}
-	count, err := result.RowsAffected()
-	if common.IsErr(err) {
-		return err
-	}
-	if count == 0 {
-		return domain.ErrEventNotExist
-	}
-	return nil
 }
 
-// DeleteEventBeforeDate removes an event before date.
-func (repo *eventDBRepository) DeleteEventBeforeDate(date time.Time) error {
-	result, err := db.Exec(
-		"DELETE FROM event WHERE start_time <= $1", date,
+// RespondInvitation sets the response of the user to an event the user is invited to, replaces its pending
+// notifications and records the change in the same transaction.
+func (repo *eventDBRepository) RespondInvitation(
+	ctx context.Context, userID int64, eventID string, status domain.AttendeeStatus,
+) (*domain.Event, error) {
+	return repo.changeAttendees(
+		ctx, eventID, domain.EventActionRespond, func(tx *sqlx.Tx, e *domain.Event, now time.Time) error {
+			a, err := e.Respond(userID, status)
+			if common.IsErr(err) {
+				return err
+			}
+			_, err = tx.ExecContext(
+				ctx,
+				"UPDATE event_attendee SET status = $3, updated_time = $4 WHERE event_id = $1 AND user_id = $2",
+				e.ID,
+				a.UserID,
+				a.Status,
+				now,
+			)
+			return err
+		},
 	)
+}
+
+// changeAttendees locks an event out of the trash and changes its attendees with fn, then it increments
+// the event version, replaces its pending notifications and records the change in the same transaction.
+func (repo *eventDBRepository) changeAttendees(
+	ctx context.Context,
+	eventID string,
+	action string,
+	fn func(tx *sqlx.Tx, e *domain.Event, now time.Time) error,
+) (*domain.Event, error) {
+	now := time.Now().UTC()
+	var after *domain.Event
+	err := inTx(ctx, func(tx *sqlx.Tx) error {
+		before, err := repo.lockAnyEvent(ctx, tx, eventID)
+		if common.IsErr(err) {
+			return err
+		}
+		if before.DeletedTime != nil {
+			return domain.ErrEventNotExist
+		}
+		after = before.Copy()
+		if err := fn(tx, after, now); common.IsErr(err) {
+			return err
+		}
+		_, err = tx.ExecContext(
+			ctx, "UPDATE event SET updated_time = $2, version = version + 1 WHERE id = $1", eventID, now,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		after.Version++
+		if err := replaceNotifications(ctx, tx, after, now.Add(-domain.NotificationGracePeriod)); common.IsErr(err) {
+			return err
+		}
+		return recordChange(ctx, tx, domain.NewEventChange(ctx, action, before, after))
+	})
 	if common.IsErr(err) {
-		return err
+		return nil, err
 	}
-	_, err = result.RowsAffected()
+	return after, nil
+}
+
+// GetInvitations returns a list of events the user is invited to, recurring events are not expanded.
+func (repo *eventDBRepository) GetInvitations(
+	ctx context.Context, userID int64, status domain.AttendeeStatus,
+) ([]*domain.Event, error) {
+	query := `SELECT ` + eventFields + ` FROM event WHERE deleted_time IS NULL
+			  AND id IN (SELECT event_id FROM event_attendee WHERE user_id = $1 AND ($2 = '' OR status = $2))
+			  ORDER BY start_time, id`
+	return repo.getEvents(ctx, query, userID, status)
+}
+
+// GetDeletedEvents returns a list of the user events in the trash, recurring events are not expanded.
+func (repo *eventDBRepository) GetDeletedEvents(ctx context.Context, userID int64) ([]*domain.Event, error) {
+	query := `SELECT ` + eventFields + ` FROM event WHERE user_id = $1 AND deleted_time IS NOT NULL
+			  ORDER BY deleted_time DESC, id`
+	return repo.getEvents(ctx, query, userID)
+}
+
+// PurgeEvents removes events deleted before deletedBefore and events which ended before expiredBefore,
+// recurring events are expired after their last occurrence.
+func (repo *eventDBRepository) PurgeEvents(ctx context.Context, expiredBefore, deletedBefore time.Time) error {
+	_, err := db.ExecContext(
+		ctx,
+		`DELETE FROM event WHERE deleted_time <= $2
+		 OR (start_time <= $1 AND (recurrence_rule IS NULL OR recurrence_end <= $1))`,
+		expiredBefore,
+		deletedBefore,
+	)
 	return err
 }
 
 func (repo *eventDBRepository) getEvents(
-	query string, args ...interface{},
+	ctx context.Context, query string, args ...interface{},
 ) ([]*domain.Event, error) {
-	rows, err := db.Query(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if common.IsErr(err) {
 		return nil, err
 	}
@@ -161,21 +598,11 @@ func (repo *eventDBRepository) getEvents(
 	}(rows)
 	var events []*domain.Event
 	for rows.Next() {
-		var e domain.Event
-		if err := rows.Scan(
-			&e.ID,
-			&e.Title,
-			&e.StartTime,
-			&e.EndTime,
-			&e.NotifyTime,
-			&e.Description,
-			&e.UserID,
-			&e.CreatedTime,
-		); common.IsErr(err) {
+		e, err := scanEvent(rows)
+		if common.IsErr(err) {
 			return nil, err
 		}
-		e.NormalizeTime()
-		events = append(events, &e)
+		events = append(events, e)
 	}
 	if err := rows.Err(); common.IsErr(err) {
 		return nil, err
@@ -183,22 +610,178 @@ func (repo *eventDBRepository) getEvents(
 	return events, nil
 }
 
-// GetEventsByPeriod returns a list of events for a period of time.
+// GetEventsByPeriod returns a list of the events visible to the user for a period of time,
+// recurring events are expanded to occurrences.
 func (repo *eventDBRepository) GetEventsByPeriod(
-	startTime, endTime time.Time,
+	ctx context.Context, userID int64, startTime, endTime time.Time,
 ) ([]*domain.Event, error) {
-	query := `SELECT id, title, start_time, end_time, notify_time, description, user_id, 
-       		  created_time FROM event WHERE start_time >= $1 AND end_time <= $2`
-	return repo.getEvents(query, startTime, endTime)
+	query := `SELECT ` + eventFields + ` FROM event WHERE ` + visibleCondition + ` AND deleted_time IS NULL
+			  AND ` + periodCondition
+	events, err := repo.getEvents(ctx, query, userID, startTime, endTime, wallClock(startTime), wallClock(endTime))
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return expandEvents(events, func(e *domain.Event) []*domain.Event {
+		return e.OccurrencesByPeriod(startTime, endTime)
+	}), nil
+}
+
+// queryArgs collects positional query arguments.
+type queryArgs []interface{}
+
+// add adds the argument and returns its placeholder.
+func (a *queryArgs) add(value interface{}) string {
+	*a = append(*a, value)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// wallClock returns the wall clock of the time as a UTC time, it's passed as a timestamp without time zone.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
 }
 
-// GetEventsByNotifyTime returns a list of events by notify time.
-func (repo *eventDBRepository) GetEventsByNotifyTime(
-	startTime, endTime time.Time,
+// filterConditions returns conditions of the filter fields except the period and the cursor,
+// the user, the period and its wall clock are the first five arguments of periodCondition.
+func filterConditions(filter *domain.EventFilter) (string, *queryArgs) {
+	args := &queryArgs{
+		filter.UserID, filter.StartTime, filter.EndTime, wallClock(filter.StartTime), wallClock(filter.EndTime),
+	}
+	conditions := []string{visibleCondition, "deleted_time IS NULL"}
+	if filter.OwnerID != 0 {
+		conditions = append(conditions, "user_id = "+args.add(filter.OwnerID))
+	}
+	if filter.CalendarID != "" {
+		conditions = append(conditions, "calendar_id = "+args.add(filter.CalendarID))
+	}
+	if filter.Title != "" {
+		conditions = append(conditions, "strpos(lower(title), lower("+args.add(filter.Title)+")) > 0")
+	}
+	if filter.HasNotification != nil {
+		if *filter.HasNotification {
+			conditions = append(conditions, "EXISTS (SELECT 1 FROM event_reminder r WHERE r.event_id = event.id)")
+		} else {
+			conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM event_reminder r WHERE r.event_id = event.id)")
+		}
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// filterOrder returns the ORDER BY direction and the keyset comparison operator of the filter.
+func filterOrder(filter *domain.EventFilter) (string, string) {
+	if filter.Descending {
+		return "DESC", "<"
+	}
+	return "ASC", ">"
+}
+
+// GetEventsPage returns a page of the user events for a period of time, recurring events are expanded to occurrences.
+// Single events are paginated by a keyset over (start_time, id), recurring series are expanded and merged with them.
+func (repo *eventDBRepository) GetEventsPage(
+	ctx context.Context, filter *domain.EventFilter,
+) (*domain.EventPage, error) {
+	where, args := filterConditions(filter)
+	single := where + " AND recurrence_rule IS NULL AND start_time >= " + periodStart + " AND end_time <= " + periodEnd
+	order, op := filterOrder(filter)
+	if filter.Cursor != nil {
+		single += " AND (start_time, id) " + op + " (" + args.add(filter.Cursor.StartTime) + ", " +
+			args.add(filter.Cursor.ID) + ")"
+	}
+	single += " ORDER BY start_time " + order + ", id " + order
+	if filter.PageSize > 0 {
+		single += " LIMIT " + args.add(filter.PageSize+1)
+	}
+	query := `(SELECT ` + eventFields + ` FROM event WHERE ` + single + `)
+			  UNION ALL
+			  (SELECT ` + eventFields + ` FROM event WHERE ` + where + ` AND recurrence_rule IS NOT NULL
+			  AND start_time <= ` + periodEnd + ` AND (recurrence_end IS NULL OR recurrence_end >= ` + periodStart + `))`
+	events, err := repo.getEvents(ctx, query, *args...)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return filter.Page(expandEvents(events, func(e *domain.Event) []*domain.Event {
+		return e.OccurrencesByPeriod(filter.StartTime, filter.EndTime)
+	})), nil
+}
+
+// IterateEvents calls fn for the user events for a period of time reading them row by row,
+// occurrences of a recurring event follow each other in the order of the series start time.
+func (repo *eventDBRepository) IterateEvents(
+	ctx context.Context,
+	filter *domain.EventFilter,
+	fn func(e *domain.Event) error,
+) error {
+	where, args := filterConditions(filter)
+	order, _ := filterOrder(filter)
+	query := `SELECT ` + eventFields + ` FROM event WHERE ` + where + ` AND ` + periodCondition + `
+			  ORDER BY start_time ` + order + `, id ` + order
+	rows, err := db.QueryContext(ctx, query, *args...)
+	if common.IsErr(err) {
+		return err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if common.IsErr(err) {
+			common.Logger.Error().Err(err).Msg("error closing rows")
+		}
+	}(rows)
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if common.IsErr(err) {
+			return err
+		}
+		if err := iterateOccurrences(ctx, filter, e, fn); common.IsErr(err) {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// iterateOccurrences calls fn for the event occurrences within the filter period in the filter order
+// or once for the whole series if the filter requires.
+func iterateOccurrences(
+	ctx context.Context,
+	filter *domain.EventFilter,
+	e *domain.Event,
+	fn func(e *domain.Event) error,
+) error {
+	occurrences := e.OccurrencesByPeriod(filter.StartTime, filter.EndTime)
+	if filter.Series && e.Recurrence != nil && len(occurrences) > 0 {
+		occurrences = []*domain.Event{e}
+	}
+	for i := range occurrences {
+		if err := ctx.Err(); common.IsErr(err) {
+			return err
+		}
+		o := occurrences[i]
+		if filter.Descending {
+			o = occurrences[len(occurrences)-1-i]
+		}
+		if err := fn(o); common.IsErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetOverlappingEvents returns a list of the user events which overlap a period of time,
+// recurring events are expanded to occurrences.
+func (repo *eventDBRepository) GetOverlappingEvents(
+	ctx context.Context, userID int64, startTime, endTime time.Time,
 ) ([]*domain.Event, error) {
-	query := `SELECT id, title, start_time, end_time, notify_time, description, user_id, 
-	   		  created_time FROM event WHERE notify_time >= $1 AND notify_time <= $2`
-	return repo.getEvents(query, startTime, endTime)
+	query := `SELECT ` + eventFields + ` FROM event WHERE user_id = $1 AND deleted_time IS NULL
+			  AND ((recurrence_rule IS NULL
+			  AND tstzrange(start_time, COALESCE(end_time, start_time), '[]')
+			  && tstzrange($2::timestamptz, $3::timestamptz, '[]'))
+			  OR (recurrence_rule IS NOT NULL AND start_time <= $3::timestamptz
+			  AND (recurrence_end IS NULL
+			  OR recurrence_end + (COALESCE(end_time, start_time) - start_time) >= $2::timestamptz)))`
+	events, err := repo.getEvents(ctx, query, userID, startTime, endTime)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return expandEvents(events, func(e *domain.Event) []*domain.Event {
+		return e.OccurrencesByRange(startTime, endTime)
+	}), nil
 }
 
 type eventCacheRepository struct{}
@@ -209,11 +792,17 @@ func NewEventCacheRepository() domain.EventRepository {
 }
 
 // Add adds a new event to the cache.
-func (repo *eventCacheRepository) Add(event *domain.Event) error {
+func (repo *eventCacheRepository) Add(ctx context.Context, event *domain.Event) error {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
 	key := []byte(event.ID)
 	createdTime := time.Now().UTC()
 	event.CreatedTime = &createdTime
+	event.Version = 1
 	event.NormalizeTime()
+	if event.CalendarID == "" {
+		event.CalendarID = cacheCalendars.defaultCalendarID(event.UserID)
+	}
 	if _, err := cacheDB.Get(key); err == nil {
 		return domain.ErrEventExist
 	}
@@ -224,30 +813,50 @@ func (repo *eventCacheRepository) Add(event *domain.Event) error {
 	if err := cacheDB.Set(key, data, 0); common.IsErr(err) {
 		return err
 	}
-	return nil
+	cacheOutbox.enqueue(ctx, event, createdTime.Add(-domain.NotificationGracePeriod))
+	return cacheHistory.record(ctx, domain.EventActionCreate, nil, event)
 }
 
-// Update updates an existing event in the cache.
-func (repo *eventCacheRepository) Update(event *domain.Event) error {
-	key := []byte(event.ID)
+// Update updates an existing event of the user in the cache if its version matches.
+func (repo *eventCacheRepository) Update(ctx context.Context, userID int64, event *domain.Event) error {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
 	event.NormalizeTime()
-	if _, err := cacheDB.Get(key); common.IsErr(err) {
-		return domain.ErrEventNotExist
-	}
-	data, err := json.Marshal(event)
+	e, err := repo.getActive(userID, event.ID)
 	if common.IsErr(err) {
 		return err
 	}
-	if err := cacheDB.Set(key, data, 0); common.IsErr(err) {
+	if e.UserID != event.UserID {
+		return domain.ErrPermission
+	}
+	if e.Version != event.Version {
+		return domain.ErrVersionConflict
+	}
+	if event.CalendarID == "" {
+		event.CalendarID = e.CalendarID
+	}
+	event.CreatedTime = e.CreatedTime
+	event.Version = e.Version + 1
+	event.Attendees = e.Attendees
+	if err := repo.set(event); common.IsErr(err) {
 		return err
 	}
-	return nil
+	cacheOutbox.replace(ctx, event, time.Now().UTC().Add(-domain.NotificationGracePeriod))
+	return cacheHistory.record(ctx, domain.EventActionUpdate, e, event)
 }
 
-// Get returns an event by ID.
-func (repo *eventCacheRepository) Get(eventID string) (*domain.Event, error) {
-	key := []byte(eventID)
-	data, err := cacheDB.Get(key)
+// set saves the event to the cache.
+func (repo *eventCacheRepository) set(event *domain.Event) error {
+	data, err := json.Marshal(event)
+	if common.IsErr(err) {
+		return err
+	}
+	return cacheDB.Set([]byte(event.ID), data, 0)
+}
+
+// load returns an event of any user by ID in the trash or out of it.
+func (repo *eventCacheRepository) load(eventID string) (*domain.Event, error) {
+	data, err := cacheDB.Get([]byte(eventID))
 	if common.IsErr(err) {
 		return nil, domain.ErrEventNotExist
 	}
@@ -259,46 +868,243 @@ func (repo *eventCacheRepository) Get(eventID string) (*domain.Event, error) {
 	return event, nil
 }
 
-// Delete removes an event by ID.
-func (repo *eventCacheRepository) Delete(eventID string) error {
-	key := []byte(eventID)
-	if _, err := cacheDB.Get(key); common.IsErr(err) {
-		return domain.ErrEventNotExist
+// get returns an event which the user may write to by ID in the trash or out of it.
+func (repo *eventCacheRepository) get(userID int64, eventID string) (*domain.Event, error) {
+	event, err := repo.load(eventID)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if !repo.allows(userID, event, domain.CalendarAccessWrite) {
+		return nil, domain.ErrPermission
+	}
+	return event, nil
+}
+
+// allows reports whether the user owns the event or is granted the access to its calendar.
+func (repo *eventCacheRepository) allows(userID int64, event *domain.Event, access domain.CalendarAccess) bool {
+	return event.UserID == userID || cacheCalendars.access(userID, event.CalendarID).Allows(access)
+}
+
+// visible reports whether the user attends the event or may read its calendar.
+func (repo *eventCacheRepository) visible(userID int64, event *domain.Event) bool {
+	return event.Attends(userID) || repo.allows(userID, event, domain.CalendarAccessRead)
+}
+
+// getActive returns an event which the user may write to by ID out of the trash.
+func (repo *eventCacheRepository) getActive(userID int64, eventID string) (*domain.Event, error) {
+	event, err := repo.get(userID, eventID)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if event.DeletedTime != nil {
+		return nil, domain.ErrEventNotExist
+	}
+	return event, nil
+}
+
+// Get returns an event of the user, an event the user is invited to or an event of a calendar shared
+// with the user by ID.
+func (repo *eventCacheRepository) Get(_ context.Context, userID int64, eventID string) (*domain.Event, error) {
+	event, err := repo.load(eventID)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if !event.CanView(userID) && !repo.allows(userID, event, domain.CalendarAccessRead) {
+		return nil, domain.ErrPermission
+	}
+	if event.DeletedTime != nil {
+		return nil, domain.ErrEventNotExist
+	}
+	return event, nil
+}
+
+// Delete moves an event of the user to the trash.
+func (repo *eventCacheRepository) Delete(ctx context.Context, userID int64, eventID string) error {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
+	before, err := repo.getActive(userID, eventID)
+	if common.IsErr(err) {
+		return err
+	}
+	event := *before
+	deletedTime := time.Now().UTC()
+	event.DeletedTime = &deletedTime
+	event.Version++
+	event.NormalizeTime()
+	if err := repo.set(&event); common.IsErr(err) {
+		return err
+	}
+	cacheOutbox.remove(eventID)
+	return cacheHistory.record(ctx, domain.EventActionDelete, before, &event)
+}
+
+// DeletePermanently removes an event of the user by ID.
+func (repo *eventCacheRepository) DeletePermanently(ctx context.Context, userID int64, eventID string) error {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
+	before, err := repo.get(userID, eventID)
+	if common.IsErr(err) {
+		return err
 	}
-	if affected := cacheDB.Del(key); !affected {
+	if affected := cacheDB.Del([]byte(eventID)); !affected {
 		return errors.New("event deletion failed")
 	}
-	return nil
+	cacheOutbox.remove(eventID)
+	return cacheHistory.record(ctx, domain.EventActionDeletePermanently, before, nil)
 }
 
-// DeleteEventBeforeDate removes an event before date.
-func (repo *eventCacheRepository) DeleteEventBeforeDate(date time.Time) error {
-	keys := cacheDB.Keys()
-	for _, key := range keys {
-		data, err := cacheDB.Get(key)
-		if common.IsErr(err) {
-			return err
+// Restore restores an event of the user from the trash.
+func (repo *eventCacheRepository) Restore(ctx context.Context, userID int64, eventID string) (*domain.Event, error) {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
+	before, err := repo.get(userID, eventID)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if before.DeletedTime == nil {
+		return nil, domain.ErrEventNotExist
+	}
+	event := *before
+	event.DeletedTime = nil
+	event.Version++
+	if err := repo.set(&event); common.IsErr(err) {
+		return nil, err
+	}
+	cacheOutbox.enqueue(ctx, &event, time.Now().UTC().Add(-domain.NotificationGracePeriod))
+	if err := cacheHistory.record(ctx, domain.EventActionRestore, before, &event); common.IsErr(err) {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// InviteAttendees invites the users to an event of the user.
+func (repo *eventCacheRepository) InviteAttendees(
+	ctx context.Context, userID int64, eventID string, userIDs []int64,
+) (*domain.Event, error) {
+	return repo.changeAttendees(ctx, eventID, domain.EventActionInvite, func(e *domain.Event) error {
+		if !repo.allows(userID, e, domain.CalendarAccessWrite) {
+			return domain.ErrPermission
 		}
-		event := &domain.Event{}
-		err = json.Unmarshal(data, event)
-		if common.IsErr(err) {
-			return err
+		_, err := e.Invite(userIDs)
+		return err
+	})
+}
+
+// RespondInvitation sets the response of the user to an event the user is invited to.
+func (repo *eventCacheRepository) RespondInvitation(
+	ctx context.Context, userID int64, eventID string, status domain.AttendeeStatus,
+) (*domain.Event, error) {
+	return repo.changeAttendees(ctx, eventID, domain.EventActionRespond, func(e *domain.Event) error {
+		_, err := e.Respond(userID, status)
+		return err
+	})
+}
+
+// changeAttendees changes attendees of an event out of the trash with fn, increments the event version
+// and replaces its pending notifications.
+func (repo *eventCacheRepository) changeAttendees(
+	ctx context.Context, eventID, action string, fn func(e *domain.Event) error,
+) (*domain.Event, error) {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
+	before, err := repo.load(eventID)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if before.DeletedTime != nil {
+		return nil, domain.ErrEventNotExist
+	}
+	event := before.Copy()
+	if err := fn(event); common.IsErr(err) {
+		return nil, err
+	}
+	event.Version++
+	if err := repo.set(event); common.IsErr(err) {
+		return nil, err
+	}
+	cacheOutbox.replace(ctx, event, time.Now().UTC().Add(-domain.NotificationGracePeriod))
+	if err := cacheHistory.record(ctx, action, before, event); common.IsErr(err) {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetInvitations returns a list of events the user is invited to, recurring events are not expanded.
+func (repo *eventCacheRepository) GetInvitations(
+	_ context.Context, userID int64, status domain.AttendeeStatus,
+) ([]*domain.Event, error) {
+	events, err := repo.getEvents()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	var result []*domain.Event
+	for _, e := range events {
+		if a := e.Attendee(userID); a != nil && (status == "" || a.Status == status) {
+			result = append(result, e)
 		}
-		if event.StartTime.Before(date) || event.StartTime.Equal(date) {
-			if affected := cacheDB.Del(key); !affected {
-				return errors.New("event deletion failed")
-			}
+	}
+	return (&domain.EventFilter{}).Page(result).Events, nil
+}
+
+// GetDeletedEvents returns a list of the user events in the trash, recurring events are not expanded.
+func (repo *eventCacheRepository) GetDeletedEvents(_ context.Context, userID int64) ([]*domain.Event, error) {
+	events, err := repo.getAllEvents()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	var result []*domain.Event
+	for _, e := range events {
+		if e.UserID == userID && e.DeletedTime != nil {
+			result = append(result, e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].DeletedTime.Equal(*result[j].DeletedTime) {
+			return result[i].DeletedTime.After(*result[j].DeletedTime)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// PurgeEvents removes events deleted before deletedBefore and events which ended before expiredBefore,
+// recurring events are expired after their last occurrence.
+func (repo *eventCacheRepository) PurgeEvents(_ context.Context, expiredBefore, deletedBefore time.Time) error {
+	cacheEventsMu.Lock()
+	defer cacheEventsMu.Unlock()
+	events, err := repo.getAllEvents()
+	if common.IsErr(err) {
+		return err
+	}
+	for _, event := range events {
+		if !repo.purgeable(event, expiredBefore, deletedBefore) {
+			continue
 		}
+		if affected := cacheDB.Del([]byte(event.ID)); !affected {
+			return errors.New("event deletion failed")
+		}
+		cacheOutbox.remove(event.ID)
 	}
 	return nil
 }
 
-// GetEventsByPeriod returns a list of events for a period of time.
-func (repo *eventCacheRepository) GetEventsByPeriod(
-	startTime, endTime time.Time,
-) ([]*domain.Event, error) {
+// purgeable reports whether the event was deleted before deletedBefore or ended before expiredBefore.
+func (repo *eventCacheRepository) purgeable(event *domain.Event, expiredBefore, deletedBefore time.Time) bool {
+	if event.DeletedTime != nil && !event.DeletedTime.After(deletedBefore) {
+		return true
+	}
+	if event.Recurrence != nil {
+		if end := event.RecurrenceEnd(); end == nil || end.After(expiredBefore) {
+			return false
+		}
+	}
+	return !event.StartTime.After(expiredBefore)
+}
+
+// getAllEvents returns all events of the cache including the events in the trash.
+func (repo *eventCacheRepository) getAllEvents() ([]*domain.Event, error) {
 	keys := cacheDB.Keys()
-	var result []*domain.Event
+	result := make([]*domain.Event, 0, len(keys))
 	for _, key := range keys {
 		data, err := cacheDB.Get(key)
 		if common.IsErr(err) {
@@ -309,38 +1115,97 @@ func (repo *eventCacheRepository) GetEventsByPeriod(
 		if common.IsErr(err) {
 			return nil, err
 		}
-		// For time equality in if statement
-		startTime = startTime.Add(-time.Millisecond)
-		endTime = endTime.Add(time.Millisecond)
-		if event.StartTime.After(startTime) && event.EndTime.Before(endTime) {
-			result = append(result, event)
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+// getEvents returns events of the cache out of the trash.
+func (repo *eventCacheRepository) getEvents() ([]*domain.Event, error) {
+	events, err := repo.getAllEvents()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	result := make([]*domain.Event, 0, len(events))
+	for _, e := range events {
+		if e.DeletedTime == nil {
+			result = append(result, e)
 		}
 	}
 	return result, nil
 }
 
-// GetEventsByNotifyTime returns a list of events by notify time.
-func (repo *eventCacheRepository) GetEventsByNotifyTime(
-	startTime, endTime time.Time,
+// GetEventsByPeriod returns a list of the events visible to the user for a period of time,
+// recurring events are expanded to occurrences.
+func (repo *eventCacheRepository) GetEventsByPeriod(
+	_ context.Context, userID int64, startTime, endTime time.Time,
 ) ([]*domain.Event, error) {
-	keys := cacheDB.Keys()
-	var result []*domain.Event
-	for _, key := range keys {
-		data, err := cacheDB.Get(key)
-		if common.IsErr(err) {
-			return nil, err
+	events, err := repo.getEvents()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return expandEvents(events, func(e *domain.Event) []*domain.Event {
+		if !repo.visible(userID, e) {
+			return nil
 		}
-		event := &domain.Event{}
-		err = json.Unmarshal(data, event)
-		if common.IsErr(err) {
-			return nil, err
+		return e.OccurrencesByPeriod(startTime, endTime)
+	}), nil
+}
+
+// GetEventsPage returns a page of the user events for a period of time, recurring events are expanded to occurrences.
+func (repo *eventCacheRepository) GetEventsPage(
+	_ context.Context, filter *domain.EventFilter,
+) (*domain.EventPage, error) {
+	events, err := repo.getEvents()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return filter.Page(expandEvents(events, func(e *domain.Event) []*domain.Event {
+		if !repo.visible(filter.UserID, e) || !filter.Match(e) {
+			return nil
+		}
+		return e.OccurrencesByPeriod(filter.StartTime, filter.EndTime)
+	})), nil
+}
+
+// IterateEvents calls fn for the user events for a period of time,
+// occurrences of a recurring event follow each other in the order of the series start time.
+func (repo *eventCacheRepository) IterateEvents(
+	ctx context.Context,
+	filter *domain.EventFilter,
+	fn func(e *domain.Event) error,
+) error {
+	events, err := repo.getEvents()
+	if common.IsErr(err) {
+		return err
+	}
+	var matched []*domain.Event
+	for _, e := range events {
+		if repo.visible(filter.UserID, e) && filter.Match(e) {
+			matched = append(matched, e)
 		}
-		// For time equality in if statement
-		startTime = startTime.Add(-time.Millisecond)
-		endTime = endTime.Add(time.Millisecond)
-		if event.NotifyTime.After(startTime) && event.NotifyTime.Before(endTime) {
-			result = append(result, event)
+	}
+	for _, e := range (&domain.EventFilter{Descending: filter.Descending}).Page(matched).Events {
+		if err := iterateOccurrences(ctx, filter, e, fn); common.IsErr(err) {
+			return err
 		}
 	}
-	return result, nil
+	return nil
+}
+
+// GetOverlappingEvents returns a list of the user events which overlap a period of time,
+// recurring events are expanded to occurrences.
+func (repo *eventCacheRepository) GetOverlappingEvents(
+	_ context.Context, userID int64, startTime, endTime time.Time,
+) ([]*domain.Event, error) {
+	events, err := repo.getEvents()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return expandEvents(events, func(e *domain.Event) []*domain.Event {
+		if e.UserID != userID {
+			return nil
+		}
+		return e.OccurrencesByRange(startTime, endTime)
+	}), nil
 }
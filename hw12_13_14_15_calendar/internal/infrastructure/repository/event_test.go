@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -30,7 +34,7 @@ func (s *eventDBTestSuite) SetupSuite() {
 }
 
 func (s *eventDBTestSuite) TearDownTest() {
-	_, err := db.Exec("TRUNCATE TABLE event CASCADE")
+	_, err := db.Exec("TRUNCATE TABLE event, event_history, calendar CASCADE")
 	if common.IsErr(err) {
 		panic(err)
 	}
@@ -38,44 +42,57 @@ func (s *eventDBTestSuite) TearDownTest() {
 
 func (s *eventDBTestSuite) setEventInDB() *domain.Event {
 	e := tests.GenerateTestEvent()
-	err := s.repo.Add(e)
+	err := s.repo.Add(context.Background(), e)
 	s.NoError(err)
 	return e
 }
 
 func (s *eventDBTestSuite) TestNonExistedEvent() {
-	_ = s.setEventInDB()
+	e := s.setEventInDB()
 	eventID := faker.UUIDHyphenated(options.WithGenerateUniqueValues(true))
-	event, err := s.repo.Get(eventID)
+	event, err := s.repo.Get(context.Background(), e.UserID, eventID)
 	s.Error(err)
 	s.Nil(event)
 }
 
 func (s *eventDBTestSuite) TestAddEvent() {
 	e := tests.GenerateTestEvent()
-	err := s.repo.Add(e)
+	err := s.repo.Add(context.Background(), e)
 	s.NoError(err)
 }
 
 func (s *eventDBTestSuite) TestAddEventWithExistingID() {
 	e := tests.GenerateTestEvent()
-	err := s.repo.Add(e)
+	err := s.repo.Add(context.Background(), e)
 	s.NoError(err)
-	err = s.repo.Add(e)
+	err = s.repo.Add(context.Background(), e)
 	s.Error(err)
 }
 
 func (s *eventDBTestSuite) TestUpdateEvent() {
 	e := tests.GenerateTestEvent()
-	err := s.repo.Add(e)
+	err := s.repo.Add(context.Background(), e)
+	s.NoError(err)
+	err = s.repo.Update(context.Background(), e.UserID, e)
 	s.NoError(err)
-	err = s.repo.Update(e)
+}
+
+func (s *eventDBTestSuite) TestUpdateEventVersionConflict() {
+	e := s.setEventInDB()
+	first, second := *e, *e
+	first.Title = "First"
+	s.NoError(s.repo.Update(context.Background(), first.UserID, &first))
+	s.Equal(e.Version+1, first.Version)
+	second.Title = "Second"
+	s.ErrorIs(s.repo.Update(context.Background(), second.UserID, &second), domain.ErrVersionConflict)
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
+	s.Equal(&first, result)
 }
 
 func (s *eventDBTestSuite) TestGetEvent() {
 	e := s.setEventInDB()
-	result, err := s.repo.Get(e.ID)
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
 	s.NotNil(result)
 	s.Equal(e, result)
@@ -83,33 +100,75 @@ func (s *eventDBTestSuite) TestGetEvent() {
 
 func (s *eventDBTestSuite) TestDeleteEvent() {
 	e := s.setEventInDB()
-	result, err := s.repo.Get(e.ID)
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
 	s.NotNil(result)
-	err = s.repo.Delete(e.ID)
+	err = s.repo.Delete(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
+	_, err = s.repo.Get(context.Background(), e.UserID, e.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, e.StartTime, *e.EndTime)
+	s.NoError(err)
+	s.Empty(events)
 }
 
-func (s *eventDBTestSuite) TestDeleteEventsBeforeDate() {
+func (s *eventDBTestSuite) TestRestoreEvent() {
 	e := s.setEventInDB()
-	result, err := s.repo.Get(e.ID)
+	s.NoError(s.repo.Delete(context.Background(), e.UserID, e.ID))
+	deleted, err := s.repo.GetDeletedEvents(context.Background(), e.UserID)
 	s.NoError(err)
-	s.NotNil(result)
-	err = s.repo.DeleteEventBeforeDate(e.StartTime)
+	s.Len(deleted, 1)
+	s.NotNil(deleted[0].DeletedTime)
+	result, err := s.repo.Restore(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
+	s.Equal(e, result)
+	_, err = s.repo.Restore(context.Background(), e.UserID, e.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+}
+
+func (s *eventDBTestSuite) TestHistory() {
+	e := tests.GenerateTestEvent()
+	ctx := changeContext(e.UserID)
+	s.NoError(s.repo.Add(ctx, e))
+	s.NoError(s.repo.Update(ctx, e.UserID, e))
+	s.NoError(s.repo.DeletePermanently(ctx, e.UserID, e.ID))
+	changes, err := s.repo.GetHistory(context.Background(), e.UserID, e.ID)
+	s.NoError(err)
+	s.Len(changes, 3)
+	s.Equal(domain.EventActionCreate, changes[0].Action)
+	s.Equal(e, changes[1].After)
+	s.Equal(domain.EventActionDeletePermanently, changes[2].Action)
+	s.Nil(changes[2].After)
+}
+
+func (s *eventDBTestSuite) TestPurgeEvents() {
+	e := s.setEventInDB()
+	deleted := s.setEventInDB()
+	s.NoError(s.repo.Delete(context.Background(), deleted.UserID, deleted.ID))
+	err := s.repo.PurgeEvents(context.Background(), e.StartTime.Add(-time.Hour), time.Now().Add(time.Minute))
+	s.NoError(err)
+	_, err = s.repo.Get(context.Background(), e.UserID, e.ID)
+	s.NoError(err)
+	events, err := s.repo.GetDeletedEvents(context.Background(), deleted.UserID)
+	s.NoError(err)
+	s.Empty(events)
+	err = s.repo.PurgeEvents(context.Background(), e.StartTime, time.Now().Add(-time.Hour))
+	s.NoError(err)
+	_, err = s.repo.Get(context.Background(), e.UserID, e.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
 }
 
 func (s *eventDBTestSuite) TestListEventsByPeriodWithNoEvents() {
 	startTime := time.Now()
 	endTime := time.Now().Add(time.Hour)
-	events, err := s.repo.GetEventsByPeriod(startTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), 1, startTime, endTime)
 	s.NoError(err)
 	s.Empty(events)
 }
 
 func (s *eventDBTestSuite) TestListEventsByPeriodWithSingleEvent() {
 	e := s.setEventInDB()
-	events, err := s.repo.GetEventsByPeriod(e.StartTime, *e.EndTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, e.StartTime, *e.EndTime)
 	s.NoError(err)
 	s.Len(events, 1)
 	s.Equal(e, events[0])
@@ -117,9 +176,12 @@ func (s *eventDBTestSuite) TestListEventsByPeriodWithSingleEvent() {
 
 func (s *eventDBTestSuite) TestListEventsByPeriodWithMultipleEvents() {
 	e1 := s.setEventInDB()
-	e2 := s.setEventInDB()
+	e2 := tests.GenerateTestEvent()
+	e2.UserID = e1.UserID
+	s.NoError(s.repo.Add(context.Background(), e2))
+	_ = s.setEventInDB()
 	startTime, endTime := tests.GetEventStartEndTime(e1, e2)
-	events, err := s.repo.GetEventsByPeriod(startTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e1.UserID, startTime, endTime)
 	s.NoError(err)
 	s.Len(events, 2)
 	s.Equal(events[0], e1)
@@ -129,7 +191,164 @@ func (s *eventDBTestSuite) TestListEventsByPeriodWithMultipleEvents() {
 func (s *eventDBTestSuite) TestListEventsByPeriodWithEventOutsidePeriod() {
 	e := s.setEventInDB()
 	endTime := e.EndTime.Add(time.Minute)
-	events, err := s.repo.GetEventsByPeriod(endTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, endTime, endTime)
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *eventDBTestSuite) TestListRecurringEventsByPeriod() {
+	e := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	s.NoError(err)
+	e.Recurrence = recurrence
+	s.NoError(s.repo.Add(context.Background(), e))
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
+	s.NoError(err)
+	s.Equal(e.Recurrence, result.Recurrence)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, e.StartTime, e.EndTime.AddDate(0, 0, 7))
+	s.NoError(err)
+	s.Len(events, 3)
+}
+
+func (s *eventDBTestSuite) TestGetOverlappingEvents() {
+	e := s.setEventInDB()
+	events, err := s.repo.GetOverlappingEvents(
+		context.Background(), e.UserID, e.StartTime.Add(-time.Hour), e.StartTime.Add(time.Minute),
+	)
+	s.NoError(err)
+	s.Len(events, 1)
+	events, err = s.repo.GetOverlappingEvents(context.Background(), e.UserID, *e.EndTime, e.EndTime.Add(time.Hour))
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *eventDBTestSuite) TestGetEventsPage() {
+	e1 := s.setEventInDB()
+	e2 := tests.GenerateTestEvent()
+	e2.UserID = e1.UserID
+	e2.StartTime = e1.StartTime.Add(time.Minute)
+	s.NoError(s.repo.Add(context.Background(), e2))
+	filter := &domain.EventFilter{
+		UserID:    e1.UserID,
+		StartTime: e1.StartTime,
+		EndTime:   e2.EndTime.Add(time.Hour),
+		PageSize:  1,
+	}
+	page, err := s.repo.GetEventsPage(context.Background(), filter)
+	s.NoError(err)
+	s.Equal([]*domain.Event{e1}, page.Events)
+	s.NotNil(page.NextCursor)
+	filter.Cursor = page.NextCursor
+	page, err = s.repo.GetEventsPage(context.Background(), filter)
+	s.NoError(err)
+	s.Equal([]*domain.Event{e2}, page.Events)
+	s.Nil(page.NextCursor)
+}
+
+func (s *eventDBTestSuite) TestListAllDayEvents() {
+	event := tests.GenerateTestEvent()
+	event.StartTime = time.Date(2030, time.December, 25, 0, 0, 0, 0, time.UTC)
+	event.TimeZone = "Europe/Berlin"
+	event.AllDay = true
+	s.NoError(s.repo.Add(context.Background(), event))
+	s.Equal(time.Date(2030, time.December, 24, 23, 0, 0, 0, time.UTC), event.StartTime)
+	newYork, err := time.LoadLocation("America/New_York")
+	s.NoError(err)
+	day := time.Date(2030, time.December, 25, 0, 0, 0, 0, newYork)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), event.UserID, day, day.AddDate(0, 0, 1))
+	s.NoError(err)
+	s.Equal([]*domain.Event{event}, events)
+	events, err = s.repo.GetEventsByPeriod(context.Background(), event.UserID, day.AddDate(0, 0, -1), day)
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *eventDBTestSuite) TestNotificationOutbox() {
+	e := withNotification(tests.GenerateTestEvent())
+	s.NoError(s.repo.Add(context.Background(), e))
+	outbox := NewNotificationOutboxDBRepository()
+	backoff := domain.Backoff{BaseDelay: time.Minute}
+	var published []*domain.OutboxMessage
+	publish := func(m *domain.OutboxMessage) error {
+		published = append(published, m)
+		return nil
+	}
+	count, err := outbox.ProcessNotifications(context.Background(), e.StartTime, 10, backoff, publish)
+	s.NoError(err)
+	s.Zero(count)
+	count, err = outbox.ProcessNotifications(context.Background(), reminderTime(e), 10, backoff, publish)
+	s.NoError(err)
+	s.Equal(1, count)
+	s.Equal(e.ID, published[0].EventID)
+	s.Equal(e.StartTime, published[0].Notifications[0].EventDate)
+	count, err = outbox.ProcessNotifications(context.Background(), reminderTime(e), 10, backoff, publish)
+	s.NoError(err)
+	s.Zero(count)
+}
+
+func (s *eventDBTestSuite) TestAttendees() {
+	e := withNotification(tests.GenerateTestEvent())
+	s.NoError(s.repo.Add(context.Background(), e))
+	attendee, declined := e.UserID+1, e.UserID+2
+	invited, err := s.repo.InviteAttendees(context.Background(), e.UserID, e.ID, []int64{declined, attendee})
+	s.NoError(err)
+	s.Equal(e.Version+1, invited.Version)
+	_, err = s.repo.RespondInvitation(context.Background(), declined, e.ID, domain.AttendeeDeclined)
+	s.NoError(err)
+	result, err := s.repo.Get(context.Background(), declined, e.ID)
+	s.NoError(err)
+	s.Equal(
+		[]*domain.Attendee{
+			{UserID: attendee, Status: domain.AttendeeInvited},
+			{UserID: declined, Status: domain.AttendeeDeclined},
+		},
+		result.Attendees,
+	)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), attendee, e.StartTime, *e.EndTime)
+	s.NoError(err)
+	s.Len(events, 1)
+	events, err = s.repo.GetEventsByPeriod(context.Background(), declined, e.StartTime, *e.EndTime)
+	s.NoError(err)
+	s.Empty(events)
+	invitations, err := s.repo.GetInvitations(context.Background(), attendee, domain.AttendeeInvited)
+	s.NoError(err)
+	s.Len(invitations, 1)
+	invitations, err = s.repo.GetInvitations(context.Background(), attendee, domain.AttendeeAccepted)
+	s.NoError(err)
+	s.Empty(invitations)
+	_, err = s.repo.InviteAttendees(context.Background(), attendee, e.ID, []int64{e.UserID + 3})
+	s.ErrorIs(err, domain.ErrPermission)
+}
+
+func (s *eventDBTestSuite) TestCalendarShares() {
+	ctx := context.Background()
+	calendars := NewCalendarDBRepository()
+	e := tests.GenerateTestEvent()
+	s.NoError(s.repo.Add(ctx, e))
+	s.NotEmpty(e.CalendarID)
+	reader, writer := e.UserID+1, e.UserID+2
+	_, err := s.repo.Get(ctx, reader, e.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+
+	share := &domain.CalendarShare{UserID: reader, Access: domain.CalendarAccessRead}
+	s.NoError(calendars.Share(ctx, e.UserID, e.CalendarID, share))
+	share = &domain.CalendarShare{UserID: writer, Access: domain.CalendarAccessWrite}
+	s.NoError(calendars.Share(ctx, e.UserID, e.CalendarID, share))
+	events, err := s.repo.GetEventsByPeriod(ctx, reader, e.StartTime, *e.EndTime)
+	s.NoError(err)
+	s.Len(events, 1)
+	result, err := s.repo.Get(ctx, reader, e.ID)
+	s.NoError(err)
+	s.ErrorIs(s.repo.Update(ctx, reader, result), domain.ErrPermission)
+	s.NoError(s.repo.Update(ctx, writer, result))
+	s.Equal(e.CalendarID, result.CalendarID)
+	s.ErrorIs(calendars.Delete(ctx, e.UserID, e.CalendarID), domain.ErrDefaultCalendar)
+
+	list, err := calendars.GetCalendars(ctx, reader)
+	s.NoError(err)
+	s.Len(list, 2)
+	s.NoError(calendars.Unshare(ctx, e.UserID, e.CalendarID, reader))
+	events, err = s.repo.GetEventsByPeriod(ctx, reader, e.StartTime, *e.EndTime)
 	s.NoError(err)
 	s.Empty(events)
 }
@@ -157,166 +376,481 @@ func (s *eventMockSQLTestSuite) SetupTest() {
 	db = sqlx.NewDb(mockDB, "sqlmock")
 }
 
+var eventColumns = []string{
+	"id",
+	"title",
+	"start_time",
+	"end_time",
+	"description",
+	"user_id",
+	"created_time",
+	"calendar_id",
+	"recurrence_rule",
+	"recurrence_exceptions",
+	"notification_channel",
+	"notification_address",
+	"deleted_time",
+	"version",
+	"time_zone",
+	"all_day",
+	"attendees",
+	"reminders",
+}
+
+func eventRow(e *domain.Event) []driver.Value {
+	var rule, exceptions, channel, address, attendees, reminders interface{}
+	if e.Recurrence != nil {
+		rule = e.Recurrence.Rule()
+		if len(e.Recurrence.Exceptions) > 0 {
+			exceptions, _ = json.Marshal(e.Recurrence.Exceptions)
+		}
+	}
+	if e.NotificationTarget != nil {
+		channel, address = e.NotificationTarget.Channel, e.NotificationTarget.Address
+	}
+	if e.Attendees != nil {
+		attendees, _ = json.Marshal(e.Attendees)
+	}
+	if e.Reminders != nil {
+		reminders, _ = json.Marshal(e.Reminders)
+	}
+	return []driver.Value{
+		e.ID,
+		e.Title,
+		e.StartTime,
+		e.EndTime,
+		e.Description,
+		e.UserID,
+		e.CreatedTime,
+		e.CalendarID,
+		rule,
+		exceptions,
+		channel,
+		address,
+		e.DeletedTime,
+		e.Version,
+		e.TimeZone,
+		e.AllDay,
+		attendees,
+		reminders,
+	}
+}
+
 func (s *eventMockSQLTestSuite) setEventInDB(e *domain.Event) *domain.Event {
-	rows := sqlmock.NewRows(
-		[]string{
-			"id", "title", "start_time", "end_time", "notify_time", "description", "user_id", "created_time",
-		},
-	).AddRow(e.ID, e.Title, e.StartTime, e.EndTime, e.NotifyTime, e.Description, e.UserID, e.CreatedTime)
-	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1$").
+	rows := sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...)
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
 		WithArgs(e.ID).
 		WillReturnRows(rows)
 	return e
 }
 
 func (s *eventMockSQLTestSuite) TestNonExistedEvent() {
-	_ = s.setEventInDB(tests.GenerateTestEvent())
+	e := s.setEventInDB(tests.GenerateTestEvent())
 	eventID := faker.UUIDHyphenated()
-	event, err := s.repo.Get(eventID)
+	event, err := s.repo.Get(context.Background(), e.UserID, eventID)
 	s.Error(err)
 	s.Nil(event)
 }
 
+// withNotification adds a reminder after the start of the event, so its notification is enqueued to the outbox.
+func withNotification(e *domain.Event) *domain.Event {
+	e.Reminders = []*domain.Reminder{{Offset: time.Minute}}
+	return e
+}
+
+// reminderTime returns the time of the first reminder of the event.
+func reminderTime(e *domain.Event) time.Time {
+	return e.Reminders[0].At(e.StartTime)
+}
+
+// expectReminders expects the reminders of the event to be inserted.
+func (s *eventMockSQLTestSuite) expectReminders(e *domain.Event) {
+	for _, r := range e.Reminders {
+		s.mock.ExpectExec("^INSERT INTO event_reminder (.+) VALUES (.+)$").
+			WithArgs(e.ID, r.Offset.Milliseconds(), r.Time).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+}
+
+// expectDefaultCalendar expects the default calendar of the user to be looked up and returns its ID.
+func (s *eventMockSQLTestSuite) expectDefaultCalendar(userID int64) string {
+	calendarID := faker.UUIDHyphenated()
+	s.mock.ExpectQuery("^SELECT id FROM calendar WHERE user_id = \\$1 AND is_default$").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(calendarID))
+	return calendarID
+}
+
 func (s *eventMockSQLTestSuite) TestAddEvent() {
-	e := tests.GenerateTestEvent()
+	e := withNotification(tests.GenerateTestEvent())
+	s.mock.ExpectBegin()
+	calendarID := s.expectDefaultCalendar(e.UserID)
 	s.mock.ExpectExec("^INSERT INTO event (.+) VALUES (.+)$").
 		WithArgs(
 			e.ID,
 			e.Title,
 			e.StartTime,
 			e.EndTime,
-			e.NotifyTime,
 			e.Description,
 			e.UserID,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			int64(1),
+			calendarID,
+			e.TimeZone,
+			e.AllDay,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
-	err := s.repo.Add(e)
+	s.expectReminders(e)
+	s.mock.ExpectExec("^INSERT INTO notification_outbox (.+) VALUES (.+)$").
+		WithArgs(e.ID, sqlmock.AnyArg(), reminderTime(e), reminderTime(e), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.expectChange(e, domain.EventActionCreate, false, true)
+	s.mock.ExpectCommit()
+	err := s.repo.Add(changeContext(e.UserID), e)
 	s.NoError(err)
+	s.Equal(calendarID, e.CalendarID)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestAddRecurringEvent() {
+	e := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	s.NoError(err)
+	recurrence.Exceptions = []time.Time{e.StartTime.AddDate(0, 0, 1)}
+	e.Recurrence = recurrence
+	s.mock.ExpectBegin()
+	calendarID := s.expectDefaultCalendar(e.UserID)
+	s.mock.ExpectExec("^INSERT INTO event (.+) VALUES (.+)$").
+		WithArgs(
+			e.ID,
+			e.Title,
+			e.StartTime,
+			e.EndTime,
+			e.Description,
+			e.UserID,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			"FREQ=DAILY;COUNT=3",
+			`{"`+e.StartTime.AddDate(0, 0, 1).UTC().Format(time.RFC3339Nano)+`"}`,
+			e.StartTime.AddDate(0, 0, 2),
+			nil,
+			nil,
+			int64(1),
+			calendarID,
+			e.TimeZone,
+			e.AllDay,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.expectChange(e, domain.EventActionCreate, false, true)
+	s.mock.ExpectCommit()
+	s.NoError(s.repo.Add(changeContext(e.UserID), e))
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+// changeContext returns a context of a change made by the user.
+func changeContext(userID int64) context.Context {
+	return domain.WithRequestID(domain.WithActor(context.Background(), userID), "request-1")
+}
+
+// expectLock expects the event to be locked by a write.
+func (s *eventMockSQLTestSuite) expectLock(e *domain.Event) {
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 FOR UPDATE$").
+		WithArgs(e.ID).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...))
+}
+
+// expectChange expects the change of the event made within changeContext to be recorded.
+func (s *eventMockSQLTestSuite) expectChange(e *domain.Event, action string, before, after bool) {
+	snapshot := func(exists bool) driver.Value {
+		if exists {
+			return sqlmock.AnyArg()
+		}
+		return nil
+	}
+	s.mock.ExpectExec("^INSERT INTO event_history (.+) VALUES (.+)$").
+		WithArgs(e.ID, e.UserID, e.UserID, "request-1", action, snapshot(before), snapshot(after), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 }
 
 func (s *eventMockSQLTestSuite) TestAddEventWithExistingID() {
 	e := tests.GenerateTestEvent()
+	e.CalendarID = faker.UUIDHyphenated()
 	duplicateErr := fmt.Errorf("pq: duplicate key value violates unique constraint \"event_pkey\"")
+	s.mock.ExpectBegin()
 	s.mock.ExpectExec("^INSERT INTO event (.+) VALUES (.+)$").
 		WithArgs(
 			e.ID,
 			e.Title,
 			e.StartTime,
 			e.EndTime,
-			e.NotifyTime,
 			e.Description,
 			e.UserID,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			int64(1),
+			e.CalendarID,
+			e.TimeZone,
+			e.AllDay,
 		).WillReturnError(duplicateErr)
-	err := s.repo.Add(e)
+	s.mock.ExpectRollback()
+	err := s.repo.Add(context.Background(), e)
 	s.ErrorIs(err, duplicateErr)
+	s.NoError(s.mock.ExpectationsWereMet())
 }
 
 func (s *eventMockSQLTestSuite) TestUpdateEvent() {
-	e := tests.GenerateTestEvent()
-	s.mock.ExpectExec("^UPDATE event SET (.+) WHERE id = \\$8$").
+	e := withNotification(tests.GenerateTestEvent())
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectExec("^UPDATE event SET (.+) WHERE id = \\$10$").
 		WithArgs(
 			e.Title,
 			e.StartTime,
 			e.EndTime,
-			e.NotifyTime,
 			e.Description,
 			e.UserID,
 			sqlmock.AnyArg(),
+			nil,
+			nil,
+			nil,
 			e.ID,
+			nil,
+			nil,
+			e.Version+1,
+			e.CalendarID,
+			e.TimeZone,
+			e.AllDay,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectExec("^DELETE FROM event_reminder WHERE event_id = \\$1$").
+		WithArgs(e.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.expectReminders(e)
+	s.mock.ExpectExec("^DELETE FROM notification_outbox WHERE event_id = \\$1 AND sent_time IS NULL (.+)$").
+		WithArgs(e.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("^INSERT INTO notification_outbox (.+) VALUES (.+)$").
+		WithArgs(e.ID, sqlmock.AnyArg(), reminderTime(e), reminderTime(e), nil).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	s.expectChange(e, domain.EventActionUpdate, true, true)
+	s.mock.ExpectCommit()
+	err := s.repo.Update(changeContext(e.UserID), e.UserID, e)
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestUpdateEventVersionConflict() {
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectRollback()
+	event := *e
+	event.Version--
+	err := s.repo.Update(context.Background(), event.UserID, &event)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+// expectCalendarAccess expects the access of the user to the event calendar to be looked up,
+// an empty access isn't granted.
+func (s *eventMockSQLTestSuite) expectCalendarAccess(e *domain.Event, userID int64, access domain.CalendarAccess) {
+	rows := sqlmock.NewRows([]string{"access"})
+	if access != "" {
+		rows.AddRow(string(access))
+	}
+	s.mock.ExpectQuery("^SELECT access FROM calendar_share WHERE calendar_id = \\$1 AND user_id = \\$2$").
+		WithArgs(e.CalendarID, userID).
+		WillReturnRows(rows)
+}
+
+func (s *eventMockSQLTestSuite) TestUpdateEventOfAnotherUser() {
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.expectCalendarAccess(e, e.UserID+1, domain.CalendarAccessRead)
+	s.mock.ExpectRollback()
+	event := *e
+	event.UserID++
+	err := s.repo.Update(context.Background(), event.UserID, &event)
+	s.ErrorIs(err, domain.ErrPermission)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestGetEventOfAnotherUser() {
+	e := s.setEventInDB(tests.GenerateTestEvent())
+	s.expectCalendarAccess(e, e.UserID+1, "")
+	result, err := s.repo.Get(context.Background(), e.UserID+1, e.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+	s.Nil(result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestGetEventOfSharedCalendar() {
+	e := tests.GenerateTestEvent()
+	e.CalendarID = faker.UUIDHyphenated()
 	s.setEventInDB(e)
-	err := s.repo.Update(e)
+	s.expectCalendarAccess(e, e.UserID+1, domain.CalendarAccessRead)
+	result, err := s.repo.Get(context.Background(), e.UserID+1, e.ID)
 	s.NoError(err)
+	s.Equal(e.CalendarID, result.CalendarID)
+	s.NoError(s.mock.ExpectationsWereMet())
 }
 
 func (s *eventMockSQLTestSuite) TestGetEvent() {
 	e := s.setEventInDB(tests.GenerateTestEvent())
-	result, err := s.repo.Get(e.ID)
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
 	s.NotNil(result)
 	s.Equal(e, result)
 }
 
 func (s *eventMockSQLTestSuite) TestDeleteEvent() {
-	e := s.setEventInDB(tests.GenerateTestEvent())
-	result, err := s.repo.Get(e.ID)
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectExec("^UPDATE event SET deleted_time = \\$2, updated_time = \\$2, version = version \\+ 1 "+
+		"WHERE id = \\$1$").
+		WithArgs(e.ID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectExec("^DELETE FROM notification_outbox WHERE event_id = \\$1 AND sent_time IS NULL (.+)$").
+		WithArgs(e.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.expectChange(e, domain.EventActionDelete, true, true)
+	s.mock.ExpectCommit()
+	err := s.repo.Delete(changeContext(e.UserID), e.UserID, e.ID)
 	s.NoError(err)
-	s.NotNil(result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestDeleteDeletedEvent() {
+	e := tests.GenerateTestEvent()
+	deletedTime := time.Now().UTC()
+	e.DeletedTime = &deletedTime
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectRollback()
+	err := s.repo.Delete(context.Background(), e.UserID, e.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestDeleteEventPermanently() {
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
 	s.mock.ExpectExec("^DELETE FROM event WHERE id = \\$1$").
 		WithArgs(e.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-	err = s.repo.Delete(e.ID)
+	s.expectChange(e, domain.EventActionDeletePermanently, true, false)
+	s.mock.ExpectCommit()
+	err := s.repo.DeletePermanently(changeContext(e.UserID), e.UserID, e.ID)
 	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
 }
 
-func (s *eventMockSQLTestSuite) TestDeleteEventsBeforeDate() {
-	e := s.setEventInDB(tests.GenerateTestEvent())
-	result, err := s.repo.Get(e.ID)
-	s.NoError(err)
-	s.NotNil(result)
-	s.mock.ExpectExec("^DELETE FROM event WHERE start_time <= \\$1$").
-		WithArgs(e.StartTime).
+func (s *eventMockSQLTestSuite) TestRestoreEvent() {
+	e := withNotification(tests.GenerateTestEvent())
+	deleted := *e
+	deletedTime := time.Now().UTC().Truncate(time.Millisecond)
+	deleted.DeletedTime = &deletedTime
+	s.mock.ExpectBegin()
+	s.expectLock(&deleted)
+	s.mock.ExpectExec("^UPDATE event SET deleted_time = NULL, updated_time = \\$2, version = version \\+ 1 "+
+		"WHERE id = \\$1$").
+		WithArgs(e.ID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectExec("^INSERT INTO notification_outbox (.+)$").
+		WithArgs(e.ID, sqlmock.AnyArg(), reminderTime(e), reminderTime(e), nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-	err = s.repo.DeleteEventBeforeDate(e.StartTime)
+	s.expectChange(e, domain.EventActionRestore, true, true)
+	s.mock.ExpectCommit()
+	result, err := s.repo.Restore(changeContext(e.UserID), e.UserID, e.ID)
+	s.NoError(err)
+	e.Version++
+	s.Equal(e, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestRestoreNotDeletedEvent() {
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectRollback()
+	result, err := s.repo.Restore(context.Background(), e.UserID, e.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	s.Nil(result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestGetDeletedEvents() {
+	e := tests.GenerateTestEvent()
+	deletedTime := time.Now().UTC().Truncate(time.Millisecond)
+	e.DeletedTime = &deletedTime
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE user_id = \\$1 AND deleted_time IS NOT NULL (.+)$").
+		WithArgs(e.UserID).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...))
+	events, err := s.repo.GetDeletedEvents(context.Background(), e.UserID)
 	s.NoError(err)
+	s.Equal([]*domain.Event{e}, events)
+}
+
+func (s *eventMockSQLTestSuite) TestPurgeEvents() {
+	expiredBefore := time.Now().UTC().AddDate(-1, 0, 0)
+	deletedBefore := time.Now().UTC().AddDate(0, -1, 0)
+	s.mock.ExpectExec("^DELETE FROM event WHERE deleted_time <= \\$2 OR \\(start_time <= \\$1 (.+)$").
+		WithArgs(expiredBefore, deletedBefore).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	err := s.repo.PurgeEvents(context.Background(), expiredBefore, deletedBefore)
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
 }
 
 func (s *eventMockSQLTestSuite) mockPeriodSelect(
+	userID int64,
 	startTime, endTime time.Time,
 	valueRows ...[]driver.Value,
 ) {
-	rows := sqlmock.NewRows(
-		[]string{
-			"id",
-			"title",
-			"start_time",
-			"end_time",
-			"notify_time",
-			"description",
-			"user_id",
-			"created_time",
-		},
-	)
+	rows := sqlmock.NewRows(eventColumns)
 	for _, row := range valueRows {
 		rows.AddRow(row...)
 	}
-	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE start_time >= \\$1 AND end_time <= \\$2$").
-		WithArgs(startTime, endTime).
+	s.mock.ExpectQuery(
+		"^SELECT (.+) FROM event WHERE \\(user_id = \\$1 OR id IN \\(SELECT event_id FROM event_attendee (.+)\\) "+
+			"AND deleted_time IS NULL AND \\(\\(recurrence_rule IS NULL (.+)$",
+	).
+		WithArgs(userID, startTime, endTime, wallClock(startTime), wallClock(endTime)).
 		WillReturnRows(rows)
 }
 
 func (s *eventMockSQLTestSuite) TestListEventsByPeriodWithNoEvents() {
 	startTime := time.Now()
 	endTime := time.Now().Add(time.Hour)
-	s.mockPeriodSelect(startTime, endTime)
-	events, err := s.repo.GetEventsByPeriod(startTime, endTime)
+	s.mockPeriodSelect(1, startTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), 1, startTime, endTime)
 	s.NoError(err)
 	s.Empty(events)
 }
 
 func (s *eventMockSQLTestSuite) TestListEventsByPeriodWithSingleEvent() {
 	e := s.setEventInDB(tests.GenerateTestEvent())
-	result, err := s.repo.Get(e.ID)
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
 	s.Equal(e, result)
 	s.mockPeriodSelect(
+		e.UserID,
 		result.StartTime,
 		*result.EndTime,
-		[]driver.Value{
-			e.ID,
-			e.Title,
-			e.StartTime,
-			e.EndTime,
-			e.NotifyTime,
-			e.Description,
-			e.UserID,
-			e.CreatedTime,
-		},
+		eventRow(e),
 	)
-	events, err := s.repo.GetEventsByPeriod(e.StartTime, *e.EndTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, e.StartTime, *e.EndTime)
 	s.NoError(err)
 	s.Len(events, 1)
 	s.Equal(e, events[0])
@@ -325,32 +859,16 @@ func (s *eventMockSQLTestSuite) TestListEventsByPeriodWithSingleEvent() {
 func (s *eventMockSQLTestSuite) TestListEventsByPeriodWithMultipleEvents() {
 	e1 := tests.GenerateTestEvent()
 	e2 := tests.GenerateTestEvent()
+	e2.UserID = e1.UserID
 	startTime, endTime := tests.GetEventStartEndTime(e1, e2)
 	s.mockPeriodSelect(
+		e1.UserID,
 		startTime,
 		endTime,
-		[]driver.Value{
-			e1.ID,
-			e1.Title,
-			e1.StartTime,
-			e1.EndTime,
-			e1.NotifyTime,
-			e1.Description,
-			e1.UserID,
-			e1.CreatedTime,
-		},
-		[]driver.Value{
-			e2.ID,
-			e2.Title,
-			e2.StartTime,
-			e2.EndTime,
-			e2.NotifyTime,
-			e2.Description,
-			e2.UserID,
-			e2.CreatedTime,
-		},
+		eventRow(e1),
+		eventRow(e2),
 	)
-	events, err := s.repo.GetEventsByPeriod(startTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e1.UserID, startTime, endTime)
 	s.NoError(err)
 	s.Len(events, 2)
 	s.Equal(events[0], e1)
@@ -359,16 +877,243 @@ func (s *eventMockSQLTestSuite) TestListEventsByPeriodWithMultipleEvents() {
 
 func (s *eventMockSQLTestSuite) TestListEventsByPeriodWithEventOutsidePeriod() {
 	e := s.setEventInDB(tests.GenerateTestEvent())
-	result, err := s.repo.Get(e.ID)
+	result, err := s.repo.Get(context.Background(), e.UserID, e.ID)
 	s.NoError(err)
 	s.Equal(e, result)
 	endTime := e.EndTime.Add(time.Minute)
-	s.mockPeriodSelect(endTime, endTime)
-	events, err := s.repo.GetEventsByPeriod(endTime, endTime)
+	s.mockPeriodSelect(e.UserID, endTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, endTime, endTime)
 	s.NoError(err)
 	s.Empty(events)
 }
 
+func (s *eventMockSQLTestSuite) TestListRecurringEventsByPeriod() {
+	e := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=WEEKLY;COUNT=4")
+	s.NoError(err)
+	recurrence.Exceptions = []time.Time{e.StartTime.AddDate(0, 0, 7)}
+	e.Recurrence = recurrence
+	startTime, endTime := e.StartTime, e.EndTime.AddDate(0, 0, 14)
+	s.mockPeriodSelect(e.UserID, startTime, endTime, eventRow(e))
+	events, err := s.repo.GetEventsByPeriod(context.Background(), e.UserID, startTime, endTime)
+	s.NoError(err)
+	s.Len(events, 2)
+	s.Equal(e.StartTime, events[0].StartTime)
+	s.Equal(e.StartTime.AddDate(0, 0, 14), events[1].StartTime)
+	s.Equal(events[1].StartTime, *events[1].RecurrenceID)
+}
+
+func (s *eventMockSQLTestSuite) TestGetOverlappingEvents() {
+	e := tests.GenerateTestEvent()
+	startTime, endTime := e.StartTime.Add(-time.Hour), e.StartTime.Add(time.Minute)
+	rows := sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...)
+	// The range bounds are zoned on both sides, so overlaps don't depend on the session time zone.
+	overlap := regexp.QuoteMeta(
+		"tstzrange(start_time, COALESCE(end_time, start_time), '[]') && " +
+			"tstzrange($2::timestamptz, $3::timestamptz, '[]')",
+	)
+	query := "^SELECT (.+) FROM event WHERE user_id = \\$1 (.+)" + overlap + "(.+)$"
+	s.mock.ExpectQuery(query).
+		WithArgs(e.UserID, startTime, endTime).
+		WillReturnRows(rows)
+	events, err := s.repo.GetOverlappingEvents(context.Background(), e.UserID, startTime, endTime)
+	s.NoError(err)
+	s.Len(events, 1)
+	s.Equal(e, events[0])
+}
+
+func (s *eventMockSQLTestSuite) TestGetEventsPage() {
+	e1 := tests.GenerateTestEvent()
+	e2 := tests.GenerateTestEvent()
+	e2.UserID = e1.UserID
+	hasNotification := true
+	filter := &domain.EventFilter{
+		UserID:          e1.UserID,
+		StartTime:       e1.StartTime,
+		EndTime:         e1.EndTime.Add(time.Hour),
+		Title:           "title",
+		HasNotification: &hasNotification,
+		PageSize:        1,
+		Cursor:          &domain.EventCursor{StartTime: e1.StartTime.Add(-time.Minute), ID: e1.ID},
+	}
+	rows := sqlmock.NewRows(eventColumns).AddRow(eventRow(e1)...).AddRow(eventRow(e2)...)
+	s.mock.ExpectQuery(
+		"^\\(SELECT (.+) strpos\\(lower\\(title\\), lower\\(\\$6\\)\\) > 0 "+
+			"AND EXISTS \\(SELECT 1 FROM event_reminder (.+)\\) "+
+			"(.+) AND \\(start_time, id\\) > \\(\\$7, \\$8\\) ORDER BY start_time ASC, id ASC LIMIT \\$9\\) "+
+			"UNION ALL (.+)$",
+	).
+		WithArgs(
+			e1.UserID, filter.StartTime, filter.EndTime, wallClock(filter.StartTime), wallClock(filter.EndTime),
+			"title", filter.Cursor.StartTime, e1.ID, 2,
+		).
+		WillReturnRows(rows)
+	page, err := s.repo.GetEventsPage(context.Background(), filter)
+	s.NoError(err)
+	s.Len(page.Events, 1)
+	s.Equal(&domain.EventCursor{StartTime: page.Events[0].StartTime, ID: page.Events[0].ID}, page.NextCursor)
+}
+
+func (s *eventMockSQLTestSuite) TestGetEventsPageOfOwner() {
+	e := tests.GenerateTestEvent()
+	filter := &domain.EventFilter{
+		UserID:    e.UserID,
+		OwnerID:   e.UserID,
+		StartTime: e.StartTime,
+		EndTime:   e.EndTime.Add(time.Hour),
+	}
+	s.mock.ExpectQuery("^\\(SELECT (.+) AND deleted_time IS NULL AND user_id = \\$6 AND recurrence_rule IS NULL (.+)$").
+		WithArgs(
+			e.UserID, filter.StartTime, filter.EndTime, wallClock(filter.StartTime), wallClock(filter.EndTime), e.UserID,
+		).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...))
+	page, err := s.repo.GetEventsPage(context.Background(), filter)
+	s.NoError(err)
+	s.Len(page.Events, 1)
+}
+
+func (s *eventMockSQLTestSuite) TestIterateEvents() {
+	e := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	s.NoError(err)
+	e.Recurrence = recurrence
+	single := tests.GenerateTestEvent()
+	single.UserID = e.UserID
+	filter := &domain.EventFilter{
+		UserID:     e.UserID,
+		StartTime:  e.StartTime,
+		EndTime:    e.StartTime.AddDate(0, 0, 7),
+		Descending: true,
+	}
+	rows := sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...).AddRow(eventRow(single)...)
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE \\(user_id = \\$1 OR (.+) ORDER BY start_time DESC, id DESC$").
+		WithArgs(e.UserID, filter.StartTime, filter.EndTime, wallClock(filter.StartTime), wallClock(filter.EndTime)).
+		WillReturnRows(rows)
+	var starts []time.Time
+	errStop := errors.New("stop")
+	err = s.repo.IterateEvents(context.Background(), filter, func(event *domain.Event) error {
+		starts = append(starts, event.StartTime)
+		if len(starts) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	s.ErrorIs(err, errStop)
+	s.Equal([]time.Time{e.StartTime.AddDate(0, 0, 2), e.StartTime.AddDate(0, 0, 1)}, starts)
+}
+
+func (s *eventMockSQLTestSuite) TestGetEventOfAttendee() {
+	e := tests.GenerateTestEvent()
+	e.Attendees = []*domain.Attendee{{UserID: e.UserID + 1, Status: domain.AttendeeDeclined}}
+	s.setEventInDB(e)
+	result, err := s.repo.Get(context.Background(), e.UserID+1, e.ID)
+	s.NoError(err)
+	s.Equal(e, result)
+}
+
+// recipientPayload matches the outbox payload of the single notification of the user.
+type recipientPayload int64
+
+func (r recipientPayload) Match(v driver.Value) bool {
+	var notifications []*domain.Notification
+	data, ok := v.([]byte)
+	return ok && json.Unmarshal(data, &notifications) == nil &&
+		len(notifications) == 1 && notifications[0].UserToSend == int64(r)
+}
+
+// expectAttendeesChange expects the event version to be incremented and its notifications to be replaced
+// by a message for each of the recipients.
+func (s *eventMockSQLTestSuite) expectAttendeesChange(e *domain.Event, recipients ...int64) {
+	s.mock.ExpectExec("^UPDATE event SET updated_time = \\$2, version = version \\+ 1 WHERE id = \\$1$").
+		WithArgs(e.ID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectExec("^DELETE FROM notification_outbox WHERE event_id = \\$1 AND sent_time IS NULL (.+)$").
+		WithArgs(e.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, userID := range recipients {
+		s.mock.ExpectExec("^INSERT INTO notification_outbox (.+) VALUES (.+)$").
+			WithArgs(e.ID, recipientPayload(userID), reminderTime(e), reminderTime(e), nil).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+	}
+}
+
+func (s *eventMockSQLTestSuite) TestInviteAttendees() {
+	e := withNotification(tests.GenerateTestEvent())
+	attendee := e.UserID + 1
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectExec("^INSERT INTO event_attendee (.+) VALUES (.+)$").
+		WithArgs(e.ID, attendee, "invited", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.expectAttendeesChange(e, e.UserID, attendee)
+	s.expectChange(e, domain.EventActionInvite, true, true)
+	s.mock.ExpectCommit()
+	result, err := s.repo.InviteAttendees(changeContext(e.UserID), e.UserID, e.ID, []int64{attendee})
+	s.NoError(err)
+	s.Equal(e.Version+1, result.Version)
+	s.Equal([]*domain.Attendee{{UserID: attendee, Status: domain.AttendeeInvited}}, result.Attendees)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestInviteAttendeesToEventOfAnotherUser() {
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.expectCalendarAccess(e, e.UserID+1, domain.CalendarAccessFreeBusy)
+	s.mock.ExpectRollback()
+	result, err := s.repo.InviteAttendees(context.Background(), e.UserID+1, e.ID, []int64{e.UserID + 2})
+	s.ErrorIs(err, domain.ErrPermission)
+	s.Nil(result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestRespondInvitation() {
+	e := withNotification(tests.GenerateTestEvent())
+	attendee := e.UserID + 1
+	e.Attendees = []*domain.Attendee{{UserID: attendee, Status: domain.AttendeeInvited}}
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectExec("^UPDATE event_attendee SET status = \\$3, updated_time = \\$4 "+
+		"WHERE event_id = \\$1 AND user_id = \\$2$").
+		WithArgs(e.ID, attendee, "accepted", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.expectAttendeesChange(e, e.UserID, attendee)
+	s.mock.ExpectExec("^INSERT INTO event_history (.+) VALUES (.+)$").
+		WithArgs(
+			e.ID, e.UserID, attendee, "request-1", domain.EventActionRespond,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+	result, err := s.repo.RespondInvitation(changeContext(attendee), attendee, e.ID, domain.AttendeeAccepted)
+	s.NoError(err)
+	s.Equal([]*domain.Attendee{{UserID: attendee, Status: domain.AttendeeAccepted}}, result.Attendees)
+	s.Equal(domain.AttendeeInvited, e.Attendees[0].Status)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestRespondInvitationNotInvited() {
+	e := tests.GenerateTestEvent()
+	s.mock.ExpectBegin()
+	s.expectLock(e)
+	s.mock.ExpectRollback()
+	_, err := s.repo.RespondInvitation(context.Background(), e.UserID+1, e.ID, domain.AttendeeAccepted)
+	s.ErrorIs(err, domain.ErrPermission)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *eventMockSQLTestSuite) TestGetInvitations() {
+	e := tests.GenerateTestEvent()
+	e.Attendees = []*domain.Attendee{{UserID: e.UserID + 1, Status: domain.AttendeeAccepted}}
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE deleted_time IS NULL AND id IN (.+) ORDER BY start_time, id$").
+		WithArgs(e.UserID+1, "accepted").
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(e)...))
+	events, err := s.repo.GetInvitations(context.Background(), e.UserID+1, domain.AttendeeAccepted)
+	s.NoError(err)
+	s.Equal([]*domain.Event{e}, events)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
 func TestRunMockSQLEventSuite(t *testing.T) {
 	suite.Run(t, new(eventMockSQLTestSuite))
 }
@@ -385,92 +1130,429 @@ func (s *eventCacheTestSuite) SetupSuite() {
 
 func (s *eventCacheTestSuite) TearDownTest() {
 	cacheDB.Clear()
+	cacheOutbox.clear()
+	cacheHistory.clear()
+	cacheCalendars.clear()
 }
 
 func (s *eventCacheTestSuite) TestAddEvent() {
 	event := tests.GenerateTestEvent()
-	err := s.repo.Add(event)
+	err := s.repo.Add(context.Background(), event)
 	s.NoError(err)
-	result, err := s.repo.Get(event.ID)
+	result, err := s.repo.Get(context.Background(), event.UserID, event.ID)
 	s.NoError(err)
 	s.Equal(event, result)
 }
 
 func (s *eventCacheTestSuite) TestAddExistEvent() {
 	event := tests.GenerateTestEvent()
-	err := s.repo.Add(event)
+	err := s.repo.Add(context.Background(), event)
 	s.NoError(err)
-	err = s.repo.Add(event)
+	err = s.repo.Add(context.Background(), event)
 	s.Error(err)
 }
 
 func (s *eventCacheTestSuite) TestUpdateEvent() {
 	event := tests.GenerateTestEvent()
-	err := s.repo.Add(event)
+	err := s.repo.Add(context.Background(), event)
 	s.NoError(err)
 	event.Title = "NewTitle"
-	err = s.repo.Update(event)
+	err = s.repo.Update(context.Background(), event.UserID, event)
 	s.NoError(err)
-	updatedEvent, err := s.repo.Get(event.ID)
+	updatedEvent, err := s.repo.Get(context.Background(), event.UserID, event.ID)
 	s.NoError(err)
 	s.Equal("NewTitle", updatedEvent.Title)
 }
 
+func (s *eventCacheTestSuite) TestUpdateEventVersionConflict() {
+	event := tests.GenerateTestEvent()
+	s.NoError(s.repo.Add(context.Background(), event))
+	first, second := *event, *event
+	first.Title = "First"
+	s.NoError(s.repo.Update(context.Background(), first.UserID, &first))
+	s.Equal(event.Version+1, first.Version)
+	second.Title = "Second"
+	s.ErrorIs(s.repo.Update(context.Background(), second.UserID, &second), domain.ErrVersionConflict)
+	result, err := s.repo.Get(context.Background(), event.UserID, event.ID)
+	s.NoError(err)
+	s.Equal(&first, result)
+}
+
 func (s *eventCacheTestSuite) TestUpdateNonExistEvent() {
 	event := tests.GenerateTestEvent()
-	err := s.repo.Update(event)
+	err := s.repo.Update(context.Background(), event.UserID, event)
 	s.Error(err)
 }
 
 func (s *eventCacheTestSuite) TestGetNonExistEvent() {
-	_, err := s.repo.Get(faker.UUIDHyphenated(options.WithGenerateUniqueValues(true)))
+	_, err := s.repo.Get(context.Background(), 1, faker.UUIDHyphenated(options.WithGenerateUniqueValues(true)))
 	s.Error(err)
 }
 
 func (s *eventCacheTestSuite) TestDeleteEvent() {
 	event := tests.GenerateTestEvent()
-	err := s.repo.Add(event)
+	err := s.repo.Add(context.Background(), event)
 	s.NoError(err)
-	err = s.repo.Delete(event.ID)
+	err = s.repo.Delete(context.Background(), event.UserID, event.ID)
 	s.NoError(err)
-	_, err = s.repo.Get(event.ID)
-	s.Error(err)
+	_, err = s.repo.Get(context.Background(), event.UserID, event.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	err = s.repo.Delete(context.Background(), event.UserID, event.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), event.UserID, event.StartTime, *event.EndTime)
+	s.NoError(err)
+	s.Empty(events)
+	deleted, err := s.repo.GetDeletedEvents(context.Background(), event.UserID)
+	s.NoError(err)
+	s.Len(deleted, 1)
+	s.NotNil(deleted[0].DeletedTime)
+}
+
+func (s *eventCacheTestSuite) TestHistory() {
+	event := tests.GenerateTestEvent()
+	ctx := changeContext(event.UserID)
+	s.NoError(s.repo.Add(ctx, event))
+	title := event.Title
+	event.Title = "NewTitle"
+	s.NoError(s.repo.Update(ctx, event.UserID, event))
+	s.NoError(s.repo.Delete(ctx, event.UserID, event.ID))
+	_, err := s.repo.Restore(ctx, event.UserID, event.ID)
+	s.NoError(err)
+	s.NoError(s.repo.DeletePermanently(ctx, event.UserID, event.ID))
+
+	changes, err := s.repo.GetHistory(context.Background(), event.UserID, event.ID)
+	s.NoError(err)
+	s.Len(changes, 5)
+	actions := make([]string, len(changes))
+	for i, change := range changes {
+		actions[i] = change.Action
+		s.Equal(event.UserID, change.ActorID)
+		s.Equal("request-1", change.RequestID)
+	}
+	s.Equal([]string{
+		domain.EventActionCreate,
+		domain.EventActionUpdate,
+		domain.EventActionDelete,
+		domain.EventActionRestore,
+		domain.EventActionDeletePermanently,
+	}, actions)
+	s.Nil(changes[0].Before)
+	s.Equal(title, changes[1].Before.Title)
+	s.Equal("NewTitle", changes[1].After.Title)
+	s.NotNil(changes[2].After.DeletedTime)
+	s.Nil(changes[4].After)
+	_, err = s.repo.GetHistory(context.Background(), event.UserID+1, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+	_, err = s.repo.GetHistory(context.Background(), event.UserID, tests.GenerateTestEvent().ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+}
+
+func (s *eventCacheTestSuite) TestRestoreEvent() {
+	event := tests.GenerateTestEvent()
+	s.NoError(s.repo.Add(context.Background(), event))
+	_, err := s.repo.Restore(context.Background(), event.UserID, event.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	s.NoError(s.repo.Delete(context.Background(), event.UserID, event.ID))
+	_, err = s.repo.Restore(context.Background(), event.UserID+1, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+	restored, err := s.repo.Restore(context.Background(), event.UserID, event.ID)
+	s.NoError(err)
+	s.Nil(restored.DeletedTime)
+	s.Equal(event.Version+2, restored.Version)
+	event.Version = restored.Version
+	result, err := s.repo.Get(context.Background(), event.UserID, event.ID)
+	s.NoError(err)
+	s.Equal(event, result)
+	deleted, err := s.repo.GetDeletedEvents(context.Background(), event.UserID)
+	s.NoError(err)
+	s.Empty(deleted)
 }
 
-func (s *eventCacheTestSuite) TestDeleteEventsBeforeDate() {
+func (s *eventCacheTestSuite) TestDeleteEventPermanently() {
 	event := tests.GenerateTestEvent()
-	err := s.repo.Add(event)
+	s.NoError(s.repo.Add(context.Background(), event))
+	s.NoError(s.repo.Delete(context.Background(), event.UserID, event.ID))
+	s.ErrorIs(s.repo.DeletePermanently(context.Background(), event.UserID+1, event.ID), domain.ErrPermission)
+	s.NoError(s.repo.DeletePermanently(context.Background(), event.UserID, event.ID))
+	_, err := s.repo.Restore(context.Background(), event.UserID, event.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+}
+
+func (s *eventCacheTestSuite) TestEventOfAnotherUser() {
+	event := tests.GenerateTestEvent()
+	err := s.repo.Add(context.Background(), event)
+	s.NoError(err)
+	_, err = s.repo.Get(context.Background(), event.UserID+1, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+	err = s.repo.Delete(context.Background(), event.UserID+1, event.ID)
+	s.ErrorIs(err, domain.ErrPermission)
+	event.UserID++
+	err = s.repo.Update(context.Background(), event.UserID, event)
+	s.ErrorIs(err, domain.ErrPermission)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), event.UserID, event.StartTime, *event.EndTime)
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *eventCacheTestSuite) TestPurgeEvents() {
+	event := tests.GenerateTestEvent()
+	err := s.repo.Add(context.Background(), event)
+	s.NoError(err)
+	deleted := tests.GenerateTestEvent()
+	s.NoError(s.repo.Add(context.Background(), deleted))
+	s.NoError(s.repo.Delete(context.Background(), deleted.UserID, deleted.ID))
+	expiredBefore := event.StartTime.Add(-time.Hour)
+	err = s.repo.PurgeEvents(context.Background(), expiredBefore, time.Now().Add(-time.Hour))
+	s.NoError(err)
+	_, err = s.repo.Restore(context.Background(), deleted.UserID, deleted.ID)
+	s.NoError(err)
+	s.NoError(s.repo.Delete(context.Background(), deleted.UserID, deleted.ID))
+	err = s.repo.PurgeEvents(context.Background(), expiredBefore, time.Now().Add(time.Minute))
 	s.NoError(err)
-	err = s.repo.DeleteEventBeforeDate(event.StartTime)
+	_, err = s.repo.Restore(context.Background(), deleted.UserID, deleted.ID)
+	s.ErrorIs(err, domain.ErrEventNotExist)
+	_, err = s.repo.Get(context.Background(), event.UserID, event.ID)
 	s.NoError(err)
-	_, err = s.repo.Get(event.ID)
+	err = s.repo.PurgeEvents(context.Background(), event.StartTime, time.Now().Add(-time.Hour))
+	s.NoError(err)
+	_, err = s.repo.Get(context.Background(), event.UserID, event.ID)
 	s.Error(err)
 }
 
 func (s *eventCacheTestSuite) TestDeleteNonExistentEvent() {
-	err := s.repo.Delete(faker.UUIDHyphenated(options.WithGenerateUniqueValues(true)))
+	err := s.repo.Delete(context.Background(), 1, faker.UUIDHyphenated(options.WithGenerateUniqueValues(true)))
 	s.Error(err)
 }
 
 func (s *eventCacheTestSuite) TestListEventsByPeriod() {
 	event1 := tests.GenerateTestEvent()
 	event2 := tests.GenerateTestEvent()
-	err := s.repo.Add(event1)
+	event2.UserID = event1.UserID
+	err := s.repo.Add(context.Background(), event1)
+	s.NoError(err)
+	err = s.repo.Add(context.Background(), event2)
 	s.NoError(err)
-	err = s.repo.Add(event2)
+	err = s.repo.Add(context.Background(), tests.GenerateTestEvent())
 	s.NoError(err)
 	startTime, endTime := tests.GetEventStartEndTime(event1, event2)
-	events, err := s.repo.GetEventsByPeriod(startTime, endTime)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), event1.UserID, startTime, endTime)
 	s.NoError(err)
 	s.Len(events, 2)
 }
 
 func (s *eventCacheTestSuite) TestListEventsByPeriodNoEvents() {
-	events, err := s.repo.GetEventsByPeriod(time.Now(), time.Now())
+	events, err := s.repo.GetEventsByPeriod(context.Background(), 1, time.Now(), time.Now())
 	s.NoError(err)
 	s.Len(events, 0)
 }
 
+func (s *eventCacheTestSuite) TestListRecurringEvents() {
+	event := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY;INTERVAL=2")
+	s.NoError(err)
+	event.Recurrence = recurrence
+	err = s.repo.Add(context.Background(), event)
+	s.NoError(err)
+	events, err := s.repo.GetEventsByPeriod(
+		context.Background(), event.UserID, event.StartTime, event.EndTime.AddDate(0, 0, 6),
+	)
+	s.NoError(err)
+	s.Len(events, 4)
+	err = s.repo.PurgeEvents(context.Background(), event.StartTime.AddDate(0, 0, 10), time.Now().Add(-time.Hour))
+	s.NoError(err)
+	_, err = s.repo.Get(context.Background(), event.UserID, event.ID)
+	s.NoError(err)
+}
+
+func (s *eventCacheTestSuite) TestListAllDayEvents() {
+	event := tests.GenerateTestEvent()
+	event.StartTime = time.Date(2030, time.December, 25, 0, 0, 0, 0, time.UTC)
+	event.TimeZone = "Europe/Berlin"
+	event.AllDay = true
+	s.NoError(s.repo.Add(context.Background(), event))
+	s.Equal(time.Date(2030, time.December, 24, 23, 0, 0, 0, time.UTC), event.StartTime)
+	newYork, err := time.LoadLocation("America/New_York")
+	s.NoError(err)
+	day := time.Date(2030, time.December, 25, 0, 0, 0, 0, newYork)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), event.UserID, day, day.AddDate(0, 0, 1))
+	s.NoError(err)
+	s.Equal([]*domain.Event{event}, events)
+	events, err = s.repo.GetEventsByPeriod(context.Background(), event.UserID, day.AddDate(0, 0, -1), day)
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *eventCacheTestSuite) TestGetOverlappingEvents() {
+	event := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY")
+	s.NoError(err)
+	event.Recurrence = recurrence
+	err = s.repo.Add(context.Background(), event)
+	s.NoError(err)
+	startTime := event.StartTime.AddDate(0, 0, 3).Add(-time.Minute)
+	events, err := s.repo.GetOverlappingEvents(context.Background(), event.UserID, startTime, startTime.Add(2*time.Minute))
+	s.NoError(err)
+	s.Len(events, 1)
+	s.Equal(event.StartTime.AddDate(0, 0, 3), events[0].StartTime)
+	events, err = s.repo.GetOverlappingEvents(
+		context.Background(), event.UserID+1, startTime, startTime.Add(2*time.Minute),
+	)
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *eventCacheTestSuite) TestGetEventsPage() {
+	event := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	s.NoError(err)
+	event.Recurrence = recurrence
+	s.NoError(s.repo.Add(context.Background(), event))
+	single := tests.GenerateTestEvent()
+	single.UserID = event.UserID
+	single.StartTime = event.StartTime.AddDate(0, 0, 1).Add(time.Minute)
+	endTime := single.StartTime.Add(time.Hour)
+	single.EndTime = &endTime
+	s.NoError(s.repo.Add(context.Background(), single))
+	filter := &domain.EventFilter{
+		UserID:    event.UserID,
+		StartTime: event.StartTime,
+		EndTime:   event.StartTime.AddDate(0, 0, 7),
+		PageSize:  2,
+	}
+	var starts []time.Time
+	for {
+		page, err := s.repo.GetEventsPage(context.Background(), filter)
+		s.NoError(err)
+		for _, e := range page.Events {
+			starts = append(starts, e.StartTime)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	s.Equal([]time.Time{
+		event.StartTime,
+		event.StartTime.AddDate(0, 0, 1),
+		single.StartTime,
+		event.StartTime.AddDate(0, 0, 2),
+	}, starts)
+}
+
+func (s *eventCacheTestSuite) TestIterateEvents() {
+	event := tests.GenerateTestEvent()
+	recurrence, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=3")
+	s.NoError(err)
+	event.Recurrence = recurrence
+	s.NoError(s.repo.Add(context.Background(), event))
+	filter := &domain.EventFilter{
+		UserID:    event.UserID,
+		StartTime: event.StartTime,
+		EndTime:   event.StartTime.AddDate(0, 0, 7),
+	}
+	var starts []time.Time
+	err = s.repo.IterateEvents(context.Background(), filter, func(e *domain.Event) error {
+		starts = append(starts, e.StartTime)
+		return nil
+	})
+	s.NoError(err)
+	s.Equal([]time.Time{event.StartTime, event.StartTime.AddDate(0, 0, 1), event.StartTime.AddDate(0, 0, 2)}, starts)
+
+	filter.Series = true
+	var series []*domain.Event
+	err = s.repo.IterateEvents(context.Background(), filter, func(e *domain.Event) error {
+		series = append(series, e)
+		return nil
+	})
+	s.NoError(err)
+	s.Len(series, 1)
+	s.Equal(event.ID, series[0].ID)
+	s.Nil(series[0].RecurrenceID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = s.repo.IterateEvents(ctx, filter, func(e *domain.Event) error {
+		return nil
+	})
+	s.ErrorIs(err, context.Canceled)
+}
+
+func (s *eventCacheTestSuite) TestAttendees() {
+	event := withNotification(tests.GenerateTestEvent())
+	s.NoError(s.repo.Add(context.Background(), event))
+	attendee, declined := event.UserID+1, event.UserID+2
+	_, err := s.repo.InviteAttendees(context.Background(), attendee, event.ID, []int64{declined})
+	s.ErrorIs(err, domain.ErrPermission)
+	invited, err := s.repo.InviteAttendees(context.Background(), event.UserID, event.ID, []int64{declined, attendee})
+	s.NoError(err)
+	s.Equal(event.Version+1, invited.Version)
+	_, err = s.repo.RespondInvitation(context.Background(), event.UserID, event.ID, domain.AttendeeAccepted)
+	s.ErrorIs(err, domain.ErrPermission)
+	responded, err := s.repo.RespondInvitation(context.Background(), declined, event.ID, domain.AttendeeDeclined)
+	s.NoError(err)
+	s.Equal(
+		[]*domain.Attendee{
+			{UserID: attendee, Status: domain.AttendeeInvited},
+			{UserID: declined, Status: domain.AttendeeDeclined},
+		},
+		responded.Attendees,
+	)
+
+	result, err := s.repo.Get(context.Background(), declined, event.ID)
+	s.NoError(err)
+	s.Equal(responded, result)
+	events, err := s.repo.GetEventsByPeriod(context.Background(), attendee, event.StartTime, *event.EndTime)
+	s.NoError(err)
+	s.Len(events, 1)
+	events, err = s.repo.GetEventsByPeriod(context.Background(), declined, event.StartTime, *event.EndTime)
+	s.NoError(err)
+	s.Empty(events)
+	invitations, err := s.repo.GetInvitations(context.Background(), declined, "")
+	s.NoError(err)
+	s.Len(invitations, 1)
+	invitations, err = s.repo.GetInvitations(context.Background(), declined, domain.AttendeeAccepted)
+	s.NoError(err)
+	s.Empty(invitations)
+
+	// Each recipient is notified by its own message.
+	messages := cacheOutbox.claim(reminderTime(event), 10)
+	s.Len(messages, 2)
+	for i, userID := range []int64{event.UserID, attendee} {
+		s.Len(messages[i].Notifications, 1)
+		s.Equal(userID, messages[i].Notifications[0].UserToSend)
+	}
+
+	update := *responded
+	update.UserID = attendee
+	s.ErrorIs(s.repo.Update(context.Background(), update.UserID, &update), domain.ErrPermission)
+	update.UserID = event.UserID
+	update.Attendees = nil
+	s.NoError(s.repo.Update(context.Background(), update.UserID, &update))
+	s.Equal(responded.Attendees, update.Attendees)
+}
+
+func (s *eventCacheTestSuite) TestGetEventsPageOfOwner() {
+	owned := tests.GenerateTestEvent()
+	s.NoError(s.repo.Add(context.Background(), owned))
+	invited := tests.GenerateTestEvent()
+	invited.UserID = owned.UserID + 1
+	invited.StartTime = owned.StartTime
+	invited.EndTime = owned.EndTime
+	s.NoError(s.repo.Add(context.Background(), invited))
+	_, err := s.repo.InviteAttendees(context.Background(), invited.UserID, invited.ID, []int64{owned.UserID})
+	s.NoError(err)
+	filter := &domain.EventFilter{UserID: owned.UserID, StartTime: owned.StartTime, EndTime: *owned.EndTime}
+
+	page, err := s.repo.GetEventsPage(context.Background(), filter)
+	s.NoError(err)
+	s.Len(page.Events, 2)
+	filter.OwnerID = owned.UserID
+	page, err = s.repo.GetEventsPage(context.Background(), filter)
+	s.NoError(err)
+	s.Len(page.Events, 1)
+	s.Equal(owned.ID, page.Events[0].ID)
+}
+
 func TestRunCacheEventSuite(t *testing.T) {
 	suite.Run(t, new(eventCacheTestSuite))
 }
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/migrations"
+)
+
+var errMigrationsPending = errors.New("database migrations are pending")
+
+// CheckDB checks the connection to the database, the cache database is always available.
+func CheckDB(ctx context.Context) error {
+	if common.Config.UseCacheDB {
+		return nil
+	}
+	return db.PingContext(ctx)
+}
+
+// CheckMigrations checks that the database schema isn't older than the latest migration of the service.
+func CheckMigrations(ctx context.Context) error {
+	if common.Config.UseCacheDB {
+		return nil
+	}
+	latest, err := migrations.LatestVersion()
+	if common.IsErr(err) {
+		return err
+	}
+	var version int64
+	err = db.QueryRowContext(
+		ctx, "SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied",
+	).Scan(&version)
+	if common.IsErr(err) {
+		return fmt.Errorf("failed to get the schema version: %w", err)
+	}
+	if version < latest {
+		return fmt.Errorf("%w: schema version %d, latest migration %d", errMigrationsPending, version, latest)
+	}
+	return nil
+}
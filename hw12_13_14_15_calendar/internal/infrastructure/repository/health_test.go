@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/migrations"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMigrations(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	db = sqlx.NewDb(mockDB, "sqlmock")
+	latest, err := migrations.LatestVersion()
+	require.NoError(t, err)
+	require.Positive(t, latest)
+
+	query := "^SELECT COALESCE\\(MAX\\(version_id\\), 0\\) FROM goose_db_version WHERE is_applied$"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest - 1))
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest))
+
+	require.ErrorIs(t, CheckMigrations(context.Background()), errMigrationsPending)
+	require.NoError(t, CheckMigrations(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
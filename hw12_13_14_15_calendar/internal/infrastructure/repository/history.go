@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// cacheHistorySize limits the number of changes kept by the cache repository, the oldest ones are dropped.
+const cacheHistorySize = 10000
+
+// eventJSON returns the event as a JSON column value, NULL for a missing event.
+func eventJSON(e *domain.Event) (sql.NullString, error) {
+	if e == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(e)
+	return sql.NullString{String: string(data), Valid: true}, err
+}
+
+// parseEventJSON parses the event of a JSON column value.
+func parseEventJSON(value sql.NullString) (*domain.Event, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	e := &domain.Event{}
+	if err := json.Unmarshal([]byte(value.String), e); common.IsErr(err) {
+		return nil, err
+	}
+	return e, nil
+}
+
+// cloneEvent returns a deep copy of the event, so a change snapshot isn't modified with the event.
+func cloneEvent(e *domain.Event) (*domain.Event, error) {
+	if e == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(e)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	clone := &domain.Event{}
+	return clone, json.Unmarshal(data, clone)
+}
+
+// recordChange adds the event change to the history.
+func recordChange(ctx context.Context, tx *sqlx.Tx, change *domain.EventChange) error {
+	before, err := eventJSON(change.Before)
+	if common.IsErr(err) {
+		return err
+	}
+	after, err := eventJSON(change.After)
+	if common.IsErr(err) {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO event_history (event_id, owner_id, actor_id, request_id, action, before, after, changed_time)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		change.EventID,
+		change.OwnerID(),
+		change.ActorID,
+		change.RequestID,
+		change.Action,
+		before,
+		after,
+		change.Time,
+	)
+	return err
+}
+
+// GetHistory returns changes of an event of the user in the order they were made.
+func (repo *eventDBRepository) GetHistory(
+	ctx context.Context, userID int64, eventID string,
+) ([]*domain.EventChange, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT id, event_id, owner_id, actor_id, request_id, action, before, after, changed_time
+		 FROM event_history WHERE event_id = $1 ORDER BY id`,
+		eventID,
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if common.IsErr(err) {
+			common.Logger.Error().Err(err).Msg("error closing rows")
+		}
+	}(rows)
+	var changes []*domain.EventChange
+	for rows.Next() {
+		var (
+			change        domain.EventChange
+			ownerID       int64
+			before, after sql.NullString
+		)
+		err := rows.Scan(
+			&change.ID,
+			&change.EventID,
+			&ownerID,
+			&change.ActorID,
+			&change.RequestID,
+			&change.Action,
+			&before,
+			&after,
+			&change.Time,
+		)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		if ownerID != userID {
+			return nil, domain.ErrPermission
+		}
+		if change.Before, err = parseEventJSON(before); common.IsErr(err) {
+			return nil, err
+		}
+		if change.After, err = parseEventJSON(after); common.IsErr(err) {
+			return nil, err
+		}
+		change.Time = change.Time.UTC()
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); common.IsErr(err) {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, domain.ErrEventNotExist
+	}
+	return changes, nil
+}
+
+// memoryHistory is a bounded in-memory event history of the cache repository.
+type memoryHistory struct {
+	mu      sync.Mutex
+	lastID  int64
+	size    int
+	changes []*domain.EventChange
+}
+
+func newMemoryHistory(size int) *memoryHistory {
+	return &memoryHistory{size: size}
+}
+
+// add adds the change dropping the oldest one if the history is full.
+func (h *memoryHistory) add(change *domain.EventChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastID++
+	change.ID = h.lastID
+	if len(h.changes) >= h.size {
+		h.changes = append(h.changes[:0], h.changes[len(h.changes)-h.size+1:]...)
+	}
+	h.changes = append(h.changes, change)
+}
+
+// record adds the change of the event made by the actor and the request of the context.
+func (h *memoryHistory) record(ctx context.Context, action string, before, after *domain.Event) error {
+	before, err := cloneEvent(before)
+	if common.IsErr(err) {
+		return err
+	}
+	after, err = cloneEvent(after)
+	if common.IsErr(err) {
+		return err
+	}
+	h.add(domain.NewEventChange(ctx, action, before, after))
+	return nil
+}
+
+// get returns kept changes of the event.
+func (h *memoryHistory) get(eventID string) []*domain.EventChange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var changes []*domain.EventChange
+	for _, change := range h.changes {
+		if change.EventID == eventID {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// clear removes all changes.
+func (h *memoryHistory) clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.changes = nil
+}
+
+// GetHistory returns kept changes of an event of the user in the order they were made.
+func (repo *eventCacheRepository) GetHistory(
+	_ context.Context, userID int64, eventID string,
+) ([]*domain.EventChange, error) {
+	changes := cacheHistory.get(eventID)
+	if len(changes) == 0 {
+		return nil, domain.ErrEventNotExist
+	}
+	for _, change := range changes {
+		if change.OwnerID() != userID {
+			return nil, domain.ErrPermission
+		}
+	}
+	return changes, nil
+}
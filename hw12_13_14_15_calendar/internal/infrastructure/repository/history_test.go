@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+var historyColumns = []string{
+	"id", "event_id", "owner_id", "actor_id", "request_id", "action", "before", "after", "changed_time",
+}
+
+func TestEventDBRepositoryGetHistory(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	db = sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewEventDBRepository()
+	e := tests.GenerateTestEvent()
+	after, err := json.Marshal(e)
+	require.NoError(t, err)
+	changedTime := time.Now().UTC().Truncate(time.Millisecond)
+	query := "^SELECT (.+) FROM event_history WHERE event_id = \\$1 ORDER BY id$"
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(historyColumns).
+			AddRow(1, e.ID, e.UserID, e.UserID, "request-1", domain.EventActionCreate, nil, after, changedTime)
+	}
+	mock.ExpectQuery(query).WithArgs(e.ID).WillReturnRows(rows())
+	mock.ExpectQuery(query).WithArgs(e.ID).WillReturnRows(rows())
+	mock.ExpectQuery(query).WithArgs(e.ID).WillReturnRows(sqlmock.NewRows(historyColumns))
+
+	changes, err := repo.GetHistory(context.Background(), e.UserID, e.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.EventChange{{
+		ID:        1,
+		EventID:   e.ID,
+		Action:    domain.EventActionCreate,
+		ActorID:   e.UserID,
+		RequestID: "request-1",
+		After:     e,
+		Time:      changedTime,
+	}}, changes)
+	_, err = repo.GetHistory(context.Background(), e.UserID+1, e.ID)
+	require.ErrorIs(t, err, domain.ErrPermission)
+	_, err = repo.GetHistory(context.Background(), e.UserID, e.ID)
+	require.ErrorIs(t, err, domain.ErrEventNotExist)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMemoryHistory(t *testing.T) {
+	history := newMemoryHistory(2)
+	e := tests.GenerateTestEvent()
+	ctx := domain.WithActor(context.Background(), e.UserID)
+	require.NoError(t, history.record(ctx, domain.EventActionCreate, nil, e))
+	e.Title = "NewTitle"
+	require.NoError(t, history.record(ctx, domain.EventActionUpdate, nil, e))
+	require.NoError(t, history.record(ctx, domain.EventActionDeletePermanently, e, nil))
+	e.Title = "Changed"
+
+	changes := history.get(e.ID)
+	require.Len(t, changes, 2)
+	require.Equal(t, domain.EventActionUpdate, changes[0].Action)
+	require.Equal(t, "NewTitle", changes[0].After.Title)
+	require.Equal(t, domain.EventActionDeletePermanently, changes[1].Action)
+	require.Equal(t, int64(3), changes[1].ID)
+	require.Equal(t, e.UserID, changes[1].ActorID)
+	require.Nil(t, changes[1].After)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/freecache"
+	"github.com/jmoiron/sqlx"
+)
+
+type idempotencyDBRepository struct{}
+
+// NewIdempotencyDBRepository returns a new instance of an idempotencyDBRepository.
+func NewIdempotencyDBRepository() domain.IdempotencyRepository {
+	return &idempotencyDBRepository{}
+}
+
+// Reserve inserts a record of the request unless a record of the request ID exists, an expired record is replaced.
+func (repo *idempotencyDBRepository) Reserve(
+	ctx context.Context, request *domain.IdempotentRequest, ttl time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	var record *domain.IdempotencyRecord
+	err := inTx(ctx, func(tx *sqlx.Tx) error {
+		now := time.Now().UTC()
+		_, err := tx.ExecContext(
+			ctx,
+			`DELETE FROM idempotency_key WHERE user_id = $1 AND request_id = $2 AND expires_time <= $3`,
+			request.UserID,
+			request.RequestID,
+			now,
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		result, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO idempotency_key (user_id, request_id, request_hash, created_time, expires_time)
+			 VALUES ($1, $2, $3, $4, $5) ON CONFLICT (user_id, request_id) DO NOTHING`,
+			request.UserID,
+			request.RequestID,
+			request.Hash,
+			now,
+			now.Add(ttl),
+		)
+		if common.IsErr(err) {
+			return err
+		}
+		if affected, err := result.RowsAffected(); common.IsErr(err) || affected == 1 {
+			return err
+		}
+		record = &domain.IdempotencyRecord{}
+		err = tx.QueryRowContext(
+			ctx,
+			`SELECT request_hash, completed_time IS NOT NULL, response FROM idempotency_key
+			 WHERE user_id = $1 AND request_id = $2`,
+			request.UserID,
+			request.RequestID,
+		).Scan(&record.Hash, &record.Completed, &record.Response)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The record was released by the failed request after the insert.
+			return domain.ErrRequestInProgress
+		}
+		return err
+	})
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Complete stores the response of the request.
+func (repo *idempotencyDBRepository) Complete(
+	ctx context.Context, request *domain.IdempotentRequest, response []byte, ttl time.Duration,
+) error {
+	now := time.Now().UTC()
+	_, err := db.ExecContext(
+		ctx,
+		`UPDATE idempotency_key SET response = $3, completed_time = $4, expires_time = $5
+		 WHERE user_id = $1 AND request_id = $2`,
+		request.UserID,
+		request.RequestID,
+		response,
+		now,
+		now.Add(ttl),
+	)
+	return err
+}
+
+// Release removes the record of the request unless it's completed.
+func (repo *idempotencyDBRepository) Release(ctx context.Context, request *domain.IdempotentRequest) error {
+	_, err := db.ExecContext(
+		ctx,
+		`DELETE FROM idempotency_key WHERE user_id = $1 AND request_id = $2 AND completed_time IS NULL`,
+		request.UserID,
+		request.RequestID,
+	)
+	return err
+}
+
+// Purge removes expired records.
+func (repo *idempotencyDBRepository) Purge(ctx context.Context, now time.Time) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM idempotency_key WHERE expires_time <= $1`, now)
+	return err
+}
+
+// idempotencyCacheRepository keeps records in freecache which expires them.
+type idempotencyCacheRepository struct {
+	mu    sync.Mutex
+	cache *freecache.CacheDB
+}
+
+// NewIdempotencyCacheRepository returns a new instance of an idempotencyCacheRepository keeping records in the cache.
+func NewIdempotencyCacheRepository(cache *freecache.CacheDB) domain.IdempotencyRepository {
+	return &idempotencyCacheRepository{cache: cache}
+}
+
+// key returns the cache key of the request.
+func (repo *idempotencyCacheRepository) key(request *domain.IdempotentRequest) []byte {
+	return []byte(fmt.Sprintf("%d:%s", request.UserID, request.RequestID))
+}
+
+// set stores the record for ttl, freecache expires entries in whole seconds.
+func (repo *idempotencyCacheRepository) set(
+	request *domain.IdempotentRequest, record *domain.IdempotencyRecord, ttl time.Duration,
+) error {
+	data, err := json.Marshal(record)
+	if common.IsErr(err) {
+		return err
+	}
+	return repo.cache.Set(repo.key(request), data, max(int(ttl/time.Second), 1))
+}
+
+// get returns the record of the request, nil if it's missing or expired.
+func (repo *idempotencyCacheRepository) get(request *domain.IdempotentRequest) (*domain.IdempotencyRecord, error) {
+	data, err := repo.cache.Get(repo.key(request))
+	if common.IsErr(err) {
+		return nil, nil
+	}
+	record := &domain.IdempotencyRecord{}
+	return record, json.Unmarshal(data, record)
+}
+
+// Reserve stores a record of the request unless a record of the request ID exists.
+func (repo *idempotencyCacheRepository) Reserve(
+	_ context.Context, request *domain.IdempotentRequest, ttl time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	record, err := repo.get(request)
+	if common.IsErr(err) || record != nil {
+		return record, err
+	}
+	return nil, repo.set(request, &domain.IdempotencyRecord{Hash: request.Hash}, ttl)
+}
+
+// Complete stores the response of the request.
+func (repo *idempotencyCacheRepository) Complete(
+	_ context.Context, request *domain.IdempotentRequest, response []byte, ttl time.Duration,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.set(request, &domain.IdempotencyRecord{Hash: request.Hash, Completed: true, Response: response}, ttl)
+}
+
+// Release removes the record of the request unless it's completed.
+func (repo *idempotencyCacheRepository) Release(_ context.Context, request *domain.IdempotentRequest) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	record, err := repo.get(request)
+	if common.IsErr(err) || record == nil || record.Completed {
+		return err
+	}
+	repo.cache.Del(repo.key(request))
+	return nil
+}
+
+// Purge does nothing since the cache expires records itself.
+func (repo *idempotencyCacheRepository) Purge(_ context.Context, _ time.Time) error {
+	return nil
+}
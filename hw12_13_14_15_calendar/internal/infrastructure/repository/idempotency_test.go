@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/freecache"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyDBRepository(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	db = sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewIdempotencyDBRepository()
+	request := domain.NewIdempotentRequest(7, "request-1", "CreateEvent", []byte("payload"))
+	deleteExpired := "^DELETE FROM idempotency_key WHERE user_id = \\$1 AND request_id = \\$2 AND expires_time <= \\$3$"
+	insert := "^INSERT INTO idempotency_key (.+) ON CONFLICT \\(user_id, request_id\\) DO NOTHING$"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteExpired).WithArgs(7, "request-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insert).
+		WithArgs(7, "request-1", request.Hash, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("^UPDATE idempotency_key SET (.+) WHERE user_id = \\$1 AND request_id = \\$2$").
+		WithArgs(7, "request-1", []byte("response"), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteExpired).WithArgs(7, "request-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insert).
+		WithArgs(7, "request-1", request.Hash, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("^SELECT request_hash, (.+) FROM idempotency_key WHERE user_id = \\$1 AND request_id = \\$2$").
+		WithArgs(7, "request-1").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash", "completed", "response"}).
+			AddRow(request.Hash, true, []byte("response")))
+	mock.ExpectCommit()
+	mock.ExpectExec("^DELETE FROM idempotency_key WHERE user_id = \\$1 AND request_id = \\$2 AND completed_time IS NULL$").
+		WithArgs(7, "request-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("^DELETE FROM idempotency_key WHERE expires_time <= \\$1$").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := context.Background()
+	record, err := repo.Reserve(ctx, request, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, record)
+	require.NoError(t, repo.Complete(ctx, request, []byte("response"), time.Hour))
+	record, err = repo.Reserve(ctx, request, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, &domain.IdempotencyRecord{Hash: request.Hash, Completed: true, Response: []byte("response")}, record)
+	require.NoError(t, repo.Release(ctx, request))
+	require.NoError(t, repo.Purge(ctx, time.Now()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyCacheRepository(t *testing.T) {
+	repo := NewIdempotencyCacheRepository(freecache.NewCacheDB(1024 * 1024))
+	request := domain.NewIdempotentRequest(7, "request-1", "CreateEvent", []byte("payload"))
+	otherUser := domain.NewIdempotentRequest(8, "request-1", "CreateEvent", []byte("payload"))
+	ctx := context.Background()
+
+	record, err := repo.Reserve(ctx, request, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, record)
+	record, err = repo.Reserve(ctx, request, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, &domain.IdempotencyRecord{Hash: request.Hash}, record)
+	record, err = repo.Reserve(ctx, otherUser, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	require.NoError(t, repo.Release(ctx, otherUser))
+	record, err = repo.Reserve(ctx, otherUser, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	require.NoError(t, repo.Complete(ctx, request, []byte("response"), time.Hour))
+	require.NoError(t, repo.Release(ctx, request))
+	record, err = repo.Reserve(ctx, request, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, &domain.IdempotencyRecord{Hash: request.Hash, Completed: true, Response: []byte("response")}, record)
+}
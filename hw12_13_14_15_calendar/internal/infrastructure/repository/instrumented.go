@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+// streamOperations are repository operations limited by the stream timeout.
+var streamOperations = map[string]bool{"IterateEvents": true}
+
+// queryTimeout returns the timeout of the repository operation.
+func queryTimeout(operation string) time.Duration {
+	if streamOperations[operation] {
+		return time.Duration(common.Config.DB.StreamTimeout) * time.Second
+	}
+	return time.Duration(common.Config.DB.QueryTimeout) * time.Second
+}
+
+// startQuery starts a span of the repository operation and limits the operation by its timeout,
+// the returned function ends the span, observes the operation latency and returns the operation error
+// wrapping the context error if the operation was canceled or timed out.
+func startQuery(ctx context.Context, repository, operation string) (context.Context, func(err error) error) {
+	start := time.Now()
+	ctx, span := common.Tracer.Start(ctx, repository+"."+operation)
+	cancel := context.CancelFunc(func() {})
+	if timeout := queryTimeout(operation); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func(err error) error {
+		if ctxErr := ctx.Err(); common.IsErr(err) && ctxErr != nil && !errors.Is(err, ctxErr) {
+			err = fmt.Errorf("%w: %w", ctxErr, err)
+		}
+		cancel()
+		common.EndSpan(span, err)
+		observeQuery(repository, operation, start, err)
+		return err
+	}
+}
+
+// observeQuery observes the latency of the repository operation started at start.
+func observeQuery(repository, operation string, start time.Time, err error) {
+	common.RepositoryQueryDuration.
+		WithLabelValues(repository, operation, common.MetricStatus(err)).
+		Observe(time.Since(start).Seconds())
+}
+
+// eventInstrumentedRepository traces and observes latency of the event repository calls.
+type eventInstrumentedRepository struct {
+	repository domain.EventRepository
+}
+
+// newEventInstrumentedRepository returns the event repository tracing and observing latency of its calls.
+func newEventInstrumentedRepository(repository domain.EventRepository) domain.EventRepository {
+	return &eventInstrumentedRepository{repository: repository}
+}
+
+func (repo *eventInstrumentedRepository) Add(ctx context.Context, event *domain.Event) (err error) {
+	ctx, done := startQuery(ctx, "event", "Add")
+	defer func() { err = done(err) }()
+	return repo.repository.Add(ctx, event)
+}
+
+func (repo *eventInstrumentedRepository) Update(ctx context.Context, userID int64, event *domain.Event) (err error) {
+	ctx, done := startQuery(ctx, "event", "Update")
+	defer func() { err = done(err) }()
+	return repo.repository.Update(ctx, userID, event)
+}
+
+func (repo *eventInstrumentedRepository) Delete(ctx context.Context, userID int64, eventID string) (err error) {
+	ctx, done := startQuery(ctx, "event", "Delete")
+	defer func() { err = done(err) }()
+	return repo.repository.Delete(ctx, userID, eventID)
+}
+
+func (repo *eventInstrumentedRepository) DeletePermanently(
+	ctx context.Context, userID int64, eventID string,
+) (err error) {
+	ctx, done := startQuery(ctx, "event", "DeletePermanently")
+	defer func() { err = done(err) }()
+	return repo.repository.DeletePermanently(ctx, userID, eventID)
+}
+
+func (repo *eventInstrumentedRepository) Restore(
+	ctx context.Context, userID int64, eventID string,
+) (e *domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "Restore")
+	defer func() { err = done(err) }()
+	return repo.repository.Restore(ctx, userID, eventID)
+}
+
+func (repo *eventInstrumentedRepository) GetDeletedEvents(
+	ctx context.Context, userID int64,
+) (events []*domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "GetDeletedEvents")
+	defer func() { err = done(err) }()
+	return repo.repository.GetDeletedEvents(ctx, userID)
+}
+
+func (repo *eventInstrumentedRepository) PurgeEvents(
+	ctx context.Context, expiredBefore, deletedBefore time.Time,
+) (err error) {
+	ctx, done := startQuery(ctx, "event", "PurgeEvents")
+	defer func() { err = done(err) }()
+	return repo.repository.PurgeEvents(ctx, expiredBefore, deletedBefore)
+}
+
+func (repo *eventInstrumentedRepository) Get(
+	ctx context.Context, userID int64, eventID string,
+) (e *domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "Get")
+	defer func() { err = done(err) }()
+	return repo.repository.Get(ctx, userID, eventID)
+}
+
+func (repo *eventInstrumentedRepository) GetEventsByPeriod(
+	ctx context.Context, userID int64, startTime, endTime time.Time,
+) (events []*domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "GetEventsByPeriod")
+	defer func() { err = done(err) }()
+	return repo.repository.GetEventsByPeriod(ctx, userID, startTime, endTime)
+}
+
+func (repo *eventInstrumentedRepository) GetEventsPage(
+	ctx context.Context, filter *domain.EventFilter,
+) (page *domain.EventPage, err error) {
+	ctx, done := startQuery(ctx, "event", "GetEventsPage")
+	defer func() { err = done(err) }()
+	return repo.repository.GetEventsPage(ctx, filter)
+}
+
+func (repo *eventInstrumentedRepository) IterateEvents(
+	ctx context.Context, filter *domain.EventFilter, fn func(e *domain.Event) error,
+) (err error) {
+	ctx, done := startQuery(ctx, "event", "IterateEvents")
+	defer func() { err = done(err) }()
+	return repo.repository.IterateEvents(ctx, filter, fn)
+}
+
+func (repo *eventInstrumentedRepository) GetOverlappingEvents(
+	ctx context.Context, userID int64, startTime, endTime time.Time,
+) (events []*domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "GetOverlappingEvents")
+	defer func() { err = done(err) }()
+	return repo.repository.GetOverlappingEvents(ctx, userID, startTime, endTime)
+}
+
+func (repo *eventInstrumentedRepository) InviteAttendees(
+	ctx context.Context, userID int64, eventID string, userIDs []int64,
+) (e *domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "InviteAttendees")
+	defer func() { err = done(err) }()
+	return repo.repository.InviteAttendees(ctx, userID, eventID, userIDs)
+}
+
+func (repo *eventInstrumentedRepository) RespondInvitation(
+	ctx context.Context, userID int64, eventID string, status domain.AttendeeStatus,
+) (e *domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "RespondInvitation")
+	defer func() { err = done(err) }()
+	return repo.repository.RespondInvitation(ctx, userID, eventID, status)
+}
+
+func (repo *eventInstrumentedRepository) GetInvitations(
+	ctx context.Context, userID int64, status domain.AttendeeStatus,
+) (events []*domain.Event, err error) {
+	ctx, done := startQuery(ctx, "event", "GetInvitations")
+	defer func() { err = done(err) }()
+	return repo.repository.GetInvitations(ctx, userID, status)
+}
+
+func (repo *eventInstrumentedRepository) GetHistory(
+	ctx context.Context, userID int64, eventID string,
+) (changes []*domain.EventChange, err error) {
+	ctx, done := startQuery(ctx, "event", "GetHistory")
+	defer func() { err = done(err) }()
+	return repo.repository.GetHistory(ctx, userID, eventID)
+}
+
+// outboxInstrumentedRepository traces and observes latency of the notification outbox calls.
+type outboxInstrumentedRepository struct {
+	outbox domain.NotificationOutbox
+}
+
+// newOutboxInstrumentedRepository returns the notification outbox tracing and observing latency of its calls.
+func newOutboxInstrumentedRepository(outbox domain.NotificationOutbox) domain.NotificationOutbox {
+	return &outboxInstrumentedRepository{outbox: outbox}
+}
+
+func (repo *outboxInstrumentedRepository) ProcessNotifications(
+	ctx context.Context, now time.Time, limit int, backoff domain.Backoff, fn func(m *domain.OutboxMessage) error,
+) (count int, err error) {
+	ctx, done := startQuery(ctx, "outbox", "ProcessNotifications")
+	defer func() { err = done(err) }()
+	return repo.outbox.ProcessNotifications(ctx, now, limit, backoff, fn)
+}
+
+// calendarInstrumentedRepository traces and observes latency of the calendar repository calls.
+type calendarInstrumentedRepository struct {
+	repository domain.CalendarRepository
+}
+
+// newCalendarInstrumentedRepository returns the calendar repository tracing and observing latency of its calls.
+func newCalendarInstrumentedRepository(repository domain.CalendarRepository) domain.CalendarRepository {
+	return &calendarInstrumentedRepository{repository: repository}
+}
+
+func (repo *calendarInstrumentedRepository) Add(ctx context.Context, calendar *domain.Calendar) (err error) {
+	ctx, done := startQuery(ctx, "calendar", "Add")
+	defer func() { err = done(err) }()
+	return repo.repository.Add(ctx, calendar)
+}
+
+func (repo *calendarInstrumentedRepository) Update(ctx context.Context, calendar *domain.Calendar) (err error) {
+	ctx, done := startQuery(ctx, "calendar", "Update")
+	defer func() { err = done(err) }()
+	return repo.repository.Update(ctx, calendar)
+}
+
+func (repo *calendarInstrumentedRepository) Delete(ctx context.Context, userID int64, calendarID string) (err error) {
+	ctx, done := startQuery(ctx, "calendar", "Delete")
+	defer func() { err = done(err) }()
+	return repo.repository.Delete(ctx, userID, calendarID)
+}
+
+func (repo *calendarInstrumentedRepository) Get(
+	ctx context.Context, userID int64, calendarID string,
+) (c *domain.Calendar, err error) {
+	ctx, done := startQuery(ctx, "calendar", "Get")
+	defer func() { err = done(err) }()
+	return repo.repository.Get(ctx, userID, calendarID)
+}
+
+func (repo *calendarInstrumentedRepository) GetCalendars(
+	ctx context.Context, userID int64,
+) (calendars []*domain.Calendar, err error) {
+	ctx, done := startQuery(ctx, "calendar", "GetCalendars")
+	defer func() { err = done(err) }()
+	return repo.repository.GetCalendars(ctx, userID)
+}
+
+func (repo *calendarInstrumentedRepository) Share(
+	ctx context.Context, ownerID int64, calendarID string, share *domain.CalendarShare,
+) (err error) {
+	ctx, done := startQuery(ctx, "calendar", "Share")
+	defer func() { err = done(err) }()
+	return repo.repository.Share(ctx, ownerID, calendarID, share)
+}
+
+func (repo *calendarInstrumentedRepository) Unshare(
+	ctx context.Context, ownerID int64, calendarID string, userID int64,
+) (err error) {
+	ctx, done := startQuery(ctx, "calendar", "Unshare")
+	defer func() { err = done(err) }()
+	return repo.repository.Unshare(ctx, ownerID, calendarID, userID)
+}
+
+func (repo *calendarInstrumentedRepository) GetShares(
+	ctx context.Context, ownerID int64, calendarID string,
+) (shares []*domain.CalendarShare, err error) {
+	ctx, done := startQuery(ctx, "calendar", "GetShares")
+	defer func() { err = done(err) }()
+	return repo.repository.GetShares(ctx, ownerID, calendarID)
+}
+
+// notificationTargetInstrumentedRepository traces and observes latency of the notification target repository calls.
+type notificationTargetInstrumentedRepository struct {
+	repository domain.NotificationTargetRepository
+}
+
+// newNotificationTargetInstrumentedRepository returns the notification target repository
+// tracing and observing latency of its calls.
+func newNotificationTargetInstrumentedRepository(
+	repository domain.NotificationTargetRepository,
+) domain.NotificationTargetRepository {
+	return &notificationTargetInstrumentedRepository{repository: repository}
+}
+
+func (repo *notificationTargetInstrumentedRepository) Get(
+	ctx context.Context, userID int64,
+) (target *domain.NotificationTarget, err error) {
+	ctx, done := startQuery(ctx, "notification_target", "Get")
+	defer func() { err = done(err) }()
+	return repo.repository.Get(ctx, userID)
+}
+
+func (repo *notificationTargetInstrumentedRepository) Set(
+	ctx context.Context, userID int64, target *domain.NotificationTarget,
+) (err error) {
+	ctx, done := startQuery(ctx, "notification_target", "Set")
+	defer func() { err = done(err) }()
+	return repo.repository.Set(ctx, userID, target)
+}
+
+// idempotencyInstrumentedRepository traces and observes latency of the idempotency repository calls.
+type idempotencyInstrumentedRepository struct {
+	repository domain.IdempotencyRepository
+}
+
+// newIdempotencyInstrumentedRepository returns the idempotency repository tracing and observing latency of its calls.
+func newIdempotencyInstrumentedRepository(repository domain.IdempotencyRepository) domain.IdempotencyRepository {
+	return &idempotencyInstrumentedRepository{repository: repository}
+}
+
+func (repo *idempotencyInstrumentedRepository) Reserve(
+	ctx context.Context, request *domain.IdempotentRequest, ttl time.Duration,
+) (record *domain.IdempotencyRecord, err error) {
+	ctx, done := startQuery(ctx, "idempotency", "Reserve")
+	defer func() { err = done(err) }()
+	return repo.repository.Reserve(ctx, request, ttl)
+}
+
+func (repo *idempotencyInstrumentedRepository) Complete(
+	ctx context.Context, request *domain.IdempotentRequest, response []byte, ttl time.Duration,
+) (err error) {
+	ctx, done := startQuery(ctx, "idempotency", "Complete")
+	defer func() { err = done(err) }()
+	return repo.repository.Complete(ctx, request, response, ttl)
+}
+
+func (repo *idempotencyInstrumentedRepository) Release(
+	ctx context.Context, request *domain.IdempotentRequest,
+) (err error) {
+	ctx, done := startQuery(ctx, "idempotency", "Release")
+	defer func() { err = done(err) }()
+	return repo.repository.Release(ctx, request)
+}
+
+func (repo *idempotencyInstrumentedRepository) Purge(ctx context.Context, now time.Time) (err error) {
+	ctx, done := startQuery(ctx, "idempotency", "Purge")
+	defer func() { err = done(err) }()
+	return repo.repository.Purge(ctx, now)
+}
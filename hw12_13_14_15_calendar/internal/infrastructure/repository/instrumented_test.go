@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationTargetInstrumentedRepository(t *testing.T) {
+	repo := newNotificationTargetInstrumentedRepository(NewNotificationTargetCacheRepository())
+	_, err := repo.Get(context.Background(), 7)
+	require.ErrorIs(t, err, domain.ErrNotificationTargetNotExist)
+	require.NoError(t, repo.Set(context.Background(), 7, &domain.NotificationTarget{Channel: domain.ChannelLog}))
+
+	rec := httptest.NewRecorder()
+	common.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `calendar_repository_query_duration_seconds_count`+
+		`{operation="Get",repository="notification_target",status="error"} 1`)
+	require.Contains(t, string(body), `calendar_repository_query_duration_seconds_count`+
+		`{operation="Set",repository="notification_target",status="ok"} 1`)
+}
+
+func TestEventInstrumentedRepositoryTimeout(t *testing.T) {
+	config := common.Config.DB
+	defer func() { common.Config.DB = config }()
+	const eventID = "b8a2a2c4-5a4b-4bd0-9cbb-2b1a1a7d6a51"
+	newRepo := func() (domain.EventRepository, sqlmock.Sqlmock) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		db = sqlx.NewDb(mockDB, "sqlmock")
+		return newEventInstrumentedRepository(NewEventDBRepository()), mock
+	}
+
+	t.Run("caller deadline", func(t *testing.T) {
+		common.Config.DB.QueryTimeout, common.Config.DB.StreamTimeout = 0, 0
+		repo, mock := newRepo()
+		mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
+			WillReturnRows(sqlmock.NewRows(eventColumns)).
+			WillDelayFor(time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := repo.Get(ctx, 1, eventID)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("query timeout", func(t *testing.T) {
+		common.Config.DB.QueryTimeout, common.Config.DB.StreamTimeout = 1, 60
+		repo, mock := newRepo()
+		mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
+			WillReturnRows(sqlmock.NewRows(eventColumns)).
+			WillDelayFor(5 * time.Second)
+		start := time.Now()
+		_, err := repo.Get(context.Background(), 1, eventID)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("stream timeout", func(t *testing.T) {
+		common.Config.DB.QueryTimeout, common.Config.DB.StreamTimeout = 60, 1
+		repo, mock := newRepo()
+		mock.ExpectQuery("^SELECT (.+) FROM event WHERE (.+) ORDER BY start_time ASC, id ASC$").
+			WillReturnRows(sqlmock.NewRows(eventColumns)).
+			WillDelayFor(5 * time.Second)
+		start := time.Now()
+		filter := &domain.EventFilter{UserID: 1, StartTime: time.Now(), EndTime: time.Now().AddDate(0, 0, 7)}
+		err := repo.IterateEvents(context.Background(), filter, func(*domain.Event) error { return nil })
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestQueryTimeout(t *testing.T) {
+	config := common.Config.DB
+	defer func() { common.Config.DB = config }()
+	common.Config.DB.QueryTimeout, common.Config.DB.StreamTimeout = 5, 300
+	require.Equal(t, 5*time.Second, queryTimeout("Get"))
+	require.Equal(t, 300*time.Second, queryTimeout("IterateEvents"))
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+)
+
+type notificationTargetDBRepository struct{}
+
+// NewNotificationTargetDBRepository returns a new instance of a notificationTargetDBRepository.
+func NewNotificationTargetDBRepository() domain.NotificationTargetRepository {
+	return &notificationTargetDBRepository{}
+}
+
+// Get returns the notification target of the user.
+func (repo *notificationTargetDBRepository) Get(ctx context.Context, userID int64) (*domain.NotificationTarget, error) {
+	var target domain.NotificationTarget
+	err := db.QueryRowContext(
+		ctx,
+		"SELECT channel, address FROM user_notification_target WHERE user_id = $1", userID,
+	).Scan(&target.Channel, &target.Address)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotificationTargetNotExist
+	}
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// Set sets the notification target of the user.
+func (repo *notificationTargetDBRepository) Set(
+	ctx context.Context, userID int64, target *domain.NotificationTarget,
+) error {
+	query := `INSERT INTO user_notification_target (user_id, channel, address, updated_time) VALUES ($1, $2, $3, $4)
+			  ON CONFLICT (user_id) DO UPDATE SET (channel, address, updated_time)
+			  = (EXCLUDED.channel, EXCLUDED.address, EXCLUDED.updated_time)`
+	_, err := db.ExecContext(ctx, query, userID, target.Channel, target.Address, time.Now().UTC())
+	return err
+}
+
+type notificationTargetCacheRepository struct {
+	mu      sync.RWMutex
+	targets map[int64]domain.NotificationTarget
+}
+
+// NewNotificationTargetCacheRepository returns a new instance of an in-memory notificationTargetCacheRepository.
+func NewNotificationTargetCacheRepository() domain.NotificationTargetRepository {
+	return &notificationTargetCacheRepository{targets: make(map[int64]domain.NotificationTarget)}
+}
+
+// Get returns the notification target of the user.
+func (repo *notificationTargetCacheRepository) Get(
+	_ context.Context, userID int64,
+) (*domain.NotificationTarget, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	target, ok := repo.targets[userID]
+	if !ok {
+		return nil, domain.ErrNotificationTargetNotExist
+	}
+	return &target, nil
+}
+
+// Set sets the notification target of the user.
+func (repo *notificationTargetCacheRepository) Set(
+	_ context.Context, userID int64, target *domain.NotificationTarget,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.targets[userID] = *target
+	return nil
+}
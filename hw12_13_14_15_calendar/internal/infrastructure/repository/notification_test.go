@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationTargetDBRepository(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	db = sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewNotificationTargetDBRepository()
+	target := &domain.NotificationTarget{Channel: domain.ChannelEmail, Address: "user@example.com"}
+
+	mock.ExpectQuery("^SELECT channel, address FROM user_notification_target WHERE user_id = \\$1$").
+		WithArgs(7).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("^INSERT INTO user_notification_target (.+) ON CONFLICT \\(user_id\\) DO UPDATE (.+)$").
+		WithArgs(7, target.Channel, target.Address, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("^SELECT channel, address FROM user_notification_target WHERE user_id = \\$1$").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"channel", "address"}).AddRow(target.Channel, target.Address))
+
+	_, err = repo.Get(context.Background(), 7)
+	require.ErrorIs(t, err, domain.ErrNotificationTargetNotExist)
+	require.NoError(t, repo.Set(context.Background(), 7, target))
+	result, err := repo.Get(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, target, result)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationTargetCacheRepository(t *testing.T) {
+	repo := NewNotificationTargetCacheRepository()
+	target := &domain.NotificationTarget{Channel: domain.ChannelWebhook, Address: "https://example.com/hook"}
+
+	_, err := repo.Get(context.Background(), 7)
+	require.ErrorIs(t, err, domain.ErrNotificationTargetNotExist)
+	require.NoError(t, repo.Set(context.Background(), 7, target))
+	result, err := repo.Get(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, target, result)
+	_, err = repo.Get(context.Background(), 8)
+	require.ErrorIs(t, err, domain.ErrNotificationTargetNotExist)
+}
@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// enqueueNotifications adds the first notifications of each event reminder at or after the time to the outbox.
+func enqueueNotifications(ctx context.Context, tx *sqlx.Tx, e *domain.Event, after time.Time) error {
+	for _, m := range domain.NewOutboxMessages(e, after) {
+		if err := insertMessage(ctx, tx, m); common.IsErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertMessage adds the message to the outbox with the trace context of the context span.
+func insertMessage(ctx context.Context, tx *sqlx.Tx, m *domain.OutboxMessage) error {
+	payload, err := json.Marshal(m.Notifications)
+	if common.IsErr(err) {
+		return err
+	}
+	traceContext, err := traceContextValue(ctx)
+	if common.IsErr(err) {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO notification_outbox (event_id, payload, notify_time, next_attempt_time, trace_context)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		m.EventID,
+		payload,
+		m.NotifyTime,
+		m.NextAttemptTime,
+		traceContext,
+	)
+	return err
+}
+
+// traceContextValue returns the trace context of the context span as a column value.
+func traceContextValue(ctx context.Context) (sql.NullString, error) {
+	carrier := common.TraceCarrier(ctx)
+	if carrier == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(carrier)
+	return sql.NullString{String: string(data), Valid: true}, err
+}
+
+// removeNotifications removes pending event notifications from the outbox.
+func removeNotifications(ctx context.Context, tx *sqlx.Tx, eventID string) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM notification_outbox WHERE event_id = $1 AND sent_time IS NULL AND failed_time IS NULL`,
+		eventID,
+	)
+	return err
+}
+
+// replaceNotifications replaces pending event notifications of the outbox.
+func replaceNotifications(ctx context.Context, tx *sqlx.Tx, e *domain.Event, after time.Time) error {
+	if err := removeNotifications(ctx, tx, e.ID); common.IsErr(err) {
+		return err
+	}
+	return enqueueNotifications(ctx, tx, e, after)
+}
+
+// outboxClaimTimeout is how long claimed messages are not due, they're processed again after the timeout
+// if the scheduler stops before their results are recorded.
+const outboxClaimTimeout = time.Minute
+
+type notificationOutboxDBRepository struct{}
+
+// NewNotificationOutboxDBRepository returns a new instance of a notificationOutboxDBRepository.
+func NewNotificationOutboxDBRepository() domain.NotificationOutbox {
+	return &notificationOutboxDBRepository{}
+}
+
+// ProcessNotifications claims due messages of the outbox and calls fn for each of them outside a transaction,
+// the result of each message is recorded in its own transaction. Messages are locked with FOR UPDATE SKIP LOCKED
+// and postponed by the claim timeout, so concurrent schedulers don't claim the same messages.
+func (repo *notificationOutboxDBRepository) ProcessNotifications(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	backoff domain.Backoff,
+	fn func(m *domain.OutboxMessage) error,
+) (int, error) {
+	messages, err := repo.claim(ctx, now, limit)
+	if common.IsErr(err) {
+		return 0, err
+	}
+	var errs []error
+	for _, m := range messages {
+		if err := fn(m); common.IsErr(err) {
+			m.Fail(now, err, backoff)
+		} else {
+			m.SentTime = &now
+		}
+		// A failed record doesn't stop the batch, the message is processed again after the claim timeout.
+		if err := repo.record(ctx, m); common.IsErr(err) {
+			errs = append(errs, fmt.Errorf("failed to record notification %d: %w", m.ID, err))
+		}
+	}
+	return len(messages), errors.Join(errs...)
+}
+
+// claim postpones up to limit due messages by the claim timeout and returns them in order of their due time.
+func (repo *notificationOutboxDBRepository) claim(
+	ctx context.Context, now time.Time, limit int,
+) ([]*domain.OutboxMessage, error) {
+	query := `WITH due AS (
+				  SELECT id, next_attempt_time FROM notification_outbox
+				  WHERE sent_time IS NULL AND failed_time IS NULL AND next_attempt_time <= $1
+				  ORDER BY next_attempt_time, id LIMIT $2 FOR UPDATE SKIP LOCKED
+			  )
+			  UPDATE notification_outbox o SET next_attempt_time = $3 FROM due WHERE o.id = due.id
+			  RETURNING o.id, o.event_id, o.payload, o.notify_time, o.attempts, due.next_attempt_time, o.trace_context`
+	rows, err := db.QueryContext(ctx, query, now, limit, now.Add(outboxClaimTimeout))
+	if common.IsErr(err) {
+		return nil, err
+	}
+	defer rows.Close()
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		var (
+			m            domain.OutboxMessage
+			payload      []byte
+			traceContext sql.NullString
+		)
+		err := rows.Scan(&m.ID, &m.EventID, &payload, &m.NotifyTime, &m.Attempts, &m.NextAttemptTime, &traceContext)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &m.Notifications); common.IsErr(err) {
+			return nil, err
+		}
+		if traceContext.Valid {
+			if err := json.Unmarshal([]byte(traceContext.String), &m.TraceContext); common.IsErr(err) {
+				return nil, err
+			}
+		}
+		messages = append(messages, &m)
+	}
+	// RETURNING doesn't keep the order of the claimed messages.
+	sort.Slice(messages, func(i, j int) bool {
+		if messages[i].NextAttemptTime.Equal(messages[j].NextAttemptTime) {
+			return messages[i].ID < messages[j].ID
+		}
+		return messages[i].NextAttemptTime.Before(messages[j].NextAttemptTime)
+	})
+	return messages, rows.Err()
+}
+
+// record stores the result of the processed message and enqueues the next notification after a sent one
+// or a given up one, so a failed occurrence doesn't stop reminders of the series.
+func (repo *notificationOutboxDBRepository) record(ctx context.Context, m *domain.OutboxMessage) error {
+	return inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := repo.save(ctx, tx, m); common.IsErr(err) {
+			return err
+		}
+		if m.SentTime == nil && m.FailedTime == nil {
+			return nil
+		}
+		return repo.enqueueNext(ctx, tx, m)
+	})
+}
+
+func (repo *notificationOutboxDBRepository) save(ctx context.Context, tx *sqlx.Tx, m *domain.OutboxMessage) error {
+	query := `UPDATE notification_outbox SET (attempts, next_attempt_time, last_error, sent_time, failed_time)
+			  = ($1, $2, $3, $4, $5) WHERE id = $6`
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		m.Attempts,
+		m.NextAttemptTime,
+		sql.NullString{String: m.LastError, Valid: m.LastError != ""},
+		m.SentTime,
+		m.FailedTime,
+		m.ID,
+	)
+	return err
+}
+
+// enqueueNext enqueues the notification of the recurring event following the completed one by the same reminder,
+// it continues the trace of the completed one.
+func (repo *notificationOutboxDBRepository) enqueueNext(
+	ctx context.Context, tx *sqlx.Tx, m *domain.OutboxMessage,
+) error {
+	e, err := scanEvent(tx.QueryRowContext(
+		ctx, `SELECT `+eventFields+` FROM event WHERE id = $1 AND deleted_time IS NULL`, m.EventID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if common.IsErr(err) {
+		return err
+	}
+	next := domain.NextOutboxMessage(e, m)
+	if next == nil {
+		return nil
+	}
+	return insertMessage(common.ContextWithTrace(ctx, m.TraceContext), tx, next)
+}
+
+// memoryOutbox is an in-memory notification outbox of the cache repository, sent messages are not kept.
+type memoryOutbox struct {
+	mu       sync.Mutex
+	lastID   int64
+	messages map[int64]*domain.OutboxMessage
+	claimed  map[int64]bool
+}
+
+func newMemoryOutbox() *memoryOutbox {
+	return &memoryOutbox{
+		messages: make(map[int64]*domain.OutboxMessage),
+		claimed:  make(map[int64]bool),
+	}
+}
+
+// enqueue adds the first notifications of each event reminder at or after the time.
+func (o *memoryOutbox) enqueue(ctx context.Context, e *domain.Event, after time.Time) {
+	for _, m := range domain.NewOutboxMessages(e, after) {
+		o.add(ctx, m)
+	}
+}
+
+// add adds the message with the trace context of the context span.
+func (o *memoryOutbox) add(ctx context.Context, m *domain.OutboxMessage) {
+	m.TraceContext = common.TraceCarrier(ctx)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.lastID++
+	m.ID = o.lastID
+	o.messages[m.ID] = m
+}
+
+// remove removes pending notifications of the event.
+func (o *memoryOutbox) remove(eventID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for id, m := range o.messages {
+		if m.EventID == eventID {
+			delete(o.messages, id)
+			delete(o.claimed, id)
+		}
+	}
+}
+
+// replace replaces pending event notifications.
+func (o *memoryOutbox) replace(ctx context.Context, e *domain.Event, after time.Time) {
+	o.remove(e.ID)
+	o.enqueue(ctx, e, after)
+}
+
+// clear removes all messages.
+func (o *memoryOutbox) clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = make(map[int64]*domain.OutboxMessage)
+	o.claimed = make(map[int64]bool)
+}
+
+// claim returns copies of up to limit due messages which are not claimed yet.
+func (o *memoryOutbox) claim(now time.Time, limit int) []*domain.OutboxMessage {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var due []*domain.OutboxMessage
+	for id, m := range o.messages {
+		if !o.claimed[id] && !m.NextAttemptTime.After(now) {
+			due = append(due, m)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].NextAttemptTime.Equal(due[j].NextAttemptTime) {
+			return due[i].ID < due[j].ID
+		}
+		return due[i].NextAttemptTime.Before(due[j].NextAttemptTime)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	result := make([]*domain.OutboxMessage, len(due))
+	for i, m := range due {
+		o.claimed[m.ID] = true
+		c := *m
+		result[i] = &c
+	}
+	return result
+}
+
+// complete stores the result of the claimed message, it reports whether the message was sent or given up
+// and was not removed while being processed.
+func (o *memoryOutbox) complete(m *domain.OutboxMessage) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.messages[m.ID]; !ok {
+		return false
+	}
+	delete(o.claimed, m.ID)
+	if m.SentTime != nil || m.FailedTime != nil {
+		delete(o.messages, m.ID)
+	} else {
+		o.messages[m.ID] = m
+	}
+	return m.SentTime != nil || m.FailedTime != nil
+}
+
+type notificationOutboxCacheRepository struct{}
+
+// NewNotificationOutboxCacheRepository returns a new instance of a notificationOutboxCacheRepository.
+func NewNotificationOutboxCacheRepository() domain.NotificationOutbox {
+	return &notificationOutboxCacheRepository{}
+}
+
+// ProcessNotifications processes due messages of the in-memory outbox.
+func (repo *notificationOutboxCacheRepository) ProcessNotifications(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	backoff domain.Backoff,
+	fn func(m *domain.OutboxMessage) error,
+) (int, error) {
+	messages := cacheOutbox.claim(now, limit)
+	for _, m := range messages {
+		if err := fn(m); common.IsErr(err) {
+			m.Fail(now, err, backoff)
+		} else {
+			m.SentTime = &now
+		}
+		if !cacheOutbox.complete(m) {
+			continue
+		}
+		data, err := cacheDB.Get([]byte(m.EventID))
+		if common.IsErr(err) {
+			continue
+		}
+		e := &domain.Event{}
+		if err := json.Unmarshal(data, e); common.IsErr(err) {
+			common.Logger.Error().Msgf("failed to enqueue next notification: %v", err)
+			continue
+		}
+		// Trashed events get no further notifications, as in the DB outbox.
+		if e.DeletedTime != nil {
+			continue
+		}
+		if next := domain.NextOutboxMessage(e, m); next != nil {
+			cacheOutbox.add(common.ContextWithTrace(ctx, m.TraceContext), next)
+		}
+	}
+	return len(messages), nil
+}
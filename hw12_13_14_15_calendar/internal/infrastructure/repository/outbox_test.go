@@ -0,0 +1,347 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/freecache"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	testBackoff   = domain.Backoff{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 2}
+	errPublish    = errors.New("publish failed")
+	outboxColumns = []string{
+		"id", "event_id", "payload", "notify_time", "attempts", "next_attempt_time", "trace_context",
+	}
+)
+
+type outboxMockSQLTestSuite struct {
+	suite.Suite
+	repo domain.NotificationOutbox
+	mock sqlmock.Sqlmock
+}
+
+func (s *outboxMockSQLTestSuite) SetupSuite() {
+	s.repo = NewNotificationOutboxDBRepository()
+}
+
+func (s *outboxMockSQLTestSuite) SetupTest() {
+	mockDB, mock, err := sqlmock.New()
+	if common.IsErr(err) {
+		panic("An error was not expected when opening a stub database connection")
+	}
+	s.mock = mock
+	db = sqlx.NewDb(mockDB, "sqlmock")
+}
+
+func (s *outboxMockSQLTestSuite) outboxRow(id int64, e *domain.Event) []driver.Value {
+	payload, err := json.Marshal(domain.NewOutboxMessages(e, e.StartTime)[0].Notifications)
+	s.NoError(err)
+	return []driver.Value{id, e.ID, payload, reminderTime(e), 0, reminderTime(e), nil}
+}
+
+// expectClaim expects the claim of the messages at now.
+func (s *outboxMockSQLTestSuite) expectClaim(now time.Time, rows *sqlmock.Rows) {
+	s.mock.ExpectQuery("^WITH due AS (.+) FOR UPDATE SKIP LOCKED (.+) UPDATE notification_outbox (.+) RETURNING (.+)$").
+		WithArgs(now, 10, now.Add(outboxClaimTimeout)).
+		WillReturnRows(rows)
+}
+
+func (s *outboxMockSQLTestSuite) TestProcessNotifications() {
+	now := time.Now().UTC()
+	single := withNotification(tests.GenerateTestEvent())
+	recurring := withNotification(tests.GenerateTestEvent())
+	recurring.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1}
+	failed := withNotification(tests.GenerateTestEvent())
+
+	// Messages are processed in order of their due time whatever order they are returned in.
+	s.expectClaim(now, sqlmock.NewRows(outboxColumns).
+		AddRow(s.outboxRow(3, failed)...).
+		AddRow(s.outboxRow(2, recurring)...).
+		AddRow(s.outboxRow(1, single)...),
+	)
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("^UPDATE notification_outbox SET (.+) WHERE id = \\$6$").
+		WithArgs(0, reminderTime(single), nil, now, nil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
+		WithArgs(single.ID).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(single)...))
+	s.mock.ExpectCommit()
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("^UPDATE notification_outbox SET (.+) WHERE id = \\$6$").
+		WithArgs(0, reminderTime(recurring), nil, now, nil, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
+		WithArgs(recurring.ID).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(recurring)...))
+	nextNotifyTime := reminderTime(recurring).AddDate(0, 0, 1)
+	s.mock.ExpectExec("^INSERT INTO notification_outbox (.+) VALUES (.+)$").
+		WithArgs(recurring.ID, sqlmock.AnyArg(), nextNotifyTime, nextNotifyTime, nil).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	s.mock.ExpectCommit()
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("^UPDATE notification_outbox SET (.+) WHERE id = \\$6$").
+		WithArgs(1, now.Add(time.Minute), errPublish.Error(), nil, nil, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	var published []string
+	count, err := s.repo.ProcessNotifications(
+		context.Background(), now, 10, testBackoff, func(m *domain.OutboxMessage) error {
+			if m.EventID == failed.ID {
+				return errPublish
+			}
+			published = append(published, m.EventID)
+			return nil
+		},
+	)
+	s.NoError(err)
+	s.Equal(3, count)
+	s.Equal([]string{single.ID, recurring.ID}, published)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *outboxMockSQLTestSuite) TestProcessNotificationsRecordError() {
+	now := time.Now().UTC()
+	first := withNotification(tests.GenerateTestEvent())
+	second := withNotification(tests.GenerateTestEvent())
+	saveErr := errors.New("save failed")
+
+	s.expectClaim(now, sqlmock.NewRows(outboxColumns).
+		AddRow(s.outboxRow(1, first)...).
+		AddRow(s.outboxRow(2, second)...),
+	)
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("^UPDATE notification_outbox SET (.+) WHERE id = \\$6$").
+		WithArgs(0, reminderTime(first), nil, now, nil, 1).
+		WillReturnError(saveErr)
+	s.mock.ExpectRollback()
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("^UPDATE notification_outbox SET (.+) WHERE id = \\$6$").
+		WithArgs(0, reminderTime(second), nil, now, nil, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
+		WithArgs(second.ID).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(second)...))
+	s.mock.ExpectCommit()
+
+	// The failed record of the first message neither stops the batch nor rolls back the second one.
+	var published []string
+	count, err := s.repo.ProcessNotifications(
+		context.Background(), now, 10, testBackoff, func(m *domain.OutboxMessage) error {
+			published = append(published, m.EventID)
+			return nil
+		},
+	)
+	s.ErrorIs(err, saveErr)
+	s.Equal(2, count)
+	s.Equal([]string{first.ID, second.ID}, published)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *outboxMockSQLTestSuite) TestProcessNotificationsGivenUp() {
+	now := time.Now().UTC()
+	event := withNotification(tests.GenerateTestEvent())
+	event.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1}
+	row := s.outboxRow(1, event)
+	row[4] = testBackoff.MaxAttempts - 1
+
+	s.expectClaim(now, sqlmock.NewRows(outboxColumns).AddRow(row...))
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("^UPDATE notification_outbox SET (.+) WHERE id = \\$6$").
+		WithArgs(testBackoff.MaxAttempts, reminderTime(event), errPublish.Error(), nil, now, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("^SELECT (.+) FROM event WHERE id = \\$1 AND deleted_time IS NULL$").
+		WithArgs(event.ID).
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(eventRow(event)...))
+	// The given up occurrence doesn't stop notifications of the following ones.
+	nextNotifyTime := reminderTime(event).AddDate(0, 0, 1)
+	s.mock.ExpectExec("^INSERT INTO notification_outbox (.+) VALUES (.+)$").
+		WithArgs(event.ID, sqlmock.AnyArg(), nextNotifyTime, nextNotifyTime, nil).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	s.mock.ExpectCommit()
+
+	count, err := s.repo.ProcessNotifications(
+		context.Background(), now, 10, testBackoff, func(m *domain.OutboxMessage) error {
+			return errPublish
+		},
+	)
+	s.NoError(err)
+	s.Equal(1, count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *outboxMockSQLTestSuite) TestProcessNotificationsError() {
+	now := time.Now().UTC()
+	queryErr := errors.New("query failed")
+	s.mock.ExpectQuery("^WITH due AS (.+)$").WillReturnError(queryErr)
+	count, err := s.repo.ProcessNotifications(
+		context.Background(), now, 10, testBackoff, func(m *domain.OutboxMessage) error {
+			return nil
+		},
+	)
+	s.ErrorIs(err, queryErr)
+	s.Zero(count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func TestRunMockSQLOutboxSuite(t *testing.T) {
+	suite.Run(t, new(outboxMockSQLTestSuite))
+}
+
+type outboxCacheTestSuite struct {
+	suite.Suite
+	events domain.EventRepository
+	repo   domain.NotificationOutbox
+}
+
+func (s *outboxCacheTestSuite) SetupSuite() {
+	s.events = NewEventCacheRepository()
+	s.repo = NewNotificationOutboxCacheRepository()
+	cacheDB = freecache.NewCacheDB(1024 * 1024 * 100)
+}
+
+func (s *outboxCacheTestSuite) TearDownTest() {
+	cacheDB.Clear()
+	cacheOutbox.clear()
+}
+
+// process processes notifications due at now returning IDs of the published events.
+func (s *outboxCacheTestSuite) process(now time.Time, fail bool) []string {
+	var published []string
+	_, err := s.repo.ProcessNotifications(
+		context.Background(), now, 10, testBackoff, func(m *domain.OutboxMessage) error {
+			if fail {
+				return errPublish
+			}
+			published = append(published, m.EventID)
+			return nil
+		},
+	)
+	s.NoError(err)
+	return published
+}
+
+func (s *outboxCacheTestSuite) TestProcessNotifications() {
+	event := withNotification(tests.GenerateTestEvent())
+	s.NoError(s.events.Add(context.Background(), event))
+	noNotification := tests.GenerateTestEvent()
+	s.NoError(s.events.Add(context.Background(), noNotification))
+
+	s.Empty(s.process(reminderTime(event).Add(-time.Second), false))
+	s.Equal([]string{event.ID}, s.process(reminderTime(event), false))
+	s.Empty(s.process(reminderTime(event).Add(time.Hour), false))
+}
+
+func (s *outboxCacheTestSuite) TestProcessRecurringNotifications() {
+	event := withNotification(tests.GenerateTestEvent())
+	event.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1, Count: 2}
+	s.NoError(s.events.Add(context.Background(), event))
+
+	s.Equal([]string{event.ID}, s.process(reminderTime(event), false))
+	s.Empty(s.process(reminderTime(event).Add(time.Hour), false))
+	s.Equal([]string{event.ID}, s.process(reminderTime(event).AddDate(0, 0, 1), false))
+	s.Empty(s.process(reminderTime(event).AddDate(0, 0, 7), false))
+}
+
+func (s *outboxCacheTestSuite) TestProcessNotificationsOfDeletedEvent() {
+	event := withNotification(tests.GenerateTestEvent())
+	event.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1}
+	s.NoError(s.events.Add(context.Background(), event))
+	// The event is moved to the trash while its notification is already claimed.
+	deletedTime := time.Now().UTC()
+	event.DeletedTime = &deletedTime
+	data, err := json.Marshal(event)
+	s.NoError(err)
+	s.NoError(cacheDB.Set([]byte(event.ID), data, 0))
+
+	s.Equal([]string{event.ID}, s.process(reminderTime(event), false))
+	s.Empty(s.process(reminderTime(event).AddDate(0, 0, 1), false))
+}
+
+func (s *outboxCacheTestSuite) TestProcessMultipleReminders() {
+	event := tests.GenerateTestEvent()
+	event.Reminders = []*domain.Reminder{{Offset: time.Hour}, {Offset: time.Minute}}
+	event.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1, Count: 2}
+	s.NoError(s.events.Add(context.Background(), event))
+
+	s.Equal([]string{event.ID}, s.process(event.StartTime.Add(time.Minute), false))
+	s.Equal([]string{event.ID}, s.process(event.StartTime.Add(time.Hour), false))
+	s.Equal(
+		[]string{event.ID, event.ID},
+		s.process(event.StartTime.AddDate(0, 0, 1).Add(time.Hour), false),
+	)
+	s.Empty(s.process(event.StartTime.AddDate(0, 0, 7), false))
+}
+
+func (s *outboxCacheTestSuite) TestRetryNotifications() {
+	event := withNotification(tests.GenerateTestEvent())
+	s.NoError(s.events.Add(context.Background(), event))
+
+	s.Empty(s.process(reminderTime(event), true))
+	s.Empty(s.process(reminderTime(event).Add(time.Second), false))
+	s.Equal([]string{event.ID}, s.process(reminderTime(event).Add(time.Minute), false))
+
+	event = withNotification(tests.GenerateTestEvent())
+	s.NoError(s.events.Add(context.Background(), event))
+	s.Empty(s.process(reminderTime(event), true))
+	s.Empty(s.process(reminderTime(event).Add(time.Minute), true))
+	// The message is given up after the last attempt.
+	s.Empty(s.process(reminderTime(event).Add(time.Hour), false))
+}
+
+func (s *outboxCacheTestSuite) TestRetryRecurringNotifications() {
+	event := withNotification(tests.GenerateTestEvent())
+	event.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1}
+	s.NoError(s.events.Add(context.Background(), event))
+
+	s.Empty(s.process(reminderTime(event), true))
+	s.Empty(s.process(reminderTime(event).Add(time.Minute), true))
+	// The next occurrence is notified after the previous one is given up.
+	s.Equal([]string{event.ID}, s.process(reminderTime(event).AddDate(0, 0, 1), false))
+}
+
+func (s *outboxCacheTestSuite) TestUpdateAndDeleteEvent() {
+	event := withNotification(tests.GenerateTestEvent())
+	s.NoError(s.events.Add(context.Background(), event))
+	event.Reminders[0].Offset += time.Hour
+	notifyTime := reminderTime(event)
+	s.NoError(s.events.Update(context.Background(), event.UserID, event))
+
+	s.Empty(s.process(notifyTime.Add(-time.Second), false))
+	s.Equal([]string{event.ID}, s.process(notifyTime, false))
+
+	event = withNotification(tests.GenerateTestEvent())
+	s.NoError(s.events.Add(context.Background(), event))
+	s.NoError(s.events.Delete(context.Background(), event.UserID, event.ID))
+	s.Empty(s.process(reminderTime(event), false))
+}
+
+func (s *outboxCacheTestSuite) TestRecentlyDueNotification() {
+	event := tests.GenerateTestEvent()
+	event.StartTime = time.Now().Add(-2 * time.Second)
+	event.Reminders = []*domain.Reminder{{Offset: time.Second}}
+	s.NoError(s.events.Add(context.Background(), event))
+	s.Equal([]string{event.ID}, s.process(time.Now(), false))
+
+	event = tests.GenerateTestEvent()
+	event.StartTime = time.Now().Add(-time.Hour)
+	event.Reminders = []*domain.Reminder{{}}
+	s.NoError(s.events.Add(context.Background(), event))
+	s.Empty(s.process(time.Now(), false))
+}
+
+func TestRunCacheOutboxSuite(t *testing.T) {
+	suite.Run(t, new(outboxCacheTestSuite))
+}
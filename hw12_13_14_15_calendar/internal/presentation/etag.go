@@ -0,0 +1,37 @@
+package presentation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// IfMatchHeader is a request metadata key (HTTP header) with the ETag of the event version expected by an update.
+	IfMatchHeader = "if-match"
+	// ETagHeader is a response metadata key (HTTP header) with the ETag of the returned event version.
+	ETagHeader = "etag"
+	// VersionConflictReason is a reason of the error details of an update of another event version.
+	VersionConflictReason = "EVENT_VERSION_CONFLICT"
+)
+
+// ErrETag is returned for a malformed ETag.
+var ErrETag = errors.New("invalid " + IfMatchHeader + " ETag")
+
+// FormatETag returns the ETag of the event version.
+func FormatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseETag parses the event version of the ETag, weak ETags are accepted.
+func ParseETag(etag string) (int64, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, ErrETag
+	}
+	version, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrETag
+	}
+	return version, nil
+}
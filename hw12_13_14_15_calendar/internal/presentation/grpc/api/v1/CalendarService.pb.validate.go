@@ -0,0 +1,2238 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: api/v1/CalendarService.proto
+
+package pb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// define the regex for a UUID once up-front
+var _calendar_service_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on Calendar with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Calendar) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Calendar with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CalendarMultiError, or nil
+// if none found.
+func (m *Calendar) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Calendar) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 255 {
+		err := CalendarValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetColor() != "" {
+
+		if !_Calendar_Color_Pattern.MatchString(m.GetColor()) {
+			err := CalendarValidationError{
+				field:  "Color",
+				reason: "value does not match regex pattern \"^#[0-9a-fA-F]{6}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for TimeZone
+
+	// no validation rules for UserId
+
+	// no validation rules for IsDefault
+
+	if all {
+		switch v := interface{}(m.GetCreatedTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CalendarValidationError{
+					field:  "CreatedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CalendarValidationError{
+					field:  "CreatedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CalendarValidationError{
+				field:  "CreatedTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Access
+
+	if len(errors) > 0 {
+		return CalendarMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarMultiError is an error wrapping multiple validation errors returned
+// by Calendar.ValidateAll() if the designated constraints aren't met.
+type CalendarMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarMultiError) AllErrors() []error { return m }
+
+// CalendarValidationError is the validation error returned by
+// Calendar.Validate if the designated constraints aren't met.
+type CalendarValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarValidationError) ErrorName() string { return "CalendarValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CalendarValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendar.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarValidationError{}
+
+var _Calendar_Color_Pattern = regexp.MustCompile("^#[0-9a-fA-F]{6}$")
+
+// Validate checks the field values on CalendarRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CalendarRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CalendarRequestMultiError, or nil if none found.
+func (m *CalendarRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetCalendar() == nil {
+		err := CalendarRequestValidationError{
+			field:  "Calendar",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetCalendar()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CalendarRequestValidationError{
+					field:  "Calendar",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CalendarRequestValidationError{
+					field:  "Calendar",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCalendar()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CalendarRequestValidationError{
+				field:  "Calendar",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CalendarRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarRequestMultiError is an error wrapping multiple validation errors
+// returned by CalendarRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarRequestMultiError) AllErrors() []error { return m }
+
+// CalendarRequestValidationError is the validation error returned by
+// CalendarRequest.Validate if the designated constraints aren't met.
+type CalendarRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarRequestValidationError) ErrorName() string { return "CalendarRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CalendarRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarRequestValidationError{}
+
+// Validate checks the field values on CalendarIDRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CalendarIDRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarIDRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CalendarIDRequestMultiError, or nil if none found.
+func (m *CalendarIDRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarIDRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = CalendarIDRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CalendarIDRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *CalendarIDRequest) _validateUuid(uuid string) error {
+	if matched := _calendar_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// CalendarIDRequestMultiError is an error wrapping multiple validation errors
+// returned by CalendarIDRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarIDRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarIDRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarIDRequestMultiError) AllErrors() []error { return m }
+
+// CalendarIDRequestValidationError is the validation error returned by
+// CalendarIDRequest.Validate if the designated constraints aren't met.
+type CalendarIDRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarIDRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarIDRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarIDRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarIDRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarIDRequestValidationError) ErrorName() string {
+	return "CalendarIDRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CalendarIDRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarIDRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarIDRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarIDRequestValidationError{}
+
+// Validate checks the field values on CalendarResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CalendarResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CalendarResponseMultiError, or nil if none found.
+func (m *CalendarResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCalendar()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CalendarResponseValidationError{
+					field:  "Calendar",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CalendarResponseValidationError{
+					field:  "Calendar",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCalendar()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CalendarResponseValidationError{
+				field:  "Calendar",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CalendarResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarResponseMultiError is an error wrapping multiple validation errors
+// returned by CalendarResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarResponseMultiError) AllErrors() []error { return m }
+
+// CalendarResponseValidationError is the validation error returned by
+// CalendarResponse.Validate if the designated constraints aren't met.
+type CalendarResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarResponseValidationError) ErrorName() string { return "CalendarResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CalendarResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarResponseValidationError{}
+
+// Validate checks the field values on CalendarsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CalendarsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CalendarsResponseMultiError, or nil if none found.
+func (m *CalendarsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetCalendars() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CalendarsResponseValidationError{
+						field:  fmt.Sprintf("Calendars[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CalendarsResponseValidationError{
+						field:  fmt.Sprintf("Calendars[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CalendarsResponseValidationError{
+					field:  fmt.Sprintf("Calendars[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CalendarsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarsResponseMultiError is an error wrapping multiple validation errors
+// returned by CalendarsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarsResponseMultiError) AllErrors() []error { return m }
+
+// CalendarsResponseValidationError is the validation error returned by
+// CalendarsResponse.Validate if the designated constraints aren't met.
+type CalendarsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarsResponseValidationError) ErrorName() string {
+	return "CalendarsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CalendarsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarsResponseValidationError{}
+
+// Validate checks the field values on CalendarShare with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CalendarShare) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarShare with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CalendarShareMultiError, or
+// nil if none found.
+func (m *CalendarShare) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarShare) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Access
+
+	if len(errors) > 0 {
+		return CalendarShareMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarShareMultiError is an error wrapping multiple validation errors
+// returned by CalendarShare.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarShareMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarShareMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarShareMultiError) AllErrors() []error { return m }
+
+// CalendarShareValidationError is the validation error returned by
+// CalendarShare.Validate if the designated constraints aren't met.
+type CalendarShareValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarShareValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarShareValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarShareValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarShareValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarShareValidationError) ErrorName() string { return "CalendarShareValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CalendarShareValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarShare.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarShareValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarShareValidationError{}
+
+// Validate checks the field values on ShareCalendarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ShareCalendarRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ShareCalendarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ShareCalendarRequestMultiError, or nil if none found.
+func (m *ShareCalendarRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ShareCalendarRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = ShareCalendarRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUserId() <= 0 {
+		err := ShareCalendarRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _ShareCalendarRequest_Access_InLookup[m.GetAccess()]; !ok {
+		err := ShareCalendarRequestValidationError{
+			field:  "Access",
+			reason: "value must be in list [CALENDAR_ACCESS_FREE_BUSY CALENDAR_ACCESS_READ CALENDAR_ACCESS_WRITE]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ShareCalendarRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ShareCalendarRequest) _validateUuid(uuid string) error {
+	if matched := _calendar_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ShareCalendarRequestMultiError is an error wrapping multiple validation
+// errors returned by ShareCalendarRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ShareCalendarRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ShareCalendarRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ShareCalendarRequestMultiError) AllErrors() []error { return m }
+
+// ShareCalendarRequestValidationError is the validation error returned by
+// ShareCalendarRequest.Validate if the designated constraints aren't met.
+type ShareCalendarRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ShareCalendarRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ShareCalendarRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ShareCalendarRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ShareCalendarRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ShareCalendarRequestValidationError) ErrorName() string {
+	return "ShareCalendarRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ShareCalendarRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sShareCalendarRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ShareCalendarRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ShareCalendarRequestValidationError{}
+
+var _ShareCalendarRequest_Access_InLookup = map[CalendarAccess]struct{}{
+	1: {},
+	2: {},
+	3: {},
+}
+
+// Validate checks the field values on UnshareCalendarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UnshareCalendarRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnshareCalendarRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnshareCalendarRequestMultiError, or nil if none found.
+func (m *UnshareCalendarRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnshareCalendarRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = UnshareCalendarRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUserId() <= 0 {
+		err := UnshareCalendarRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UnshareCalendarRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *UnshareCalendarRequest) _validateUuid(uuid string) error {
+	if matched := _calendar_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// UnshareCalendarRequestMultiError is an error wrapping multiple validation
+// errors returned by UnshareCalendarRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UnshareCalendarRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnshareCalendarRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnshareCalendarRequestMultiError) AllErrors() []error { return m }
+
+// UnshareCalendarRequestValidationError is the validation error returned by
+// UnshareCalendarRequest.Validate if the designated constraints aren't met.
+type UnshareCalendarRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnshareCalendarRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnshareCalendarRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnshareCalendarRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnshareCalendarRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnshareCalendarRequestValidationError) ErrorName() string {
+	return "UnshareCalendarRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnshareCalendarRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnshareCalendarRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnshareCalendarRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnshareCalendarRequestValidationError{}
+
+// Validate checks the field values on CalendarSharesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CalendarSharesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarSharesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CalendarSharesResponseMultiError, or nil if none found.
+func (m *CalendarSharesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarSharesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetShares() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CalendarSharesResponseValidationError{
+						field:  fmt.Sprintf("Shares[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CalendarSharesResponseValidationError{
+						field:  fmt.Sprintf("Shares[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CalendarSharesResponseValidationError{
+					field:  fmt.Sprintf("Shares[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CalendarSharesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarSharesResponseMultiError is an error wrapping multiple validation
+// errors returned by CalendarSharesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CalendarSharesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarSharesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarSharesResponseMultiError) AllErrors() []error { return m }
+
+// CalendarSharesResponseValidationError is the validation error returned by
+// CalendarSharesResponse.Validate if the designated constraints aren't met.
+type CalendarSharesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarSharesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarSharesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarSharesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarSharesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarSharesResponseValidationError) ErrorName() string {
+	return "CalendarSharesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CalendarSharesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarSharesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarSharesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarSharesResponseValidationError{}
+
+// Validate checks the field values on TimePeriod with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TimePeriod) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TimePeriod with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TimePeriodMultiError, or
+// nil if none found.
+func (m *TimePeriod) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TimePeriod) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetStartTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TimePeriodValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TimePeriodValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TimePeriodValidationError{
+				field:  "StartTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TimePeriodValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TimePeriodValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TimePeriodValidationError{
+				field:  "EndTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return TimePeriodMultiError(errors)
+	}
+
+	return nil
+}
+
+// TimePeriodMultiError is an error wrapping multiple validation errors
+// returned by TimePeriod.ValidateAll() if the designated constraints aren't met.
+type TimePeriodMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TimePeriodMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TimePeriodMultiError) AllErrors() []error { return m }
+
+// TimePeriodValidationError is the validation error returned by
+// TimePeriod.Validate if the designated constraints aren't met.
+type TimePeriodValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TimePeriodValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TimePeriodValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TimePeriodValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TimePeriodValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TimePeriodValidationError) ErrorName() string { return "TimePeriodValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TimePeriodValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTimePeriod.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TimePeriodValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TimePeriodValidationError{}
+
+// Validate checks the field values on FreeBusyRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *FreeBusyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FreeBusyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FreeBusyRequestMultiError, or nil if none found.
+func (m *FreeBusyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FreeBusyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetUserIds()); l < 1 || l > 20 {
+		err := FreeBusyRequestValidationError{
+			field:  "UserIds",
+			reason: "value must contain between 1 and 20 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_FreeBusyRequest_UserIds_Unique := make(map[int64]struct{}, len(m.GetUserIds()))
+
+	for idx, item := range m.GetUserIds() {
+		_, _ = idx, item
+
+		if _, exists := _FreeBusyRequest_UserIds_Unique[item]; exists {
+			err := FreeBusyRequestValidationError{
+				field:  fmt.Sprintf("UserIds[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_FreeBusyRequest_UserIds_Unique[item] = struct{}{}
+		}
+
+		if item <= 0 {
+			err := FreeBusyRequestValidationError{
+				field:  fmt.Sprintf("UserIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetStartTime() == nil {
+		err := FreeBusyRequestValidationError{
+			field:  "StartTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEndTime() == nil {
+		err := FreeBusyRequestValidationError{
+			field:  "EndTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return FreeBusyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// FreeBusyRequestMultiError is an error wrapping multiple validation errors
+// returned by FreeBusyRequest.ValidateAll() if the designated constraints
+// aren't met.
+type FreeBusyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FreeBusyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FreeBusyRequestMultiError) AllErrors() []error { return m }
+
+// FreeBusyRequestValidationError is the validation error returned by
+// FreeBusyRequest.Validate if the designated constraints aren't met.
+type FreeBusyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FreeBusyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FreeBusyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FreeBusyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FreeBusyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FreeBusyRequestValidationError) ErrorName() string { return "FreeBusyRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FreeBusyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFreeBusyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FreeBusyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FreeBusyRequestValidationError{}
+
+// Validate checks the field values on UserFreeBusy with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserFreeBusy) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserFreeBusy with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserFreeBusyMultiError, or
+// nil if none found.
+func (m *UserFreeBusy) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserFreeBusy) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	for idx, item := range m.GetBusy() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserFreeBusyValidationError{
+						field:  fmt.Sprintf("Busy[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserFreeBusyValidationError{
+						field:  fmt.Sprintf("Busy[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserFreeBusyValidationError{
+					field:  fmt.Sprintf("Busy[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserFreeBusyMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserFreeBusyMultiError is an error wrapping multiple validation errors
+// returned by UserFreeBusy.ValidateAll() if the designated constraints aren't met.
+type UserFreeBusyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserFreeBusyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserFreeBusyMultiError) AllErrors() []error { return m }
+
+// UserFreeBusyValidationError is the validation error returned by
+// UserFreeBusy.Validate if the designated constraints aren't met.
+type UserFreeBusyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserFreeBusyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserFreeBusyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserFreeBusyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserFreeBusyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserFreeBusyValidationError) ErrorName() string { return "UserFreeBusyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserFreeBusyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserFreeBusy.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserFreeBusyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserFreeBusyValidationError{}
+
+// Validate checks the field values on FreeBusyResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *FreeBusyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FreeBusyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FreeBusyResponseMultiError, or nil if none found.
+func (m *FreeBusyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FreeBusyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FreeBusyResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FreeBusyResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FreeBusyResponseValidationError{
+					field:  fmt.Sprintf("Users[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return FreeBusyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// FreeBusyResponseMultiError is an error wrapping multiple validation errors
+// returned by FreeBusyResponse.ValidateAll() if the designated constraints
+// aren't met.
+type FreeBusyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FreeBusyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FreeBusyResponseMultiError) AllErrors() []error { return m }
+
+// FreeBusyResponseValidationError is the validation error returned by
+// FreeBusyResponse.Validate if the designated constraints aren't met.
+type FreeBusyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FreeBusyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FreeBusyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FreeBusyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FreeBusyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FreeBusyResponseValidationError) ErrorName() string { return "FreeBusyResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FreeBusyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFreeBusyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FreeBusyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FreeBusyResponseValidationError{}
+
+// Validate checks the field values on MeetingSlotsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *MeetingSlotsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MeetingSlotsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MeetingSlotsRequestMultiError, or nil if none found.
+func (m *MeetingSlotsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MeetingSlotsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetUserIds()) > 20 {
+		err := MeetingSlotsRequestValidationError{
+			field:  "UserIds",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_MeetingSlotsRequest_UserIds_Unique := make(map[int64]struct{}, len(m.GetUserIds()))
+
+	for idx, item := range m.GetUserIds() {
+		_, _ = idx, item
+
+		if _, exists := _MeetingSlotsRequest_UserIds_Unique[item]; exists {
+			err := MeetingSlotsRequestValidationError{
+				field:  fmt.Sprintf("UserIds[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_MeetingSlotsRequest_UserIds_Unique[item] = struct{}{}
+		}
+
+		if item <= 0 {
+			err := MeetingSlotsRequestValidationError{
+				field:  fmt.Sprintf("UserIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetStartTime() == nil {
+		err := MeetingSlotsRequestValidationError{
+			field:  "StartTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEndTime() == nil {
+		err := MeetingSlotsRequestValidationError{
+			field:  "EndTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetDuration() == nil {
+		err := MeetingSlotsRequestValidationError{
+			field:  "Duration",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetDuration(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = MeetingSlotsRequestValidationError{
+				field:  "Duration",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			lte := time.Duration(86400*time.Second + 0*time.Nanosecond)
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt || dur > lte {
+				err := MeetingSlotsRequestValidationError{
+					field:  "Duration",
+					reason: "value must be inside range (0s, 24h0m0s]",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if val := m.GetSlotCount(); val < 0 || val > 50 {
+		err := MeetingSlotsRequestValidationError{
+			field:  "SlotCount",
+			reason: "value must be inside range [0, 50]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetWorkingHoursStart() != "" {
+
+		if !_MeetingSlotsRequest_WorkingHoursStart_Pattern.MatchString(m.GetWorkingHoursStart()) {
+			err := MeetingSlotsRequestValidationError{
+				field:  "WorkingHoursStart",
+				reason: "value does not match regex pattern \"^([01][0-9]|2[0-3]):[0-5][0-9]$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetWorkingHoursEnd() != "" {
+
+		if !_MeetingSlotsRequest_WorkingHoursEnd_Pattern.MatchString(m.GetWorkingHoursEnd()) {
+			err := MeetingSlotsRequestValidationError{
+				field:  "WorkingHoursEnd",
+				reason: "value does not match regex pattern \"^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for TimeZone
+
+	// no validation rules for IncludeWeekends
+
+	if len(errors) > 0 {
+		return MeetingSlotsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// MeetingSlotsRequestMultiError is an error wrapping multiple validation
+// errors returned by MeetingSlotsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type MeetingSlotsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MeetingSlotsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MeetingSlotsRequestMultiError) AllErrors() []error { return m }
+
+// MeetingSlotsRequestValidationError is the validation error returned by
+// MeetingSlotsRequest.Validate if the designated constraints aren't met.
+type MeetingSlotsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MeetingSlotsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MeetingSlotsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MeetingSlotsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MeetingSlotsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MeetingSlotsRequestValidationError) ErrorName() string {
+	return "MeetingSlotsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MeetingSlotsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMeetingSlotsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MeetingSlotsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MeetingSlotsRequestValidationError{}
+
+var _MeetingSlotsRequest_WorkingHoursStart_Pattern = regexp.MustCompile("^([01][0-9]|2[0-3]):[0-5][0-9]$")
+
+var _MeetingSlotsRequest_WorkingHoursEnd_Pattern = regexp.MustCompile("^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$")
+
+// Validate checks the field values on MeetingSlotsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *MeetingSlotsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MeetingSlotsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MeetingSlotsResponseMultiError, or nil if none found.
+func (m *MeetingSlotsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MeetingSlotsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetSlots() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MeetingSlotsResponseValidationError{
+						field:  fmt.Sprintf("Slots[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MeetingSlotsResponseValidationError{
+						field:  fmt.Sprintf("Slots[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MeetingSlotsResponseValidationError{
+					field:  fmt.Sprintf("Slots[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return MeetingSlotsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// MeetingSlotsResponseMultiError is an error wrapping multiple validation
+// errors returned by MeetingSlotsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type MeetingSlotsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MeetingSlotsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MeetingSlotsResponseMultiError) AllErrors() []error { return m }
+
+// MeetingSlotsResponseValidationError is the validation error returned by
+// MeetingSlotsResponse.Validate if the designated constraints aren't met.
+type MeetingSlotsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MeetingSlotsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MeetingSlotsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MeetingSlotsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MeetingSlotsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MeetingSlotsResponseValidationError) ErrorName() string {
+	return "MeetingSlotsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MeetingSlotsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMeetingSlotsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MeetingSlotsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MeetingSlotsResponseValidationError{}
@@ -0,0 +1,465 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.2
+// source: api/v1/CalendarService.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CalendarServiceV1_CreateCalendar_FullMethodName     = "/event.CalendarServiceV1/CreateCalendar"
+	CalendarServiceV1_GetCalendar_FullMethodName        = "/event.CalendarServiceV1/GetCalendar"
+	CalendarServiceV1_ListCalendars_FullMethodName      = "/event.CalendarServiceV1/ListCalendars"
+	CalendarServiceV1_UpdateCalendar_FullMethodName     = "/event.CalendarServiceV1/UpdateCalendar"
+	CalendarServiceV1_DeleteCalendar_FullMethodName     = "/event.CalendarServiceV1/DeleteCalendar"
+	CalendarServiceV1_ShareCalendar_FullMethodName      = "/event.CalendarServiceV1/ShareCalendar"
+	CalendarServiceV1_UnshareCalendar_FullMethodName    = "/event.CalendarServiceV1/UnshareCalendar"
+	CalendarServiceV1_ListCalendarShares_FullMethodName = "/event.CalendarServiceV1/ListCalendarShares"
+	CalendarServiceV1_QueryFreeBusy_FullMethodName      = "/event.CalendarServiceV1/QueryFreeBusy"
+	CalendarServiceV1_FindMeetingSlots_FullMethodName   = "/event.CalendarServiceV1/FindMeetingSlots"
+)
+
+// CalendarServiceV1Client is the client API for CalendarServiceV1 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CalendarServiceV1Client interface {
+	CreateCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error)
+	GetCalendar(ctx context.Context, in *CalendarIDRequest, opts ...grpc.CallOption) (*CalendarResponse, error)
+	// Lists calendars of the current user and calendars shared with the user ordered by name,
+	// the default calendar of the user is created on the first call.
+	ListCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CalendarsResponse, error)
+	// Updates the name, the color and the time zone of the calendar of the current user.
+	UpdateCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error)
+	// Deletes the calendar of the current user, calendars with events and default calendars can't be deleted.
+	DeleteCalendar(ctx context.Context, in *CalendarIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Grants the access to the calendar of the current user to the user replacing the previous one,
+	// readers and writers see the calendar events in their listings.
+	ShareCalendar(ctx context.Context, in *ShareCalendarRequest, opts ...grpc.CallOption) (*CalendarSharesResponse, error)
+	// Revokes the access of the user to the calendar of the current user.
+	UnshareCalendar(ctx context.Context, in *UnshareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCalendarShares(ctx context.Context, in *CalendarIDRequest, opts ...grpc.CallOption) (*CalendarSharesResponse, error)
+	// Returns busy periods of the users without event details, only events of calendars the current user
+	// owns or has any access to are taken into account.
+	QueryFreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error)
+	// Proposes free slots of the duration within working hours for a meeting of the current user and the users,
+	// busy periods are taken into account as in QueryFreeBusy.
+	FindMeetingSlots(ctx context.Context, in *MeetingSlotsRequest, opts ...grpc.CallOption) (*MeetingSlotsResponse, error)
+}
+
+type calendarServiceV1Client struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalendarServiceV1Client(cc grpc.ClientConnInterface) CalendarServiceV1Client {
+	return &calendarServiceV1Client{cc}
+}
+
+func (c *calendarServiceV1Client) CreateCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error) {
+	out := new(CalendarResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_CreateCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) GetCalendar(ctx context.Context, in *CalendarIDRequest, opts ...grpc.CallOption) (*CalendarResponse, error) {
+	out := new(CalendarResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_GetCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) ListCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CalendarsResponse, error) {
+	out := new(CalendarsResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_ListCalendars_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) UpdateCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error) {
+	out := new(CalendarResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_UpdateCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) DeleteCalendar(ctx context.Context, in *CalendarIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_DeleteCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) ShareCalendar(ctx context.Context, in *ShareCalendarRequest, opts ...grpc.CallOption) (*CalendarSharesResponse, error) {
+	out := new(CalendarSharesResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_ShareCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) UnshareCalendar(ctx context.Context, in *UnshareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_UnshareCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) ListCalendarShares(ctx context.Context, in *CalendarIDRequest, opts ...grpc.CallOption) (*CalendarSharesResponse, error) {
+	out := new(CalendarSharesResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_ListCalendarShares_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) QueryFreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error) {
+	out := new(FreeBusyResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_QueryFreeBusy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV1Client) FindMeetingSlots(ctx context.Context, in *MeetingSlotsRequest, opts ...grpc.CallOption) (*MeetingSlotsResponse, error) {
+	out := new(MeetingSlotsResponse)
+	err := c.cc.Invoke(ctx, CalendarServiceV1_FindMeetingSlots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceV1Server is the server API for CalendarServiceV1 service.
+// All implementations must embed UnimplementedCalendarServiceV1Server
+// for forward compatibility
+type CalendarServiceV1Server interface {
+	CreateCalendar(context.Context, *CalendarRequest) (*CalendarResponse, error)
+	GetCalendar(context.Context, *CalendarIDRequest) (*CalendarResponse, error)
+	// Lists calendars of the current user and calendars shared with the user ordered by name,
+	// the default calendar of the user is created on the first call.
+	ListCalendars(context.Context, *emptypb.Empty) (*CalendarsResponse, error)
+	// Updates the name, the color and the time zone of the calendar of the current user.
+	UpdateCalendar(context.Context, *CalendarRequest) (*CalendarResponse, error)
+	// Deletes the calendar of the current user, calendars with events and default calendars can't be deleted.
+	DeleteCalendar(context.Context, *CalendarIDRequest) (*emptypb.Empty, error)
+	// Grants the access to the calendar of the current user to the user replacing the previous one,
+	// readers and writers see the calendar events in their listings.
+	ShareCalendar(context.Context, *ShareCalendarRequest) (*CalendarSharesResponse, error)
+	// Revokes the access of the user to the calendar of the current user.
+	UnshareCalendar(context.Context, *UnshareCalendarRequest) (*emptypb.Empty, error)
+	ListCalendarShares(context.Context, *CalendarIDRequest) (*CalendarSharesResponse, error)
+	// Returns busy periods of the users without event details, only events of calendars the current user
+	// owns or has any access to are taken into account.
+	QueryFreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error)
+	// Proposes free slots of the duration within working hours for a meeting of the current user and the users,
+	// busy periods are taken into account as in QueryFreeBusy.
+	FindMeetingSlots(context.Context, *MeetingSlotsRequest) (*MeetingSlotsResponse, error)
+	mustEmbedUnimplementedCalendarServiceV1Server()
+}
+
+// UnimplementedCalendarServiceV1Server must be embedded to have forward compatible implementations.
+type UnimplementedCalendarServiceV1Server struct {
+}
+
+func (UnimplementedCalendarServiceV1Server) CreateCalendar(context.Context, *CalendarRequest) (*CalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCalendar not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) GetCalendar(context.Context, *CalendarIDRequest) (*CalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendar not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) ListCalendars(context.Context, *emptypb.Empty) (*CalendarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendars not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) UpdateCalendar(context.Context, *CalendarRequest) (*CalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCalendar not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) DeleteCalendar(context.Context, *CalendarIDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) ShareCalendar(context.Context, *ShareCalendarRequest) (*CalendarSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCalendar not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) UnshareCalendar(context.Context, *UnshareCalendarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareCalendar not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) ListCalendarShares(context.Context, *CalendarIDRequest) (*CalendarSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendarShares not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) QueryFreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryFreeBusy not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) FindMeetingSlots(context.Context, *MeetingSlotsRequest) (*MeetingSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMeetingSlots not implemented")
+}
+func (UnimplementedCalendarServiceV1Server) mustEmbedUnimplementedCalendarServiceV1Server() {}
+
+// UnsafeCalendarServiceV1Server may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalendarServiceV1Server will
+// result in compilation errors.
+type UnsafeCalendarServiceV1Server interface {
+	mustEmbedUnimplementedCalendarServiceV1Server()
+}
+
+func RegisterCalendarServiceV1Server(s grpc.ServiceRegistrar, srv CalendarServiceV1Server) {
+	s.RegisterService(&CalendarServiceV1_ServiceDesc, srv)
+}
+
+func _CalendarServiceV1_CreateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).CreateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_CreateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).CreateCalendar(ctx, req.(*CalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_GetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).GetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_GetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).GetCalendar(ctx, req.(*CalendarIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_ListCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).ListCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_ListCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).ListCalendars(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_UpdateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).UpdateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_UpdateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).UpdateCalendar(ctx, req.(*CalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).DeleteCalendar(ctx, req.(*CalendarIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_ShareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).ShareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_ShareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).ShareCalendar(ctx, req.(*ShareCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_UnshareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).UnshareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_UnshareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).UnshareCalendar(ctx, req.(*UnshareCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_ListCalendarShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).ListCalendarShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_ListCalendarShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).ListCalendarShares(ctx, req.(*CalendarIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_QueryFreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).QueryFreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_QueryFreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).QueryFreeBusy(ctx, req.(*FreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV1_FindMeetingSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV1Server).FindMeetingSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarServiceV1_FindMeetingSlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV1Server).FindMeetingSlots(ctx, req.(*MeetingSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CalendarServiceV1_ServiceDesc is the grpc.ServiceDesc for CalendarServiceV1 service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CalendarServiceV1_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "event.CalendarServiceV1",
+	HandlerType: (*CalendarServiceV1Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCalendar",
+			Handler:    _CalendarServiceV1_CreateCalendar_Handler,
+		},
+		{
+			MethodName: "GetCalendar",
+			Handler:    _CalendarServiceV1_GetCalendar_Handler,
+		},
+		{
+			MethodName: "ListCalendars",
+			Handler:    _CalendarServiceV1_ListCalendars_Handler,
+		},
+		{
+			MethodName: "UpdateCalendar",
+			Handler:    _CalendarServiceV1_UpdateCalendar_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _CalendarServiceV1_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "ShareCalendar",
+			Handler:    _CalendarServiceV1_ShareCalendar_Handler,
+		},
+		{
+			MethodName: "UnshareCalendar",
+			Handler:    _CalendarServiceV1_UnshareCalendar_Handler,
+		},
+		{
+			MethodName: "ListCalendarShares",
+			Handler:    _CalendarServiceV1_ListCalendarShares_Handler,
+		},
+		{
+			MethodName: "QueryFreeBusy",
+			Handler:    _CalendarServiceV1_QueryFreeBusy_Handler,
+		},
+		{
+			MethodName: "FindMeetingSlots",
+			Handler:    _CalendarServiceV1_FindMeetingSlots_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/CalendarService.proto",
+}
@@ -38,32 +38,31 @@ var (
 // define the regex for a UUID once up-front
 var _event_service_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
 
-// Validate checks the field values on Event with the rules defined in the
+// Validate checks the field values on Recurrence with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *Event) Validate() error {
+func (m *Recurrence) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Event with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in EventMultiError, or nil if none found.
-func (m *Event) ValidateAll() error {
+// ValidateAll checks the field values on Recurrence with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RecurrenceMultiError, or
+// nil if none found.
+func (m *Recurrence) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Event) validate(all bool) error {
+func (m *Recurrence) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	if utf8.RuneCountInString(m.GetTitle()) < 1 {
-		err := EventValidationError{
-			field:  "Title",
+	if utf8.RuneCountInString(m.GetRule()) < 1 {
+		err := RecurrenceValidationError{
+			field:  "Rule",
 			reason: "value length must be at least 1 runes",
 		}
 		if !all {
@@ -72,130 +71,53 @@ func (m *Event) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if m.GetStartTime() == nil {
-		err := EventValidationError{
-			field:  "StartTime",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if all {
-		switch v := interface{}(m.GetEndTime()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EventValidationError{
-					field:  "EndTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, EventValidationError{
-					field:  "EndTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EventValidationError{
-				field:  "EndTime",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	for idx, item := range m.GetExceptions() {
+		_, _ = idx, item
 
-	if all {
-		switch v := interface{}(m.GetNotifyTime()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EventValidationError{
-					field:  "NotifyTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RecurrenceValidationError{
+						field:  fmt.Sprintf("Exceptions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RecurrenceValidationError{
+						field:  fmt.Sprintf("Exceptions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, EventValidationError{
-					field:  "NotifyTime",
+				return RecurrenceValidationError{
+					field:  fmt.Sprintf("Exceptions[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetNotifyTime()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EventValidationError{
-				field:  "NotifyTime",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
-	}
-
-	// no validation rules for Description
-
-	if m.GetUserId() < 0 {
-		err := EventValidationError{
-			field:  "UserId",
-			reason: "value must be greater than or equal to 0",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
 
-	if all {
-		switch v := interface{}(m.GetCreatedTime()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EventValidationError{
-					field:  "CreatedTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, EventValidationError{
-					field:  "CreatedTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetCreatedTime()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EventValidationError{
-				field:  "CreatedTime",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
 	}
 
 	if len(errors) > 0 {
-		return EventMultiError(errors)
+		return RecurrenceMultiError(errors)
 	}
 
 	return nil
 }
 
-// EventMultiError is an error wrapping multiple validation errors returned by
-// Event.ValidateAll() if the designated constraints aren't met.
-type EventMultiError []error
+// RecurrenceMultiError is an error wrapping multiple validation errors
+// returned by Recurrence.ValidateAll() if the designated constraints aren't met.
+type RecurrenceMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EventMultiError) Error() string {
+func (m RecurrenceMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -204,11 +126,11 @@ func (m EventMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EventMultiError) AllErrors() []error { return m }
+func (m RecurrenceMultiError) AllErrors() []error { return m }
 
-// EventValidationError is the validation error returned by Event.Validate if
-// the designated constraints aren't met.
-type EventValidationError struct {
+// RecurrenceValidationError is the validation error returned by
+// Recurrence.Validate if the designated constraints aren't met.
+type RecurrenceValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -216,22 +138,22 @@ type EventValidationError struct {
 }
 
 // Field function returns field value.
-func (e EventValidationError) Field() string { return e.field }
+func (e RecurrenceValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EventValidationError) Reason() string { return e.reason }
+func (e RecurrenceValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EventValidationError) Cause() error { return e.cause }
+func (e RecurrenceValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EventValidationError) Key() bool { return e.key }
+func (e RecurrenceValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EventValidationError) ErrorName() string { return "EventValidationError" }
+func (e RecurrenceValidationError) ErrorName() string { return "RecurrenceValidationError" }
 
 // Error satisfies the builtin error interface
-func (e EventValidationError) Error() string {
+func (e RecurrenceValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -243,14 +165,14 @@ func (e EventValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEvent.%s: %s%s",
+		"invalid %sRecurrence.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EventValidationError{}
+var _ error = RecurrenceValidationError{}
 
 var _ interface {
 	Field() string
@@ -258,73 +180,57 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EventValidationError{}
+} = RecurrenceValidationError{}
 
-// Validate checks the field values on EventResponse with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *EventResponse) Validate() error {
+// Validate checks the field values on NotificationTarget with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *NotificationTarget) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on EventResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in EventResponseMultiError, or
-// nil if none found.
-func (m *EventResponse) ValidateAll() error {
+// ValidateAll checks the field values on NotificationTarget with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// NotificationTargetMultiError, or nil if none found.
+func (m *NotificationTarget) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *EventResponse) validate(all bool) error {
+func (m *NotificationTarget) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetEvent()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EventResponseValidationError{
-					field:  "Event",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, EventResponseValidationError{
-					field:  "Event",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
+	if _, ok := _NotificationTarget_Channel_InLookup[m.GetChannel()]; !ok {
+		err := NotificationTargetValidationError{
+			field:  "Channel",
+			reason: "value must be in list [email webhook log]",
 		}
-	} else if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EventResponseValidationError{
-				field:  "Event",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
+	// no validation rules for Address
+
 	if len(errors) > 0 {
-		return EventResponseMultiError(errors)
+		return NotificationTargetMultiError(errors)
 	}
 
 	return nil
 }
 
-// EventResponseMultiError is an error wrapping multiple validation errors
-// returned by EventResponse.ValidateAll() if the designated constraints
+// NotificationTargetMultiError is an error wrapping multiple validation errors
+// returned by NotificationTarget.ValidateAll() if the designated constraints
 // aren't met.
-type EventResponseMultiError []error
+type NotificationTargetMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EventResponseMultiError) Error() string {
+func (m NotificationTargetMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -333,11 +239,11 @@ func (m EventResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EventResponseMultiError) AllErrors() []error { return m }
+func (m NotificationTargetMultiError) AllErrors() []error { return m }
 
-// EventResponseValidationError is the validation error returned by
-// EventResponse.Validate if the designated constraints aren't met.
-type EventResponseValidationError struct {
+// NotificationTargetValidationError is the validation error returned by
+// NotificationTarget.Validate if the designated constraints aren't met.
+type NotificationTargetValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -345,22 +251,24 @@ type EventResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e EventResponseValidationError) Field() string { return e.field }
+func (e NotificationTargetValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EventResponseValidationError) Reason() string { return e.reason }
+func (e NotificationTargetValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EventResponseValidationError) Cause() error { return e.cause }
+func (e NotificationTargetValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EventResponseValidationError) Key() bool { return e.key }
+func (e NotificationTargetValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EventResponseValidationError) ErrorName() string { return "EventResponseValidationError" }
+func (e NotificationTargetValidationError) ErrorName() string {
+	return "NotificationTargetValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e EventResponseValidationError) Error() string {
+func (e NotificationTargetValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -372,14 +280,14 @@ func (e EventResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEventResponse.%s: %s%s",
+		"invalid %sNotificationTarget.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EventResponseValidationError{}
+var _ error = NotificationTargetValidationError{}
 
 var _ interface {
 	Field() string
@@ -387,78 +295,2217 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EventResponseValidationError{}
+} = NotificationTargetValidationError{}
 
-// Validate checks the field values on EventsResponse with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
+var _NotificationTarget_Channel_InLookup = map[string]struct{}{
+	"email":   {},
+	"webhook": {},
+	"log":     {},
+}
+
+// Validate checks the field values on Attendee with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *EventsResponse) Validate() error {
+func (m *Attendee) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on EventsResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in EventsResponseMultiError,
-// or nil if none found.
-func (m *EventsResponse) ValidateAll() error {
+// ValidateAll checks the field values on Attendee with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AttendeeMultiError, or nil
+// if none found.
+func (m *Attendee) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *EventsResponse) validate(all bool) error {
+func (m *Attendee) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetEvents() {
-		_, _ = idx, item
+	// no validation rules for UserId
 
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, EventsResponseValidationError{
-						field:  fmt.Sprintf("Events[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, EventsResponseValidationError{
-						field:  fmt.Sprintf("Events[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
+	// no validation rules for Status
+
+	if len(errors) > 0 {
+		return AttendeeMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttendeeMultiError is an error wrapping multiple validation errors returned
+// by Attendee.ValidateAll() if the designated constraints aren't met.
+type AttendeeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttendeeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttendeeMultiError) AllErrors() []error { return m }
+
+// AttendeeValidationError is the validation error returned by
+// Attendee.Validate if the designated constraints aren't met.
+type AttendeeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttendeeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttendeeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttendeeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttendeeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttendeeValidationError) ErrorName() string { return "AttendeeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AttendeeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttendee.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttendeeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttendeeValidationError{}
+
+// Validate checks the field values on Reminder with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Reminder) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Reminder with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ReminderMultiError, or nil
+// if none found.
+func (m *Reminder) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Reminder) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetOffset()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ReminderValidationError{
+					field:  "Offset",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ReminderValidationError{
+					field:  "Offset",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOffset()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReminderValidationError{
+				field:  "Offset",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ReminderValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ReminderValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReminderValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ReminderMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReminderMultiError is an error wrapping multiple validation errors returned
+// by Reminder.ValidateAll() if the designated constraints aren't met.
+type ReminderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReminderMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReminderMultiError) AllErrors() []error { return m }
+
+// ReminderValidationError is the validation error returned by
+// Reminder.Validate if the designated constraints aren't met.
+type ReminderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReminderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReminderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReminderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReminderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReminderValidationError) ErrorName() string { return "ReminderValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReminderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReminder.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReminderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReminderValidationError{}
+
+// Validate checks the field values on Event with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Event) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Event with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in EventMultiError, or nil if none found.
+func (m *Event) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Event) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if utf8.RuneCountInString(m.GetTitle()) < 1 {
+		err := EventValidationError{
+			field:  "Title",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetStartTime() == nil {
+		err := EventValidationError{
+			field:  "StartTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "EndTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Description
+
+	if m.GetUserId() < 0 {
+		err := EventValidationError{
+			field:  "UserId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "CreatedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "CreatedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "CreatedTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRecurrence()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "Recurrence",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "Recurrence",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRecurrence()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "Recurrence",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRecurrenceId()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "RecurrenceId",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "RecurrenceId",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRecurrenceId()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "RecurrenceId",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetNotificationTarget()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "NotificationTarget",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "NotificationTarget",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetNotificationTarget()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "NotificationTarget",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetDeletedTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "DeletedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "DeletedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "DeletedTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Version
+
+	for idx, item := range m.GetAttendees() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  fmt.Sprintf("Attendees[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  fmt.Sprintf("Attendees[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  fmt.Sprintf("Attendees[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.GetCalendarId() != "" {
+
+		if err := m._validateUuid(m.GetCalendarId()); err != nil {
+			err = EventValidationError{
+				field:  "CalendarId",
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetReminders()) > 10 {
+		err := EventValidationError{
+			field:  "Reminders",
+			reason: "value must contain no more than 10 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetReminders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  fmt.Sprintf("Reminders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  fmt.Sprintf("Reminders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  fmt.Sprintf("Reminders[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for TimeZone
+
+	// no validation rules for AllDay
+
+	if len(errors) > 0 {
+		return EventMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *Event) _validateUuid(uuid string) error {
+	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// EventMultiError is an error wrapping multiple validation errors returned by
+// Event.ValidateAll() if the designated constraints aren't met.
+type EventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventMultiError) AllErrors() []error { return m }
+
+// EventValidationError is the validation error returned by Event.Validate if
+// the designated constraints aren't met.
+type EventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventValidationError) ErrorName() string { return "EventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventValidationError{}
+
+// Validate checks the field values on EventResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventResponseMultiError, or
+// nil if none found.
+func (m *EventResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetEvent()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventResponseValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventResponseValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventResponseValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return EventResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EventResponseMultiError is an error wrapping multiple validation errors
+// returned by EventResponse.ValidateAll() if the designated constraints
+// aren't met.
+type EventResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventResponseMultiError) AllErrors() []error { return m }
+
+// EventResponseValidationError is the validation error returned by
+// EventResponse.Validate if the designated constraints aren't met.
+type EventResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventResponseValidationError) ErrorName() string { return "EventResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventResponseValidationError{}
+
+// Validate checks the field values on EventsResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventsResponseMultiError,
+// or nil if none found.
+func (m *EventsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEvents() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventsResponseValidationError{
+						field:  fmt.Sprintf("Events[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventsResponseValidationError{
+						field:  fmt.Sprintf("Events[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventsResponseValidationError{
+					field:  fmt.Sprintf("Events[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return EventsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EventsResponseMultiError is an error wrapping multiple validation errors
+// returned by EventsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type EventsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventsResponseMultiError) AllErrors() []error { return m }
+
+// EventsResponseValidationError is the validation error returned by
+// EventsResponse.Validate if the designated constraints aren't met.
+type EventsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventsResponseValidationError) ErrorName() string { return "EventsResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventsResponseValidationError{}
+
+// Validate checks the field values on EventRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventRequestMultiError, or
+// nil if none found.
+func (m *EventRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetEvent() == nil {
+		err := EventRequestValidationError{
+			field:  "Event",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetEvent()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventRequestValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventRequestValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventRequestValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for RequestId
+
+	// no validation rules for AllowOverlap
+
+	if len(errors) > 0 {
+		return EventRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// EventRequestMultiError is an error wrapping multiple validation errors
+// returned by EventRequest.ValidateAll() if the designated constraints aren't met.
+type EventRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventRequestMultiError) AllErrors() []error { return m }
+
+// EventRequestValidationError is the validation error returned by
+// EventRequest.Validate if the designated constraints aren't met.
+type EventRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventRequestValidationError) ErrorName() string { return "EventRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventRequestValidationError{}
+
+// Validate checks the field values on EventIDRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventIDRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventIDRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventIDRequestMultiError,
+// or nil if none found.
+func (m *EventIDRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventIDRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = EventIDRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for RequestId
+
+	if len(errors) > 0 {
+		return EventIDRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *EventIDRequest) _validateUuid(uuid string) error {
+	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// EventIDRequestMultiError is an error wrapping multiple validation errors
+// returned by EventIDRequest.ValidateAll() if the designated constraints
+// aren't met.
+type EventIDRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventIDRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventIDRequestMultiError) AllErrors() []error { return m }
+
+// EventIDRequestValidationError is the validation error returned by
+// EventIDRequest.Validate if the designated constraints aren't met.
+type EventIDRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventIDRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventIDRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventIDRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventIDRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventIDRequestValidationError) ErrorName() string { return "EventIDRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventIDRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventIDRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventIDRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventIDRequestValidationError{}
+
+// Validate checks the field values on DeleteEventRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteEventRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteEventRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteEventRequestMultiError, or nil if none found.
+func (m *DeleteEventRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteEventRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = DeleteEventRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for RequestId
+
+	// no validation rules for Permanent
+
+	if len(errors) > 0 {
+		return DeleteEventRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *DeleteEventRequest) _validateUuid(uuid string) error {
+	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// DeleteEventRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteEventRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteEventRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteEventRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteEventRequestMultiError) AllErrors() []error { return m }
+
+// DeleteEventRequestValidationError is the validation error returned by
+// DeleteEventRequest.Validate if the designated constraints aren't met.
+type DeleteEventRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteEventRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteEventRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteEventRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteEventRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteEventRequestValidationError) ErrorName() string {
+	return "DeleteEventRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteEventRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteEventRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteEventRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteEventRequestValidationError{}
+
+// Validate checks the field values on TimePeriodRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *TimePeriodRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TimePeriodRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TimePeriodRequestMultiError, or nil if none found.
+func (m *TimePeriodRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TimePeriodRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetStartTime() == nil {
+		err := TimePeriodRequestValidationError{
+			field:  "StartTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEndTime() == nil {
+		err := TimePeriodRequestValidationError{
+			field:  "EndTime",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for RequestId
+
+	if val := m.GetPageSize(); val < 0 || val > 1000 {
+		err := TimePeriodRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PageToken
+
+	// no validation rules for Title
+
+	if m.GetUserId() < 0 {
+		err := TimePeriodRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetHasNotification()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TimePeriodRequestValidationError{
+					field:  "HasNotification",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TimePeriodRequestValidationError{
+					field:  "HasNotification",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetHasNotification()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TimePeriodRequestValidationError{
+				field:  "HasNotification",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := SortOrder_name[int32(m.GetOrder())]; !ok {
+		err := TimePeriodRequestValidationError{
+			field:  "Order",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetCalendarId() != "" {
+
+		if err := m._validateUuid(m.GetCalendarId()); err != nil {
+			err = TimePeriodRequestValidationError{
+				field:  "CalendarId",
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return TimePeriodRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *TimePeriodRequest) _validateUuid(uuid string) error {
+	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// TimePeriodRequestMultiError is an error wrapping multiple validation errors
+// returned by TimePeriodRequest.ValidateAll() if the designated constraints
+// aren't met.
+type TimePeriodRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TimePeriodRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TimePeriodRequestMultiError) AllErrors() []error { return m }
+
+// TimePeriodRequestValidationError is the validation error returned by
+// TimePeriodRequest.Validate if the designated constraints aren't met.
+type TimePeriodRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TimePeriodRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TimePeriodRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TimePeriodRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TimePeriodRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TimePeriodRequestValidationError) ErrorName() string {
+	return "TimePeriodRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TimePeriodRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTimePeriodRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TimePeriodRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TimePeriodRequestValidationError{}
+
+// Validate checks the field values on DateRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DateRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DateRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DateRequestMultiError, or
+// nil if none found.
+func (m *DateRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DateRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_DateRequest_Date_Pattern.MatchString(m.GetDate()) {
+		err := DateRequestValidationError{
+			field:  "Date",
+			reason: "value does not match regex pattern \"^[0-9]{4}-[0-9]{2}-[0-9]{2}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for TimeZone
+
+	// no validation rules for RequestId
+
+	if len(errors) > 0 {
+		return DateRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DateRequestMultiError is an error wrapping multiple validation errors
+// returned by DateRequest.ValidateAll() if the designated constraints aren't met.
+type DateRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DateRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DateRequestMultiError) AllErrors() []error { return m }
+
+// DateRequestValidationError is the validation error returned by
+// DateRequest.Validate if the designated constraints aren't met.
+type DateRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DateRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DateRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DateRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DateRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DateRequestValidationError) ErrorName() string { return "DateRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DateRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDateRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DateRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DateRequestValidationError{}
+
+var _DateRequest_Date_Pattern = regexp.MustCompile("^[0-9]{4}-[0-9]{2}-[0-9]{2}$")
+
+// Validate checks the field values on InviteAttendeesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *InviteAttendeesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on InviteAttendeesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// InviteAttendeesRequestMultiError, or nil if none found.
+func (m *InviteAttendeesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *InviteAttendeesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = InviteAttendeesRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetUserIds()) < 1 {
+		err := InviteAttendeesRequestValidationError{
+			field:  "UserIds",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetUserIds() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := InviteAttendeesRequestValidationError{
+				field:  fmt.Sprintf("UserIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for RequestId
+
+	if len(errors) > 0 {
+		return InviteAttendeesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *InviteAttendeesRequest) _validateUuid(uuid string) error {
+	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// InviteAttendeesRequestMultiError is an error wrapping multiple validation
+// errors returned by InviteAttendeesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type InviteAttendeesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m InviteAttendeesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m InviteAttendeesRequestMultiError) AllErrors() []error { return m }
+
+// InviteAttendeesRequestValidationError is the validation error returned by
+// InviteAttendeesRequest.Validate if the designated constraints aren't met.
+type InviteAttendeesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e InviteAttendeesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e InviteAttendeesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e InviteAttendeesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e InviteAttendeesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e InviteAttendeesRequestValidationError) ErrorName() string {
+	return "InviteAttendeesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e InviteAttendeesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInviteAttendeesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = InviteAttendeesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = InviteAttendeesRequestValidationError{}
+
+// Validate checks the field values on RespondInvitationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RespondInvitationRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RespondInvitationRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RespondInvitationRequestMultiError, or nil if none found.
+func (m *RespondInvitationRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RespondInvitationRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = RespondInvitationRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _RespondInvitationRequest_Status_InLookup[m.GetStatus()]; !ok {
+		err := RespondInvitationRequestValidationError{
+			field:  "Status",
+			reason: "value must be in list [ATTENDEE_STATUS_ACCEPTED ATTENDEE_STATUS_DECLINED ATTENDEE_STATUS_TENTATIVE]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for RequestId
+
+	if len(errors) > 0 {
+		return RespondInvitationRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RespondInvitationRequest) _validateUuid(uuid string) error {
+	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// RespondInvitationRequestMultiError is an error wrapping multiple validation
+// errors returned by RespondInvitationRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RespondInvitationRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RespondInvitationRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RespondInvitationRequestMultiError) AllErrors() []error { return m }
+
+// RespondInvitationRequestValidationError is the validation error returned by
+// RespondInvitationRequest.Validate if the designated constraints aren't met.
+type RespondInvitationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RespondInvitationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RespondInvitationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RespondInvitationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RespondInvitationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RespondInvitationRequestValidationError) ErrorName() string {
+	return "RespondInvitationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RespondInvitationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRespondInvitationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RespondInvitationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RespondInvitationRequestValidationError{}
+
+var _RespondInvitationRequest_Status_InLookup = map[AttendeeStatus]struct{}{
+	2: {},
+	3: {},
+	4: {},
+}
+
+// Validate checks the field values on ListInvitationsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListInvitationsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListInvitationsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListInvitationsRequestMultiError, or nil if none found.
+func (m *ListInvitationsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListInvitationsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := AttendeeStatus_name[int32(m.GetStatus())]; !ok {
+		err := ListInvitationsRequestValidationError{
+			field:  "Status",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListInvitationsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListInvitationsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListInvitationsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListInvitationsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListInvitationsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListInvitationsRequestMultiError) AllErrors() []error { return m }
+
+// ListInvitationsRequestValidationError is the validation error returned by
+// ListInvitationsRequest.Validate if the designated constraints aren't met.
+type ListInvitationsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListInvitationsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListInvitationsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListInvitationsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListInvitationsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListInvitationsRequestValidationError) ErrorName() string {
+	return "ListInvitationsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListInvitationsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListInvitationsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListInvitationsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListInvitationsRequestValidationError{}
+
+// Validate checks the field values on EventChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventChangeMultiError, or
+// nil if none found.
+func (m *EventChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for EventId
+
+	// no validation rules for Action
+
+	// no validation rules for ActorId
+
+	// no validation rules for RequestId
+
+	if all {
+		switch v := interface{}(m.GetBefore()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventChangeValidationError{
+					field:  "Before",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventChangeValidationError{
+					field:  "Before",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBefore()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventChangeValidationError{
+				field:  "Before",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAfter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventChangeValidationError{
+					field:  "After",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventChangeValidationError{
+					field:  "After",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAfter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventChangeValidationError{
+				field:  "After",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventChangeValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
 			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				return EventsResponseValidationError{
-					field:  fmt.Sprintf("Events[%v]", idx),
+				errors = append(errors, EventChangeValidationError{
+					field:  "Time",
 					reason: "embedded message failed validation",
 					cause:  err,
-				}
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventChangeValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
 			}
 		}
-
 	}
 
 	if len(errors) > 0 {
-		return EventsResponseMultiError(errors)
+		return EventChangeMultiError(errors)
 	}
 
 	return nil
 }
 
-// EventsResponseMultiError is an error wrapping multiple validation errors
-// returned by EventsResponse.ValidateAll() if the designated constraints
-// aren't met.
-type EventsResponseMultiError []error
+// EventChangeMultiError is an error wrapping multiple validation errors
+// returned by EventChange.ValidateAll() if the designated constraints aren't met.
+type EventChangeMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EventsResponseMultiError) Error() string {
+func (m EventChangeMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -467,11 +2514,11 @@ func (m EventsResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EventsResponseMultiError) AllErrors() []error { return m }
+func (m EventChangeMultiError) AllErrors() []error { return m }
 
-// EventsResponseValidationError is the validation error returned by
-// EventsResponse.Validate if the designated constraints aren't met.
-type EventsResponseValidationError struct {
+// EventChangeValidationError is the validation error returned by
+// EventChange.Validate if the designated constraints aren't met.
+type EventChangeValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -479,22 +2526,22 @@ type EventsResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e EventsResponseValidationError) Field() string { return e.field }
+func (e EventChangeValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EventsResponseValidationError) Reason() string { return e.reason }
+func (e EventChangeValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EventsResponseValidationError) Cause() error { return e.cause }
+func (e EventChangeValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EventsResponseValidationError) Key() bool { return e.key }
+func (e EventChangeValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EventsResponseValidationError) ErrorName() string { return "EventsResponseValidationError" }
+func (e EventChangeValidationError) ErrorName() string { return "EventChangeValidationError" }
 
 // Error satisfies the builtin error interface
-func (e EventsResponseValidationError) Error() string {
+func (e EventChangeValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -506,14 +2553,14 @@ func (e EventsResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEventsResponse.%s: %s%s",
+		"invalid %sEventChange.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EventsResponseValidationError{}
+var _ error = EventChangeValidationError{}
 
 var _ interface {
 	Field() string
@@ -521,85 +2568,78 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EventsResponseValidationError{}
+} = EventChangeValidationError{}
 
-// Validate checks the field values on EventRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *EventRequest) Validate() error {
+// Validate checks the field values on EventHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EventHistoryResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on EventRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in EventRequestMultiError, or
-// nil if none found.
-func (m *EventRequest) ValidateAll() error {
+// ValidateAll checks the field values on EventHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EventHistoryResponseMultiError, or nil if none found.
+func (m *EventHistoryResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *EventRequest) validate(all bool) error {
+func (m *EventHistoryResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetEvent() == nil {
-		err := EventRequestValidationError{
-			field:  "Event",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
 
-	if all {
-		switch v := interface{}(m.GetEvent()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EventRequestValidationError{
-					field:  "Event",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, EventRequestValidationError{
-					field:  "Event",
+				return EventHistoryResponseValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EventRequestValidationError{
-				field:  "Event",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
-	}
 
-	// no validation rules for RequestId
+	}
 
 	if len(errors) > 0 {
-		return EventRequestMultiError(errors)
+		return EventHistoryResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// EventRequestMultiError is an error wrapping multiple validation errors
-// returned by EventRequest.ValidateAll() if the designated constraints aren't met.
-type EventRequestMultiError []error
+// EventHistoryResponseMultiError is an error wrapping multiple validation
+// errors returned by EventHistoryResponse.ValidateAll() if the designated
+// constraints aren't met.
+type EventHistoryResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EventRequestMultiError) Error() string {
+func (m EventHistoryResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -608,11 +2648,11 @@ func (m EventRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EventRequestMultiError) AllErrors() []error { return m }
+func (m EventHistoryResponseMultiError) AllErrors() []error { return m }
 
-// EventRequestValidationError is the validation error returned by
-// EventRequest.Validate if the designated constraints aren't met.
-type EventRequestValidationError struct {
+// EventHistoryResponseValidationError is the validation error returned by
+// EventHistoryResponse.Validate if the designated constraints aren't met.
+type EventHistoryResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -620,22 +2660,24 @@ type EventRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e EventRequestValidationError) Field() string { return e.field }
+func (e EventHistoryResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EventRequestValidationError) Reason() string { return e.reason }
+func (e EventHistoryResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EventRequestValidationError) Cause() error { return e.cause }
+func (e EventHistoryResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EventRequestValidationError) Key() bool { return e.key }
+func (e EventHistoryResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EventRequestValidationError) ErrorName() string { return "EventRequestValidationError" }
+func (e EventHistoryResponseValidationError) ErrorName() string {
+	return "EventHistoryResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e EventRequestValidationError) Error() string {
+func (e EventHistoryResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -647,14 +2689,14 @@ func (e EventRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEventRequest.%s: %s%s",
+		"invalid %sEventHistoryResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EventRequestValidationError{}
+var _ error = EventHistoryResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -662,35 +2704,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EventRequestValidationError{}
+} = EventHistoryResponseValidationError{}
 
-// Validate checks the field values on EventIDRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *EventIDRequest) Validate() error {
+// Validate checks the field values on NotificationTargetRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *NotificationTargetRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on EventIDRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in EventIDRequestMultiError,
-// or nil if none found.
-func (m *EventIDRequest) ValidateAll() error {
+// ValidateAll checks the field values on NotificationTargetRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// NotificationTargetRequestMultiError, or nil if none found.
+func (m *NotificationTargetRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *EventIDRequest) validate(all bool) error {
+func (m *NotificationTargetRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if err := m._validateUuid(m.GetId()); err != nil {
-		err = EventIDRequestValidationError{
-			field:  "Id",
-			reason: "value must be a valid UUID",
-			cause:  err,
+	if m.GetTarget() == nil {
+		err := NotificationTargetRequestValidationError{
+			field:  "Target",
+			reason: "value is required",
 		}
 		if !all {
 			return err
@@ -698,30 +2739,51 @@ func (m *EventIDRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	// no validation rules for RequestId
-
-	if len(errors) > 0 {
-		return EventIDRequestMultiError(errors)
+	if all {
+		switch v := interface{}(m.GetTarget()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, NotificationTargetRequestValidationError{
+					field:  "Target",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, NotificationTargetRequestValidationError{
+					field:  "Target",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTarget()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return NotificationTargetRequestValidationError{
+				field:  "Target",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
 	}
 
-	return nil
-}
+	// no validation rules for RequestId
 
-func (m *EventIDRequest) _validateUuid(uuid string) error {
-	if matched := _event_service_uuidPattern.MatchString(uuid); !matched {
-		return errors.New("invalid uuid format")
+	if len(errors) > 0 {
+		return NotificationTargetRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// EventIDRequestMultiError is an error wrapping multiple validation errors
-// returned by EventIDRequest.ValidateAll() if the designated constraints
-// aren't met.
-type EventIDRequestMultiError []error
+// NotificationTargetRequestMultiError is an error wrapping multiple validation
+// errors returned by NotificationTargetRequest.ValidateAll() if the
+// designated constraints aren't met.
+type NotificationTargetRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EventIDRequestMultiError) Error() string {
+func (m NotificationTargetRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -730,11 +2792,11 @@ func (m EventIDRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EventIDRequestMultiError) AllErrors() []error { return m }
+func (m NotificationTargetRequestMultiError) AllErrors() []error { return m }
 
-// EventIDRequestValidationError is the validation error returned by
-// EventIDRequest.Validate if the designated constraints aren't met.
-type EventIDRequestValidationError struct {
+// NotificationTargetRequestValidationError is the validation error returned by
+// NotificationTargetRequest.Validate if the designated constraints aren't met.
+type NotificationTargetRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -742,22 +2804,24 @@ type EventIDRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e EventIDRequestValidationError) Field() string { return e.field }
+func (e NotificationTargetRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EventIDRequestValidationError) Reason() string { return e.reason }
+func (e NotificationTargetRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EventIDRequestValidationError) Cause() error { return e.cause }
+func (e NotificationTargetRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EventIDRequestValidationError) Key() bool { return e.key }
+func (e NotificationTargetRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EventIDRequestValidationError) ErrorName() string { return "EventIDRequestValidationError" }
+func (e NotificationTargetRequestValidationError) ErrorName() string {
+	return "NotificationTargetRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e EventIDRequestValidationError) Error() string {
+func (e NotificationTargetRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -769,14 +2833,14 @@ func (e EventIDRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEventIDRequest.%s: %s%s",
+		"invalid %sNotificationTargetRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EventIDRequestValidationError{}
+var _ error = NotificationTargetRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -784,68 +2848,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EventIDRequestValidationError{}
+} = NotificationTargetRequestValidationError{}
 
-// Validate checks the field values on TimePeriodRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *TimePeriodRequest) Validate() error {
+// Validate checks the field values on NotificationTargetResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *NotificationTargetResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TimePeriodRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on NotificationTargetResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// TimePeriodRequestMultiError, or nil if none found.
-func (m *TimePeriodRequest) ValidateAll() error {
+// NotificationTargetResponseMultiError, or nil if none found.
+func (m *NotificationTargetResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TimePeriodRequest) validate(all bool) error {
+func (m *NotificationTargetResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetStartTime() == nil {
-		err := TimePeriodRequestValidationError{
-			field:  "StartTime",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if m.GetEndTime() == nil {
-		err := TimePeriodRequestValidationError{
-			field:  "EndTime",
-			reason: "value is required",
+	if all {
+		switch v := interface{}(m.GetTarget()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, NotificationTargetResponseValidationError{
+					field:  "Target",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, NotificationTargetResponseValidationError{
+					field:  "Target",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
 		}
-		if !all {
-			return err
+	} else if v, ok := interface{}(m.GetTarget()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return NotificationTargetResponseValidationError{
+				field:  "Target",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
 		}
-		errors = append(errors, err)
 	}
 
-	// no validation rules for RequestId
-
 	if len(errors) > 0 {
-		return TimePeriodRequestMultiError(errors)
+		return NotificationTargetResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// TimePeriodRequestMultiError is an error wrapping multiple validation errors
-// returned by TimePeriodRequest.ValidateAll() if the designated constraints
-// aren't met.
-type TimePeriodRequestMultiError []error
+// NotificationTargetResponseMultiError is an error wrapping multiple
+// validation errors returned by NotificationTargetResponse.ValidateAll() if
+// the designated constraints aren't met.
+type NotificationTargetResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TimePeriodRequestMultiError) Error() string {
+func (m NotificationTargetResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -854,11 +2923,11 @@ func (m TimePeriodRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TimePeriodRequestMultiError) AllErrors() []error { return m }
+func (m NotificationTargetResponseMultiError) AllErrors() []error { return m }
 
-// TimePeriodRequestValidationError is the validation error returned by
-// TimePeriodRequest.Validate if the designated constraints aren't met.
-type TimePeriodRequestValidationError struct {
+// NotificationTargetResponseValidationError is the validation error returned
+// by NotificationTargetResponse.Validate if the designated constraints aren't met.
+type NotificationTargetResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -866,24 +2935,24 @@ type TimePeriodRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e TimePeriodRequestValidationError) Field() string { return e.field }
+func (e NotificationTargetResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TimePeriodRequestValidationError) Reason() string { return e.reason }
+func (e NotificationTargetResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TimePeriodRequestValidationError) Cause() error { return e.cause }
+func (e NotificationTargetResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TimePeriodRequestValidationError) Key() bool { return e.key }
+func (e NotificationTargetResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TimePeriodRequestValidationError) ErrorName() string {
-	return "TimePeriodRequestValidationError"
+func (e NotificationTargetResponseValidationError) ErrorName() string {
+	return "NotificationTargetResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e TimePeriodRequestValidationError) Error() string {
+func (e NotificationTargetResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -895,14 +2964,14 @@ func (e TimePeriodRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTimePeriodRequest.%s: %s%s",
+		"invalid %sNotificationTargetResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TimePeriodRequestValidationError{}
+var _ error = NotificationTargetResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -910,4 +2979,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TimePeriodRequestValidationError{}
+} = NotificationTargetResponseValidationError{}
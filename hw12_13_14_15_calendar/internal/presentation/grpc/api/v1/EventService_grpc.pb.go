@@ -20,11 +20,23 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	EventServiceV1_GetEvent_FullMethodName          = "/event.EventServiceV1/GetEvent"
-	EventServiceV1_CreateEvent_FullMethodName       = "/event.EventServiceV1/CreateEvent"
-	EventServiceV1_UpdateEvent_FullMethodName       = "/event.EventServiceV1/UpdateEvent"
-	EventServiceV1_DeleteEvent_FullMethodName       = "/event.EventServiceV1/DeleteEvent"
-	EventServiceV1_GetEventsByPeriod_FullMethodName = "/event.EventServiceV1/GetEventsByPeriod"
+	EventServiceV1_GetEvent_FullMethodName              = "/event.EventServiceV1/GetEvent"
+	EventServiceV1_CreateEvent_FullMethodName           = "/event.EventServiceV1/CreateEvent"
+	EventServiceV1_UpdateEvent_FullMethodName           = "/event.EventServiceV1/UpdateEvent"
+	EventServiceV1_DeleteEvent_FullMethodName           = "/event.EventServiceV1/DeleteEvent"
+	EventServiceV1_RestoreEvent_FullMethodName          = "/event.EventServiceV1/RestoreEvent"
+	EventServiceV1_GetEventHistory_FullMethodName       = "/event.EventServiceV1/GetEventHistory"
+	EventServiceV1_InviteAttendees_FullMethodName       = "/event.EventServiceV1/InviteAttendees"
+	EventServiceV1_RespondInvitation_FullMethodName     = "/event.EventServiceV1/RespondInvitation"
+	EventServiceV1_ListInvitations_FullMethodName       = "/event.EventServiceV1/ListInvitations"
+	EventServiceV1_ListDeletedEvents_FullMethodName     = "/event.EventServiceV1/ListDeletedEvents"
+	EventServiceV1_GetEventsByPeriod_FullMethodName     = "/event.EventServiceV1/GetEventsByPeriod"
+	EventServiceV1_StreamEventsByPeriod_FullMethodName  = "/event.EventServiceV1/StreamEventsByPeriod"
+	EventServiceV1_ListDayEvents_FullMethodName         = "/event.EventServiceV1/ListDayEvents"
+	EventServiceV1_ListWeekEvents_FullMethodName        = "/event.EventServiceV1/ListWeekEvents"
+	EventServiceV1_ListMonthEvents_FullMethodName       = "/event.EventServiceV1/ListMonthEvents"
+	EventServiceV1_SetNotificationTarget_FullMethodName = "/event.EventServiceV1/SetNotificationTarget"
+	EventServiceV1_GetNotificationTarget_FullMethodName = "/event.EventServiceV1/GetNotificationTarget"
 )
 
 // EventServiceV1Client is the client API for EventServiceV1 service.
@@ -33,9 +45,33 @@ const (
 type EventServiceV1Client interface {
 	GetEvent(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*EventResponse, error)
 	CreateEvent(ctx context.Context, in *EventRequest, opts ...grpc.CallOption) (*EventResponse, error)
+	// Updates the event if the version of the request or the If-Match header of the gateway is the current one,
+	// the update of another version is aborted (HTTP 412).
 	UpdateEvent(ctx context.Context, in *EventRequest, opts ...grpc.CallOption) (*EventResponse, error)
-	DeleteEvent(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Moves the event to the trash, deleted events are purged after the retention period.
+	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Restores the event from the trash.
+	RestoreEvent(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*EventResponse, error)
+	// Lists changes of the event in the order they were made, the history is kept after the event is deleted.
+	GetEventHistory(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*EventHistoryResponse, error)
+	// Invites the users to the event of the current user, invited users get the event notifications
+	// and see the event in their listings unless they decline it.
+	InviteAttendees(ctx context.Context, in *InviteAttendeesRequest, opts ...grpc.CallOption) (*EventResponse, error)
+	// Responds to the invitation of the current user to the event.
+	RespondInvitation(ctx context.Context, in *RespondInvitationRequest, opts ...grpc.CallOption) (*EventResponse, error)
+	// Lists events the current user is invited to ordered by start time.
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	// Lists events of the current user in the trash, the latest deleted go first.
+	ListDeletedEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EventsResponse, error)
 	GetEventsByPeriod(ctx context.Context, in *TimePeriodRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	// Streams events for the period, paging fields of the request are ignored.
+	StreamEventsByPeriod(ctx context.Context, in *TimePeriodRequest, opts ...grpc.CallOption) (EventServiceV1_StreamEventsByPeriodClient, error)
+	ListDayEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	ListWeekEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	ListMonthEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	// Sets the default notification target of the current user.
+	SetNotificationTarget(ctx context.Context, in *NotificationTargetRequest, opts ...grpc.CallOption) (*NotificationTargetResponse, error)
+	GetNotificationTarget(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NotificationTargetResponse, error)
 }
 
 type eventServiceV1Client struct {
@@ -73,7 +109,7 @@ func (c *eventServiceV1Client) UpdateEvent(ctx context.Context, in *EventRequest
 	return out, nil
 }
 
-func (c *eventServiceV1Client) DeleteEvent(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *eventServiceV1Client) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, EventServiceV1_DeleteEvent_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -82,6 +118,60 @@ func (c *eventServiceV1Client) DeleteEvent(ctx context.Context, in *EventIDReque
 	return out, nil
 }
 
+func (c *eventServiceV1Client) RestoreEvent(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*EventResponse, error) {
+	out := new(EventResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_RestoreEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) GetEventHistory(ctx context.Context, in *EventIDRequest, opts ...grpc.CallOption) (*EventHistoryResponse, error) {
+	out := new(EventHistoryResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_GetEventHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) InviteAttendees(ctx context.Context, in *InviteAttendeesRequest, opts ...grpc.CallOption) (*EventResponse, error) {
+	out := new(EventResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_InviteAttendees_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) RespondInvitation(ctx context.Context, in *RespondInvitationRequest, opts ...grpc.CallOption) (*EventResponse, error) {
+	out := new(EventResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_RespondInvitation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
+	out := new(EventsResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_ListInvitations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) ListDeletedEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EventsResponse, error) {
+	out := new(EventsResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_ListDeletedEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eventServiceV1Client) GetEventsByPeriod(ctx context.Context, in *TimePeriodRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
 	out := new(EventsResponse)
 	err := c.cc.Invoke(ctx, EventServiceV1_GetEventsByPeriod_FullMethodName, in, out, opts...)
@@ -91,15 +181,116 @@ func (c *eventServiceV1Client) GetEventsByPeriod(ctx context.Context, in *TimePe
 	return out, nil
 }
 
+func (c *eventServiceV1Client) StreamEventsByPeriod(ctx context.Context, in *TimePeriodRequest, opts ...grpc.CallOption) (EventServiceV1_StreamEventsByPeriodClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EventServiceV1_ServiceDesc.Streams[0], EventServiceV1_StreamEventsByPeriod_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventServiceV1StreamEventsByPeriodClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventServiceV1_StreamEventsByPeriodClient interface {
+	Recv() (*EventResponse, error)
+	grpc.ClientStream
+}
+
+type eventServiceV1StreamEventsByPeriodClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventServiceV1StreamEventsByPeriodClient) Recv() (*EventResponse, error) {
+	m := new(EventResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *eventServiceV1Client) ListDayEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
+	out := new(EventsResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_ListDayEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) ListWeekEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
+	out := new(EventsResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_ListWeekEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) ListMonthEvents(ctx context.Context, in *DateRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
+	out := new(EventsResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_ListMonthEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) SetNotificationTarget(ctx context.Context, in *NotificationTargetRequest, opts ...grpc.CallOption) (*NotificationTargetResponse, error) {
+	out := new(NotificationTargetResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_SetNotificationTarget_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceV1Client) GetNotificationTarget(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NotificationTargetResponse, error) {
+	out := new(NotificationTargetResponse)
+	err := c.cc.Invoke(ctx, EventServiceV1_GetNotificationTarget_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EventServiceV1Server is the server API for EventServiceV1 service.
 // All implementations must embed UnimplementedEventServiceV1Server
 // for forward compatibility
 type EventServiceV1Server interface {
 	GetEvent(context.Context, *EventIDRequest) (*EventResponse, error)
 	CreateEvent(context.Context, *EventRequest) (*EventResponse, error)
+	// Updates the event if the version of the request or the If-Match header of the gateway is the current one,
+	// the update of another version is aborted (HTTP 412).
 	UpdateEvent(context.Context, *EventRequest) (*EventResponse, error)
-	DeleteEvent(context.Context, *EventIDRequest) (*emptypb.Empty, error)
+	// Moves the event to the trash, deleted events are purged after the retention period.
+	DeleteEvent(context.Context, *DeleteEventRequest) (*emptypb.Empty, error)
+	// Restores the event from the trash.
+	RestoreEvent(context.Context, *EventIDRequest) (*EventResponse, error)
+	// Lists changes of the event in the order they were made, the history is kept after the event is deleted.
+	GetEventHistory(context.Context, *EventIDRequest) (*EventHistoryResponse, error)
+	// Invites the users to the event of the current user, invited users get the event notifications
+	// and see the event in their listings unless they decline it.
+	InviteAttendees(context.Context, *InviteAttendeesRequest) (*EventResponse, error)
+	// Responds to the invitation of the current user to the event.
+	RespondInvitation(context.Context, *RespondInvitationRequest) (*EventResponse, error)
+	// Lists events the current user is invited to ordered by start time.
+	ListInvitations(context.Context, *ListInvitationsRequest) (*EventsResponse, error)
+	// Lists events of the current user in the trash, the latest deleted go first.
+	ListDeletedEvents(context.Context, *emptypb.Empty) (*EventsResponse, error)
 	GetEventsByPeriod(context.Context, *TimePeriodRequest) (*EventsResponse, error)
+	// Streams events for the period, paging fields of the request are ignored.
+	StreamEventsByPeriod(*TimePeriodRequest, EventServiceV1_StreamEventsByPeriodServer) error
+	ListDayEvents(context.Context, *DateRequest) (*EventsResponse, error)
+	ListWeekEvents(context.Context, *DateRequest) (*EventsResponse, error)
+	ListMonthEvents(context.Context, *DateRequest) (*EventsResponse, error)
+	// Sets the default notification target of the current user.
+	SetNotificationTarget(context.Context, *NotificationTargetRequest) (*NotificationTargetResponse, error)
+	GetNotificationTarget(context.Context, *emptypb.Empty) (*NotificationTargetResponse, error)
 	mustEmbedUnimplementedEventServiceV1Server()
 }
 
@@ -116,12 +307,48 @@ func (UnimplementedEventServiceV1Server) CreateEvent(context.Context, *EventRequ
 func (UnimplementedEventServiceV1Server) UpdateEvent(context.Context, *EventRequest) (*EventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
 }
-func (UnimplementedEventServiceV1Server) DeleteEvent(context.Context, *EventIDRequest) (*emptypb.Empty, error) {
+func (UnimplementedEventServiceV1Server) DeleteEvent(context.Context, *DeleteEventRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
 }
+func (UnimplementedEventServiceV1Server) RestoreEvent(context.Context, *EventIDRequest) (*EventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEvent not implemented")
+}
+func (UnimplementedEventServiceV1Server) GetEventHistory(context.Context, *EventIDRequest) (*EventHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventHistory not implemented")
+}
+func (UnimplementedEventServiceV1Server) InviteAttendees(context.Context, *InviteAttendeesRequest) (*EventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAttendees not implemented")
+}
+func (UnimplementedEventServiceV1Server) RespondInvitation(context.Context, *RespondInvitationRequest) (*EventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondInvitation not implemented")
+}
+func (UnimplementedEventServiceV1Server) ListInvitations(context.Context, *ListInvitationsRequest) (*EventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedEventServiceV1Server) ListDeletedEvents(context.Context, *emptypb.Empty) (*EventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedEvents not implemented")
+}
 func (UnimplementedEventServiceV1Server) GetEventsByPeriod(context.Context, *TimePeriodRequest) (*EventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEventsByPeriod not implemented")
 }
+func (UnimplementedEventServiceV1Server) StreamEventsByPeriod(*TimePeriodRequest, EventServiceV1_StreamEventsByPeriodServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEventsByPeriod not implemented")
+}
+func (UnimplementedEventServiceV1Server) ListDayEvents(context.Context, *DateRequest) (*EventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDayEvents not implemented")
+}
+func (UnimplementedEventServiceV1Server) ListWeekEvents(context.Context, *DateRequest) (*EventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWeekEvents not implemented")
+}
+func (UnimplementedEventServiceV1Server) ListMonthEvents(context.Context, *DateRequest) (*EventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMonthEvents not implemented")
+}
+func (UnimplementedEventServiceV1Server) SetNotificationTarget(context.Context, *NotificationTargetRequest) (*NotificationTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNotificationTarget not implemented")
+}
+func (UnimplementedEventServiceV1Server) GetNotificationTarget(context.Context, *emptypb.Empty) (*NotificationTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationTarget not implemented")
+}
 func (UnimplementedEventServiceV1Server) mustEmbedUnimplementedEventServiceV1Server() {}
 
 // UnsafeEventServiceV1Server may be embedded to opt out of forward compatibility for this service.
@@ -190,7 +417,7 @@ func _EventServiceV1_UpdateEvent_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _EventServiceV1_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EventIDRequest)
+	in := new(DeleteEventRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -202,7 +429,115 @@ func _EventServiceV1_DeleteEvent_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: EventServiceV1_DeleteEvent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EventServiceV1Server).DeleteEvent(ctx, req.(*EventIDRequest))
+		return srv.(EventServiceV1Server).DeleteEvent(ctx, req.(*DeleteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_RestoreEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).RestoreEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_RestoreEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).RestoreEvent(ctx, req.(*EventIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_GetEventHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).GetEventHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_GetEventHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).GetEventHistory(ctx, req.(*EventIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_InviteAttendees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteAttendeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).InviteAttendees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_InviteAttendees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).InviteAttendees(ctx, req.(*InviteAttendeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_RespondInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).RespondInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_RespondInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).RespondInvitation(ctx, req.(*RespondInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_ListInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).ListInvitations(ctx, req.(*ListInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_ListDeletedEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).ListDeletedEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_ListDeletedEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).ListDeletedEvents(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -225,6 +560,117 @@ func _EventServiceV1_GetEventsByPeriod_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventServiceV1_StreamEventsByPeriod_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TimePeriodRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceV1Server).StreamEventsByPeriod(m, &eventServiceV1StreamEventsByPeriodServer{stream})
+}
+
+type EventServiceV1_StreamEventsByPeriodServer interface {
+	Send(*EventResponse) error
+	grpc.ServerStream
+}
+
+type eventServiceV1StreamEventsByPeriodServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventServiceV1StreamEventsByPeriodServer) Send(m *EventResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EventServiceV1_ListDayEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).ListDayEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_ListDayEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).ListDayEvents(ctx, req.(*DateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_ListWeekEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).ListWeekEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_ListWeekEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).ListWeekEvents(ctx, req.(*DateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_ListMonthEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).ListMonthEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_ListMonthEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).ListMonthEvents(ctx, req.(*DateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_SetNotificationTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).SetNotificationTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_SetNotificationTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).SetNotificationTarget(ctx, req.(*NotificationTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventServiceV1_GetNotificationTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceV1Server).GetNotificationTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventServiceV1_GetNotificationTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceV1Server).GetNotificationTarget(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EventServiceV1_ServiceDesc is the grpc.ServiceDesc for EventServiceV1 service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -248,11 +694,61 @@ var EventServiceV1_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEvent",
 			Handler:    _EventServiceV1_DeleteEvent_Handler,
 		},
+		{
+			MethodName: "RestoreEvent",
+			Handler:    _EventServiceV1_RestoreEvent_Handler,
+		},
+		{
+			MethodName: "GetEventHistory",
+			Handler:    _EventServiceV1_GetEventHistory_Handler,
+		},
+		{
+			MethodName: "InviteAttendees",
+			Handler:    _EventServiceV1_InviteAttendees_Handler,
+		},
+		{
+			MethodName: "RespondInvitation",
+			Handler:    _EventServiceV1_RespondInvitation_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _EventServiceV1_ListInvitations_Handler,
+		},
+		{
+			MethodName: "ListDeletedEvents",
+			Handler:    _EventServiceV1_ListDeletedEvents_Handler,
+		},
 		{
 			MethodName: "GetEventsByPeriod",
 			Handler:    _EventServiceV1_GetEventsByPeriod_Handler,
 		},
+		{
+			MethodName: "ListDayEvents",
+			Handler:    _EventServiceV1_ListDayEvents_Handler,
+		},
+		{
+			MethodName: "ListWeekEvents",
+			Handler:    _EventServiceV1_ListWeekEvents_Handler,
+		},
+		{
+			MethodName: "ListMonthEvents",
+			Handler:    _EventServiceV1_ListMonthEvents_Handler,
+		},
+		{
+			MethodName: "SetNotificationTarget",
+			Handler:    _EventServiceV1_SetNotificationTarget_Handler,
+		},
+		{
+			MethodName: "GetNotificationTarget",
+			Handler:    _EventServiceV1_GetNotificationTarget_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEventsByPeriod",
+			Handler:       _EventServiceV1_StreamEventsByPeriod_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/EventService.proto",
 }
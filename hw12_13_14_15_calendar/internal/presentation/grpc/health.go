@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// isHealthMethod reports whether the full grpc method name belongs to the health service,
+// health checks don't require the user ID.
+func isHealthMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+// watchReadiness sets the serving status of the server and the event service by the readiness checks
+// until the context is done.
+func watchReadiness(ctx context.Context, readiness presentation.ReadinessChecker, healthServer *health.Server) {
+	ticker := time.NewTicker(time.Duration(common.Config.Health.CheckInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if report := readiness.Check(ctx); !report.Ready {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(pb.EventServiceV1_ServiceDesc.ServiceName, status)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
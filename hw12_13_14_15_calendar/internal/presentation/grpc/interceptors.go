@@ -2,10 +2,15 @@ package grpc
 
 import (
 	"context"
+	"net/http"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -20,6 +25,23 @@ func loggingRequestUnaryInterceptor(
 ) (interface{}, error) {
 	start := time.Now()
 	resp, err := handler(ctx, req)
+	logRequest(ctx, start, err, info.FullMethod)
+	return resp, err
+}
+
+func loggingRequestStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logRequest(ss.Context(), start, err, info.FullMethod)
+	return err
+}
+
+func logRequest(ctx context.Context, start time.Time, err error, method string) {
 	mD, exist := metadata.FromIncomingContext(ctx)
 	var userAgent, ip string
 	if exist {
@@ -30,18 +52,47 @@ func loggingRequestUnaryInterceptor(
 			userAgent = mD["user-agent"][0]
 		}
 	}
-	common.Logger.Info().Msgf(
+	common.LoggerCtx(ctx).Info().Msgf(
 		"%s [%v] %v %v %v %v \n",
 		start.Format(time.RFC3339),
 		status.Code(err),
 		time.Since(start),
 		ip,
 		userAgent,
-		info.FullMethod,
+		method,
 	)
+}
+
+// metricsUnaryInterceptor observes latency and status codes of grpc calls.
+func metricsUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRequest(start, err, info.FullMethod)
 	return resp, err
 }
 
+// metricsStreamInterceptor observes latency and status codes of grpc streams.
+func metricsStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRequest(start, err, info.FullMethod)
+	return err
+}
+
+func observeRequest(start time.Time, err error, method string) {
+	common.RPCDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
+
 func recoveryInterceptor(
 	ctx context.Context,
 	req interface{},
@@ -57,3 +108,129 @@ func recoveryInterceptor(
 	resp, err = handler(ctx, req)
 	return resp, err
 }
+
+// userIDContext returns a copy of the context with the user ID from the request metadata.
+func userIDContext(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, presentation.UserIDHeader)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, presentation.ErrUserID.Error())
+	}
+	userID, err := presentation.ParseUserID(values[0])
+	if common.IsErr(err) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return presentation.WithUserID(ctx, userID), nil
+}
+
+// userIDUnaryInterceptor puts the user ID from the request metadata to the context, except for health checks.
+func userIDUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if isHealthMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	ctx, err := userIDContext(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// contextServerStream is a server stream with a replaced context.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream context.
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// userIDStreamInterceptor puts the user ID from the request metadata to the stream context, except for health checks.
+func userIDStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if isHealthMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	ctx, err := userIDContext(ss.Context())
+	if common.IsErr(err) {
+		return err
+	}
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+func recoveryStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = status.Error(codes.Internal, "critical error on server")
+			common.Logger.Error().Msgf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// incomingHeaderMatcher forwards the user ID and If-Match HTTP headers to the grpc metadata.
+func incomingHeaderMatcher(key string) (string, bool) {
+	for _, header := range []string{presentation.UserIDHeader, presentation.IfMatchHeader} {
+		if strings.EqualFold(key, header) {
+			return header, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher sends the ETag grpc header as the HTTP one.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == presentation.ETagHeader {
+		return "ETag", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// statusResponseWriter overrides the status code of the response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusResponseWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(w.code)
+}
+
+// httpErrorHandler responds to the event version conflict with 412 Precondition Failed,
+// other errors are handled by the default handler.
+func httpErrorHandler(
+	ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error,
+) {
+	if isVersionConflict(err) {
+		w = &statusResponseWriter{ResponseWriter: w, code: http.StatusPreconditionFailed}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+// isVersionConflict reports whether the error is a rejected update of another event version.
+func isVersionConflict(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == presentation.VersionConflictReason {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"time"
@@ -11,19 +12,24 @@ import (
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
 	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/service"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type server struct {
-	grpcServer *grpc.Server
-	restServer *http.Server
+	grpcServer   *grpc.Server
+	restServer   *http.Server
+	healthServer *health.Server
+	readiness    presentation.ReadinessChecker
 }
 
-// NewServer returns a new instance of a server.
-func NewServer() presentation.Server {
-	return &server{}
+// NewServer returns a new instance of a server serving the grpc health service by the readiness checks.
+func NewServer(readiness presentation.ReadinessChecker) presentation.Server {
+	return &server{readiness: readiness}
 }
 
 // Start starts the GRPC server.
@@ -39,50 +45,91 @@ func (s *server) Start(ctx context.Context) error {
 	}
 	s.grpcServer = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
+			tracingUnaryInterceptor,
 			loggingRequestUnaryInterceptor,
+			metricsUnaryInterceptor,
 			recoveryInterceptor,
+			userIDUnaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			tracingStreamInterceptor,
+			loggingRequestStreamInterceptor,
+			metricsStreamInterceptor,
+			recoveryStreamInterceptor,
+			userIDStreamInterceptor,
 		),
 	)
 	eventService := service.NewGrpcEventService()
 	pb.RegisterEventServiceV1Server(s.grpcServer, eventService)
+	pb.RegisterCalendarServiceV1Server(s.grpcServer, service.NewGrpcCalendarService())
+	s.healthServer = health.NewServer()
+	healthpb.RegisterHealthServer(s.grpcServer, s.healthServer)
+	go watchReadiness(ctx, s.readiness, s.healthServer)
 
+	errCh := make(chan error, 2)
 	go func() {
 		if err := s.grpcServer.Serve(lis); err != nil {
-			common.Logger.Fatal().Msgf("grpc ListenAndServe(): %v", err)
+			errCh <- fmt.Errorf("grpc ListenAndServe(): %w", err)
 		}
 	}()
 
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(httpErrorHandler),
+	)
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(tracingClientUnaryInterceptor),
+		grpc.WithStreamInterceptor(tracingClientStreamInterceptor),
 	}
 	if err := pb.RegisterEventServiceV1HandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
 		return err
 	}
+	if err := pb.RegisterCalendarServiceV1HandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return err
+	}
 	grpcGWEndpoint := common.GetServerAddr(
 		common.Config.Server.GrpcGWHost,
 		common.Config.Server.GrpcGWPort,
 	)
 	s.restServer = &http.Server{
 		Addr:              grpcGWEndpoint,
-		Handler:           mux,
+		Handler:           tracingHandler(mux),
 		ReadHeaderTimeout: time.Duration(common.Config.Server.ReadHeaderTimeout) * time.Second,
 		ReadTimeout:       time.Duration(common.Config.Server.ReadTimeout) * time.Second,
 	}
+	restLis, err := net.Listen("tcp", grpcGWEndpoint)
+	if err != nil {
+		return err
+	}
 	go func() {
-		if err := s.restServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			common.Logger.Fatal().Msgf("rest grpc ListenAndServe(): %v", err)
+		if err := s.restServer.Serve(restLis); !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("rest grpc ListenAndServe(): %w", err)
 		}
 	}()
+	supervisor.Ready(ctx)
 	common.Logger.Info().Msg("grpc service started")
-	<-ctx.Done()
-	return nil
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		return err
+	}
 }
 
 // Stop stops the GRPC server.
 func (s *server) Stop(ctx context.Context) error {
 	common.Logger.Info().Msg("grpc service is stopping...")
-	s.grpcServer.Stop()
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
+	}
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+	if s.restServer == nil {
+		return nil
+	}
 	if err := s.restServer.Shutdown(ctx); common.IsErr(err) {
 		return err
 	}
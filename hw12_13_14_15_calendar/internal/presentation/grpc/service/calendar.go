@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultMeetingSlots is a number of meeting slots proposed without a slot count.
+const defaultMeetingSlots = 5
+
+// Default working hours of meeting slots.
+const (
+	defaultWorkingHoursStart = "09:00"
+	defaultWorkingHoursEnd   = "18:00"
+)
+
+type grpcCalendarService struct {
+	pb.CalendarServiceV1Server
+	service  *application.CalendarService
+	freeBusy *application.FreeBusyService
+}
+
+// NewGrpcCalendarService returns a new instance of the grpc calendar service.
+func NewGrpcCalendarService() pb.CalendarServiceV1Server {
+	return &grpcCalendarService{
+		service:  application.CalendarApplicationService,
+		freeBusy: application.FreeBusyApplicationService,
+	}
+}
+
+// calendarAccesses maps calendar accesses to their protobuf values.
+var calendarAccesses = map[domain.CalendarAccess]pb.CalendarAccess{
+	domain.CalendarAccessFreeBusy: pb.CalendarAccess_CALENDAR_ACCESS_FREE_BUSY,
+	domain.CalendarAccessRead:     pb.CalendarAccess_CALENDAR_ACCESS_READ,
+	domain.CalendarAccessWrite:    pb.CalendarAccess_CALENDAR_ACCESS_WRITE,
+	domain.CalendarAccessOwner:    pb.CalendarAccess_CALENDAR_ACCESS_OWNER,
+}
+
+// convertToCalendarAccess converts the protobuf access, the unspecified one is empty.
+func (s *grpcCalendarService) convertToCalendarAccess(access pb.CalendarAccess) domain.CalendarAccess {
+	for domainAccess, pbAccess := range calendarAccesses {
+		if pbAccess == access {
+			return domainAccess
+		}
+	}
+	return ""
+}
+
+func (s *grpcCalendarService) convertCalendar(c *domain.Calendar) *pb.Calendar {
+	calendar := &pb.Calendar{
+		Id:        c.ID,
+		Name:      c.Name,
+		Color:     c.Color,
+		TimeZone:  c.TimeZone,
+		UserId:    c.UserID,
+		IsDefault: c.Default,
+		Access:    calendarAccesses[c.Access],
+	}
+	if c.CreatedTime != nil {
+		calendar.CreatedTime = timestamppb.New(*c.CreatedTime)
+	}
+	return calendar
+}
+
+func (s *grpcCalendarService) convertToCalendar(c *pb.Calendar) *domain.Calendar {
+	return &domain.Calendar{
+		ID:       c.Id,
+		Name:     c.Name,
+		Color:    c.Color,
+		TimeZone: c.TimeZone,
+	}
+}
+
+func (s *grpcCalendarService) sharesResponse(shares []*domain.CalendarShare) *pb.CalendarSharesResponse {
+	response := &pb.CalendarSharesResponse{Shares: make([]*pb.CalendarShare, len(shares))}
+	for i, share := range shares {
+		response.Shares[i] = &pb.CalendarShare{UserId: share.UserID, Access: calendarAccesses[share.Access]}
+	}
+	return response
+}
+
+func (s *grpcCalendarService) convertPeriods(periods []domain.Period) []*pb.TimePeriod {
+	result := make([]*pb.TimePeriod, len(periods))
+	for i, p := range periods {
+		result[i] = &pb.TimePeriod{StartTime: timestamppb.New(p.StartTime), EndTime: timestamppb.New(p.EndTime)}
+	}
+	return result
+}
+
+// convertToMeetingQuery converts the request filling in the default slot count and working hours.
+func (s *grpcCalendarService) convertToMeetingQuery(request *pb.MeetingSlotsRequest) (*domain.MeetingQuery, error) {
+	location, err := time.LoadLocation(request.TimeZone)
+	if common.IsErr(err) {
+		return nil, fmt.Errorf("%w: unknown time zone %q", domain.ErrWorkingHours, request.TimeZone)
+	}
+	query := &domain.MeetingQuery{
+		UserIDs:   request.UserIds,
+		StartTime: request.StartTime.AsTime(),
+		EndTime:   request.EndTime.AsTime(),
+		Duration:  request.Duration.AsDuration(),
+		Hours: domain.WorkingHours{
+			Start:    s.parseClock(request.WorkingHoursStart, defaultWorkingHoursStart),
+			End:      s.parseClock(request.WorkingHoursEnd, defaultWorkingHoursEnd),
+			Location: location,
+			Weekends: request.IncludeWeekends,
+		},
+		Limit: int(request.SlotCount),
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultMeetingSlots
+	}
+	return query, nil
+}
+
+// parseClock returns the offset from midnight of the HH:MM clock validated by the request rules,
+// the default clock is used if it's empty.
+func (s *grpcCalendarService) parseClock(clock, defaultClock string) time.Duration {
+	if clock == "" {
+		clock = defaultClock
+	}
+	var hours, minutes time.Duration
+	_, _ = fmt.Sscanf(clock, "%d:%d", &hours, &minutes)
+	return hours*time.Hour + minutes*time.Minute
+}
+
+// calendarError returns the status of an error of a calendar call.
+func calendarError(err error, format string, args ...interface{}) error {
+	if errors.Is(err, domain.ErrCalendarNotExist) {
+		return status.Errorf(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, domain.ErrPermission) {
+		return status.Errorf(codes.PermissionDenied, err.Error())
+	}
+	for _, domainErr := range []error{
+		domain.ErrUUID, domain.ErrCalendar, domain.ErrCalendarShare, domain.ErrFreeBusyPeriod, domain.ErrWorkingHours,
+	} {
+		if errors.Is(err, domainErr) {
+			return status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+	if errors.Is(err, domain.ErrCalendarNotEmpty) || errors.Is(err, domain.ErrDefaultCalendar) {
+		return status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	return unknownError(err, format, args...)
+}
+
+// CreateCalendar adds a new calendar of the user.
+func (s *grpcCalendarService) CreateCalendar(
+	ctx context.Context,
+	calendarRequest *pb.CalendarRequest,
+) (*pb.CalendarResponse, error) {
+	err := calendarRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	calendar := s.convertToCalendar(calendarRequest.Calendar)
+	if err := s.service.Create(ctx, userID, calendar); common.IsErr(err) {
+		return nil, calendarError(err, "error creating calendar: %v", err)
+	}
+	return &pb.CalendarResponse{Calendar: s.convertCalendar(calendar)}, nil
+}
+
+// GetCalendar returns a calendar by ID.
+func (s *grpcCalendarService) GetCalendar(
+	ctx context.Context,
+	calendarIDRequest *pb.CalendarIDRequest,
+) (*pb.CalendarResponse, error) {
+	err := calendarIDRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	calendar, err := s.service.Get(ctx, userID, calendarIDRequest.Id)
+	if common.IsErr(err) {
+		return nil, calendarError(err, "error getting calendar: %v", err)
+	}
+	return &pb.CalendarResponse{Calendar: s.convertCalendar(calendar)}, nil
+}
+
+// ListCalendars returns a list of calendars of the user and calendars shared with the user.
+func (s *grpcCalendarService) ListCalendars(ctx context.Context, _ *emptypb.Empty) (*pb.CalendarsResponse, error) {
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	calendars, err := s.service.List(ctx, userID)
+	if common.IsErr(err) {
+		return nil, unknownError(err, "error listing calendars: %v", err)
+	}
+	response := &pb.CalendarsResponse{Calendars: make([]*pb.Calendar, len(calendars))}
+	for i, c := range calendars {
+		response.Calendars[i] = s.convertCalendar(c)
+	}
+	return response, nil
+}
+
+// UpdateCalendar updates a calendar of the user.
+func (s *grpcCalendarService) UpdateCalendar(
+	ctx context.Context,
+	calendarRequest *pb.CalendarRequest,
+) (*pb.CalendarResponse, error) {
+	err := calendarRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	calendar := s.convertToCalendar(calendarRequest.Calendar)
+	if err := s.service.Update(ctx, userID, calendar); common.IsErr(err) {
+		return nil, calendarError(err, "error updating calendar: %v", err)
+	}
+	updated, err := s.service.Get(ctx, userID, calendar.ID)
+	if common.IsErr(err) {
+		return nil, calendarError(err, "error getting calendar: %v", err)
+	}
+	return &pb.CalendarResponse{Calendar: s.convertCalendar(updated)}, nil
+}
+
+// DeleteCalendar deletes an empty calendar of the user.
+func (s *grpcCalendarService) DeleteCalendar(
+	ctx context.Context,
+	calendarIDRequest *pb.CalendarIDRequest,
+) (*emptypb.Empty, error) {
+	err := calendarIDRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if err := s.service.Delete(ctx, userID, calendarIDRequest.Id); common.IsErr(err) {
+		return nil, calendarError(err, "error deleting calendar: %v", err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+// ShareCalendar grants an access to a calendar of the user and returns the calendar shares.
+func (s *grpcCalendarService) ShareCalendar(
+	ctx context.Context,
+	shareRequest *pb.ShareCalendarRequest,
+) (*pb.CalendarSharesResponse, error) {
+	err := shareRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	share := &domain.CalendarShare{
+		UserID: shareRequest.UserId,
+		Access: s.convertToCalendarAccess(shareRequest.Access),
+	}
+	if err := s.service.Share(ctx, userID, shareRequest.Id, share); common.IsErr(err) {
+		return nil, calendarError(err, "error sharing calendar: %v", err)
+	}
+	return s.listShares(ctx, userID, shareRequest.Id)
+}
+
+// UnshareCalendar revokes an access to a calendar of the user.
+func (s *grpcCalendarService) UnshareCalendar(
+	ctx context.Context,
+	unshareRequest *pb.UnshareCalendarRequest,
+) (*emptypb.Empty, error) {
+	err := unshareRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if err := s.service.Unshare(ctx, userID, unshareRequest.Id, unshareRequest.UserId); common.IsErr(err) {
+		return nil, calendarError(err, "error unsharing calendar: %v", err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+// ListCalendarShares returns a list of shares of a calendar of the user.
+func (s *grpcCalendarService) ListCalendarShares(
+	ctx context.Context,
+	calendarIDRequest *pb.CalendarIDRequest,
+) (*pb.CalendarSharesResponse, error) {
+	err := calendarIDRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return s.listShares(ctx, userID, calendarIDRequest.Id)
+}
+
+func (s *grpcCalendarService) listShares(
+	ctx context.Context, userID int64, calendarID string,
+) (*pb.CalendarSharesResponse, error) {
+	shares, err := s.service.Shares(ctx, userID, calendarID)
+	if common.IsErr(err) {
+		return nil, calendarError(err, "error listing calendar shares: %v", err)
+	}
+	return s.sharesResponse(shares), nil
+}
+
+// QueryFreeBusy returns busy periods of the users visible to the current user.
+func (s *grpcCalendarService) QueryFreeBusy(
+	ctx context.Context,
+	freeBusyRequest *pb.FreeBusyRequest,
+) (*pb.FreeBusyResponse, error) {
+	err := freeBusyRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	freeBusy, err := s.freeBusy.Query(
+		ctx, userID, freeBusyRequest.UserIds, freeBusyRequest.StartTime.AsTime(), freeBusyRequest.EndTime.AsTime(),
+	)
+	if common.IsErr(err) {
+		return nil, calendarError(err, "error querying free/busy: %v", err)
+	}
+	response := &pb.FreeBusyResponse{Users: make([]*pb.UserFreeBusy, len(freeBusy))}
+	for i, fb := range freeBusy {
+		response.Users[i] = &pb.UserFreeBusy{UserId: fb.UserID, Busy: s.convertPeriods(fb.Busy)}
+	}
+	return response, nil
+}
+
+// FindMeetingSlots proposes free slots for a meeting of the current user and the users.
+func (s *grpcCalendarService) FindMeetingSlots(
+	ctx context.Context,
+	slotsRequest *pb.MeetingSlotsRequest,
+) (*pb.MeetingSlotsResponse, error) {
+	err := slotsRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := requestUserID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	query, err := s.convertToMeetingQuery(slotsRequest)
+	if common.IsErr(err) {
+		return nil, calendarError(err, "error finding meeting slots: %v", err)
+	}
+	slots, err := s.freeBusy.FindMeetingSlots(ctx, userID, query)
+	if common.IsErr(err) {
+		return nil, calendarError(err, "error finding meeting slots: %v", err)
+	}
+	return &pb.MeetingSlotsResponse{Slots: s.convertPeriods(slots)}, nil
+}
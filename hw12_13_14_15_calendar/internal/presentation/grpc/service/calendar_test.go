@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests/mocks"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestCalendarService(calendars domain.CalendarRepository) *grpcCalendarService {
+	return &grpcCalendarService{service: application.NewCalendarService(calendars)}
+}
+
+func TestGrpcCalendarService_CreateCalendar(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepository)
+	mockRepo.On("Add", mock.Anything, mock.MatchedBy(func(c *domain.Calendar) bool {
+		return c.UserID == 7 && c.Name == "Work" && c.TimeZone == "UTC" && !c.Default
+	})).Run(func(args mock.Arguments) {
+		createdTime := time.Now().UTC()
+		args[1].(*domain.Calendar).CreatedTime = &createdTime
+	}).Return(nil)
+
+	s := newTestCalendarService(mockRepo)
+	result, err := s.CreateCalendar(
+		userContext(7), &pb.CalendarRequest{Calendar: &pb.Calendar{Name: "Work", Color: "#1a73e8", IsDefault: true}},
+	)
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Calendar.Id)
+	require.Equal(t, "#1a73e8", result.Calendar.Color)
+	require.False(t, result.Calendar.IsDefault)
+	require.Equal(t, pb.CalendarAccess_CALENDAR_ACCESS_OWNER, result.Calendar.Access)
+	require.NotNil(t, result.Calendar.CreatedTime)
+
+	_, err = s.CreateCalendar(userContext(7), &pb.CalendarRequest{Calendar: &pb.Calendar{Name: "Work", Color: "red"}})
+	require.Error(t, err)
+	_, err = s.CreateCalendar(
+		userContext(7), &pb.CalendarRequest{Calendar: &pb.Calendar{Name: "Work", TimeZone: "Mars/Olympus"}},
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.CreateCalendar(context.Background(), &pb.CalendarRequest{Calendar: &pb.Calendar{Name: "Work"}})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGrpcCalendarService_GetCalendar(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepository)
+	calendar := &domain.Calendar{ID: faker.UUIDHyphenated(), UserID: 7, Name: "Work", Access: domain.CalendarAccessRead}
+	mockRepo.On("Get", mock.Anything, int64(8), calendar.ID).Return(calendar, nil)
+	mockRepo.On("Get", mock.Anything, int64(9), calendar.ID).Return(nil, domain.ErrPermission)
+	missingID := faker.UUIDHyphenated()
+	mockRepo.On("Get", mock.Anything, int64(8), missingID).Return(nil, domain.ErrCalendarNotExist)
+
+	s := newTestCalendarService(mockRepo)
+	result, err := s.GetCalendar(userContext(8), &pb.CalendarIDRequest{Id: calendar.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), result.Calendar.UserId)
+	require.Equal(t, pb.CalendarAccess_CALENDAR_ACCESS_READ, result.Calendar.Access)
+	_, err = s.GetCalendar(userContext(9), &pb.CalendarIDRequest{Id: calendar.ID})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.GetCalendar(userContext(8), &pb.CalendarIDRequest{Id: missingID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.GetCalendar(userContext(8), &pb.CalendarIDRequest{Id: "invalid"})
+	require.Error(t, err)
+}
+
+func TestGrpcCalendarService_ListCalendars(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepository)
+	calendars := []*domain.Calendar{domain.NewDefaultCalendar(7)}
+	mockRepo.On("GetCalendars", mock.Anything, int64(7)).Return(calendars, nil)
+
+	s := newTestCalendarService(mockRepo)
+	result, err := s.ListCalendars(userContext(7), new(emptypb.Empty))
+	require.NoError(t, err)
+	require.Len(t, result.Calendars, 1)
+	require.True(t, result.Calendars[0].IsDefault)
+	require.Equal(t, domain.DefaultCalendarName, result.Calendars[0].Name)
+}
+
+func TestGrpcCalendarService_UpdateCalendar(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepository)
+	calendar := &domain.Calendar{ID: faker.UUIDHyphenated(), UserID: 7, Name: "Renamed", TimeZone: "Europe/Moscow"}
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Calendar) bool {
+		return c.UserID == 7 && c.Name == "Renamed"
+	})).Return(nil)
+	mockRepo.On("Get", mock.Anything, int64(7), calendar.ID).Return(calendar, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Calendar) bool {
+		return c.UserID == 8
+	})).Return(domain.ErrPermission)
+
+	s := newTestCalendarService(mockRepo)
+	request := &pb.CalendarRequest{Calendar: &pb.Calendar{Id: calendar.ID, Name: "Renamed", TimeZone: "Europe/Moscow"}}
+	result, err := s.UpdateCalendar(userContext(7), request)
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Moscow", result.Calendar.TimeZone)
+	_, err = s.UpdateCalendar(userContext(8), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	request.Calendar.Id = ""
+	_, err = s.UpdateCalendar(userContext(7), request)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcCalendarService_DeleteCalendar(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepository)
+	calendarID, defaultID, emptyID := faker.UUIDHyphenated(), faker.UUIDHyphenated(), faker.UUIDHyphenated()
+	mockRepo.On("Delete", mock.Anything, int64(7), calendarID).Return(domain.ErrCalendarNotEmpty)
+	mockRepo.On("Delete", mock.Anything, int64(7), defaultID).Return(domain.ErrDefaultCalendar)
+	mockRepo.On("Delete", mock.Anything, int64(7), emptyID).Return(nil)
+
+	s := newTestCalendarService(mockRepo)
+	_, err := s.DeleteCalendar(userContext(7), &pb.CalendarIDRequest{Id: calendarID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.DeleteCalendar(userContext(7), &pb.CalendarIDRequest{Id: defaultID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	result, err := s.DeleteCalendar(userContext(7), &pb.CalendarIDRequest{Id: emptyID})
+	require.NoError(t, err)
+	require.Equal(t, new(emptypb.Empty), result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcCalendarService_Shares(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepository)
+	calendarID := faker.UUIDHyphenated()
+	share := &domain.CalendarShare{UserID: 8, Access: domain.CalendarAccessFreeBusy}
+	mockRepo.On("Share", mock.Anything, int64(7), calendarID, share).Return(nil)
+	mockRepo.On("Share", mock.Anything, int64(8), calendarID, mock.Anything).Return(domain.ErrPermission)
+	mockRepo.On("GetShares", mock.Anything, int64(7), calendarID).Return([]*domain.CalendarShare{share}, nil)
+	mockRepo.On("Unshare", mock.Anything, int64(7), calendarID, int64(8)).Return(nil)
+
+	s := newTestCalendarService(mockRepo)
+	request := &pb.ShareCalendarRequest{Id: calendarID, UserId: 8, Access: pb.CalendarAccess_CALENDAR_ACCESS_FREE_BUSY}
+	result, err := s.ShareCalendar(userContext(7), request)
+	require.NoError(t, err)
+	require.Equal(
+		t, []*pb.CalendarShare{{UserId: 8, Access: pb.CalendarAccess_CALENDAR_ACCESS_FREE_BUSY}}, result.Shares,
+	)
+	_, err = s.ShareCalendar(userContext(8), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	request.Access = pb.CalendarAccess_CALENDAR_ACCESS_OWNER
+	_, err = s.ShareCalendar(userContext(7), request)
+	require.Error(t, err)
+
+	shares, err := s.ListCalendarShares(userContext(7), &pb.CalendarIDRequest{Id: calendarID})
+	require.NoError(t, err)
+	require.Len(t, shares.Shares, 1)
+	_, err = s.UnshareCalendar(userContext(7), &pb.UnshareCalendarRequest{Id: calendarID, UserId: 8})
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_AddEventToSharedCalendar(t *testing.T) {
+	events := new(mocks.EventRepository)
+	calendars := new(mocks.CalendarRepository)
+	event := tests.GenerateTestEvent()
+	writer, reader := event.UserID+1, event.UserID+2
+	event.CalendarID = faker.UUIDHyphenated()
+	calendars.On("Get", mock.Anything, writer, event.CalendarID).
+		Return(&domain.Calendar{ID: event.CalendarID, UserID: event.UserID, Access: domain.CalendarAccessWrite}, nil)
+	calendars.On("Get", mock.Anything, reader, event.CalendarID).
+		Return(&domain.Calendar{ID: event.CalendarID, UserID: event.UserID, Access: domain.CalendarAccessRead}, nil)
+	events.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	events.On("Add", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+		return e.UserID == event.UserID && e.CalendarID == event.CalendarID
+	})).Run(func(args mock.Arguments) {
+		createdTime := time.Now().UTC()
+		args[1].(*domain.Event).CreatedTime = &createdTime
+	}).Return(nil)
+
+	s := newTestService(events)
+	s.service = application.NewEventService(events, calendars)
+	request := tests.CreateTestEventRequest(event)
+	request.Event.UserId = 0
+	request.Event.CalendarId = event.CalendarID
+	result, err := s.CreateEvent(userContext(writer), request)
+	require.NoError(t, err)
+	require.Equal(t, event.UserID, result.Event.UserId)
+	require.Equal(t, event.CalendarID, result.Event.CalendarId)
+	_, err = s.CreateEvent(userContext(reader), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	events.AssertExpectations(t)
+}
+
+func newTestFreeBusyService(
+	events domain.EventRepository, calendars domain.CalendarRepository,
+) *grpcCalendarService {
+	return &grpcCalendarService{freeBusy: application.NewFreeBusyService(events, calendars)}
+}
+
+func testEvent(userID int64, calendarID string, startTime time.Time, duration time.Duration) *domain.Event {
+	endTime := startTime.Add(duration)
+	return &domain.Event{
+		ID: faker.UUIDHyphenated(), UserID: userID, CalendarID: calendarID, StartTime: startTime, EndTime: &endTime,
+	}
+}
+
+func TestGrpcCalendarService_QueryFreeBusy(t *testing.T) {
+	events := new(mocks.EventRepository)
+	calendars := new(mocks.CalendarRepository)
+	startTime := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(24 * time.Hour)
+	sharedID, privateID := faker.UUIDHyphenated(), faker.UUIDHyphenated()
+	calendars.On("Get", mock.Anything, int64(7), sharedID).
+		Return(&domain.Calendar{ID: sharedID, UserID: 8, Access: domain.CalendarAccessFreeBusy}, nil).Once()
+	calendars.On("Get", mock.Anything, int64(7), privateID).Return(nil, domain.ErrPermission).Once()
+	events.On("GetOverlappingEvents", mock.Anything, int64(7), startTime, endTime).Return([]*domain.Event{
+		testEvent(7, faker.UUIDHyphenated(), startTime.Add(9*time.Hour), time.Hour),
+	}, nil)
+	events.On("GetOverlappingEvents", mock.Anything, int64(8), startTime, endTime).Return([]*domain.Event{
+		testEvent(8, sharedID, startTime.Add(10*time.Hour), time.Hour),
+		testEvent(8, sharedID, startTime.Add(10*time.Hour+30*time.Minute), time.Hour),
+		testEvent(8, privateID, startTime.Add(14*time.Hour), time.Hour),
+	}, nil)
+
+	s := newTestFreeBusyService(events, calendars)
+	request := &pb.FreeBusyRequest{
+		UserIds: []int64{8, 7}, StartTime: timestamppb.New(startTime), EndTime: timestamppb.New(endTime),
+	}
+	result, err := s.QueryFreeBusy(userContext(7), request)
+	require.NoError(t, err)
+	require.Len(t, result.Users, 2)
+	require.Equal(t, int64(8), result.Users[0].UserId)
+	require.Len(t, result.Users[0].Busy, 1)
+	require.Equal(t, startTime.Add(10*time.Hour), result.Users[0].Busy[0].StartTime.AsTime())
+	require.Equal(t, startTime.Add(11*time.Hour+30*time.Minute), result.Users[0].Busy[0].EndTime.AsTime())
+	require.Equal(t, int64(7), result.Users[1].UserId)
+	require.Len(t, result.Users[1].Busy, 1)
+	calendars.AssertExpectations(t)
+
+	request.EndTime = timestamppb.New(startTime.Add(domain.FreeBusyMaxPeriod + time.Hour))
+	_, err = s.QueryFreeBusy(userContext(7), request)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	request.UserIds = nil
+	_, err = s.QueryFreeBusy(userContext(7), request)
+	require.Error(t, err)
+}
+
+func TestGrpcCalendarService_FindMeetingSlots(t *testing.T) {
+	events := new(mocks.EventRepository)
+	calendars := new(mocks.CalendarRepository)
+	calendarID := faker.UUIDHyphenated()
+	location, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	startTime := time.Date(2024, 1, 8, 0, 0, 0, 0, location)
+	endTime := startTime.Add(48 * time.Hour)
+	calendars.On("Get", mock.Anything, int64(7), calendarID).
+		Return(&domain.Calendar{ID: calendarID, UserID: 8, Access: domain.CalendarAccessRead}, nil)
+	events.On("GetOverlappingEvents", mock.Anything, int64(7), mock.Anything, mock.Anything).Return([]*domain.Event{
+		testEvent(7, faker.UUIDHyphenated(), startTime.Add(10*time.Hour), 2*time.Hour),
+	}, nil)
+	events.On("GetOverlappingEvents", mock.Anything, int64(8), mock.Anything, mock.Anything).Return([]*domain.Event{
+		testEvent(8, calendarID, startTime.Add(12*time.Hour), 5*time.Hour+50*time.Minute),
+	}, nil)
+
+	s := newTestFreeBusyService(events, calendars)
+	request := &pb.MeetingSlotsRequest{
+		UserIds:           []int64{7, 8},
+		StartTime:         timestamppb.New(startTime),
+		EndTime:           timestamppb.New(endTime),
+		Duration:          durationpb.New(time.Hour),
+		SlotCount:         3,
+		WorkingHoursStart: "09:30",
+		TimeZone:          "Europe/Moscow",
+	}
+	result, err := s.FindMeetingSlots(userContext(7), request)
+	require.NoError(t, err)
+	// Monday is busy after 09:30 until the end of working hours, slots are proposed on Tuesday.
+	require.Len(t, result.Slots, 3)
+	require.Equal(t, startTime.Add(33*time.Hour+30*time.Minute).UTC(), result.Slots[0].StartTime.AsTime())
+	require.Equal(t, startTime.Add(34*time.Hour+30*time.Minute).UTC(), result.Slots[0].EndTime.AsTime())
+	require.Equal(t, startTime.Add(35*time.Hour+30*time.Minute).UTC(), result.Slots[2].StartTime.AsTime())
+	events.AssertNumberOfCalls(t, "GetOverlappingEvents", 2)
+
+	request.TimeZone = "Mars/Olympus"
+	_, err = s.FindMeetingSlots(userContext(7), request)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	request.TimeZone = ""
+	request.WorkingHoursEnd = "09:00"
+	_, err = s.FindMeetingSlots(userContext(7), request)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	request.Duration = durationpb.New(0)
+	_, err = s.FindMeetingSlots(userContext(7), request)
+	require.Error(t, err)
+}
@@ -8,25 +8,49 @@ import (
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
 	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type grpcEventService struct {
 	pb.EventServiceV1Server
-	service *application.EventService
+	service       *application.EventService
+	notifications *application.NotificationService
+	idempotency   *application.IdempotencyService
 }
 
 // NewGrpcEventService returns a new instance of the grpc event service.
 func NewGrpcEventService() pb.EventServiceV1Server {
 	return &grpcEventService{
-		service: application.EventApplicationService,
+		service:       application.EventApplicationService,
+		notifications: application.NotificationApplicationService,
+		idempotency:   application.IdempotencyApplicationService,
 	}
 }
 
+// userID returns ID of the user making the request.
+func (s *grpcEventService) userID(ctx context.Context) (int64, error) {
+	return requestUserID(ctx)
+}
+
+// requestUserID returns ID of the user making the request or the Unauthenticated status.
+func requestUserID(ctx context.Context) (int64, error) {
+	userID, err := presentation.UserIDFromContext(ctx)
+	if common.IsErr(err) {
+		return 0, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	return userID, nil
+}
+
 func (s *grpcEventService) convertEventTimestamp(t *time.Time) *timestamppb.Timestamp {
 	if t == nil {
 		return nil
@@ -34,15 +58,122 @@ func (s *grpcEventService) convertEventTimestamp(t *time.Time) *timestamppb.Time
 	return timestamppb.New(*t)
 }
 
+func (s *grpcEventService) convertRecurrence(r *domain.Recurrence) *pb.Recurrence {
+	if r == nil {
+		return nil
+	}
+	exceptions := make([]*timestamppb.Timestamp, len(r.Exceptions))
+	for i, t := range r.Exceptions {
+		exceptions[i] = timestamppb.New(t)
+	}
+	return &pb.Recurrence{Rule: r.Rule(), Exceptions: exceptions}
+}
+
+func (s *grpcEventService) convertNotificationTarget(t *domain.NotificationTarget) *pb.NotificationTarget {
+	if t == nil {
+		return nil
+	}
+	return &pb.NotificationTarget{Channel: t.Channel, Address: t.Address}
+}
+
+func (s *grpcEventService) convertReminders(reminders []*domain.Reminder) []*pb.Reminder {
+	if reminders == nil {
+		return nil
+	}
+	result := make([]*pb.Reminder, len(reminders))
+	for i, r := range reminders {
+		if r.Time != nil {
+			result[i] = &pb.Reminder{Time: timestamppb.New(*r.Time)}
+		} else {
+			result[i] = &pb.Reminder{Offset: durationpb.New(r.Offset)}
+		}
+	}
+	return result
+}
+
+func (s *grpcEventService) convertToReminders(reminders []*pb.Reminder) []*domain.Reminder {
+	if reminders == nil {
+		return nil
+	}
+	result := make([]*domain.Reminder, len(reminders))
+	for i, r := range reminders {
+		result[i] = &domain.Reminder{Offset: r.Offset.AsDuration()}
+		if r.Time != nil {
+			t := r.Time.AsTime()
+			result[i].Time = &t
+		}
+	}
+	return result
+}
+
+// attendeeStatuses maps attendee statuses to their protobuf values.
+var attendeeStatuses = map[domain.AttendeeStatus]pb.AttendeeStatus{
+	domain.AttendeeInvited:   pb.AttendeeStatus_ATTENDEE_STATUS_INVITED,
+	domain.AttendeeAccepted:  pb.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED,
+	domain.AttendeeDeclined:  pb.AttendeeStatus_ATTENDEE_STATUS_DECLINED,
+	domain.AttendeeTentative: pb.AttendeeStatus_ATTENDEE_STATUS_TENTATIVE,
+}
+
+func (s *grpcEventService) convertAttendees(attendees []*domain.Attendee) []*pb.Attendee {
+	if attendees == nil {
+		return nil
+	}
+	result := make([]*pb.Attendee, len(attendees))
+	for i, a := range attendees {
+		result[i] = &pb.Attendee{UserId: a.UserID, Status: attendeeStatuses[a.Status]}
+	}
+	return result
+}
+
+// convertToAttendeeStatus converts the protobuf status, the unspecified one is empty.
+func (s *grpcEventService) convertToAttendeeStatus(status pb.AttendeeStatus) domain.AttendeeStatus {
+	for domainStatus, pbStatus := range attendeeStatuses {
+		if pbStatus == status {
+			return domainStatus
+		}
+	}
+	return ""
+}
+
 func (s *grpcEventService) convertEvent(e *domain.Event) *pb.Event {
 	return &pb.Event{
-		Id:          e.ID,
-		Title:       e.Title,
-		StartTime:   timestamppb.New(e.StartTime),
-		EndTime:     s.convertEventTimestamp(e.EndTime),
-		NotifyTime:  s.convertEventTimestamp(e.NotifyTime),
-		UserId:      e.UserID,
-		CreatedTime: timestamppb.New(*e.CreatedTime),
+		Id:                 e.ID,
+		Title:              e.Title,
+		StartTime:          timestamppb.New(e.StartTime),
+		EndTime:            s.convertEventTimestamp(e.EndTime),
+		UserId:             e.UserID,
+		CreatedTime:        timestamppb.New(*e.CreatedTime),
+		Recurrence:         s.convertRecurrence(e.Recurrence),
+		RecurrenceId:       s.convertEventTimestamp(e.RecurrenceID),
+		NotificationTarget: s.convertNotificationTarget(e.NotificationTarget),
+		DeletedTime:        s.convertEventTimestamp(e.DeletedTime),
+		Version:            e.Version,
+		Attendees:          s.convertAttendees(e.Attendees),
+		CalendarId:         e.CalendarID,
+		Reminders:          s.convertReminders(e.Reminders),
+		TimeZone:           e.TimeZone,
+		AllDay:             e.AllDay,
+	}
+}
+
+// convertEventOrNil converts the event, a missing event stays nil.
+func (s *grpcEventService) convertEventOrNil(e *domain.Event) *pb.Event {
+	if e == nil {
+		return nil
+	}
+	return s.convertEvent(e)
+}
+
+func (s *grpcEventService) convertEventChange(c *domain.EventChange) *pb.EventChange {
+	return &pb.EventChange{
+		Id:        c.ID,
+		EventId:   c.EventID,
+		Action:    c.Action,
+		ActorId:   c.ActorID,
+		RequestId: c.RequestID,
+		Before:    s.convertEventOrNil(c.Before),
+		After:     s.convertEventOrNil(c.After),
+		Time:      timestamppb.New(c.Time),
 	}
 }
 
@@ -60,130 +191,722 @@ func (s *grpcEventService) eventsResponse(events []*domain.Event) *pb.EventsResp
 	return &pb.EventsResponse{Events: pbEvents}
 }
 
-func (s *grpcEventService) convertToEvent(e *pb.Event) *domain.Event {
-	var (
-		endTime    *time.Time
-		notifyTime *time.Time
-	)
+func (s *grpcEventService) convertToRecurrence(r *pb.Recurrence) (*domain.Recurrence, error) {
+	if r == nil {
+		return nil, nil
+	}
+	recurrence, err := domain.ParseRecurrenceRule(r.Rule)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	for _, t := range r.Exceptions {
+		recurrence.Exceptions = append(recurrence.Exceptions, t.AsTime())
+	}
+	return recurrence, nil
+}
+
+func (s *grpcEventService) convertToNotificationTarget(t *pb.NotificationTarget) *domain.NotificationTarget {
+	if t == nil {
+		return nil
+	}
+	return &domain.NotificationTarget{Channel: t.Channel, Address: t.Address}
+}
+
+func (s *grpcEventService) convertToFilter(r *pb.TimePeriodRequest) (*domain.EventFilter, error) {
+	filter := &domain.EventFilter{
+		OwnerID:    r.UserId,
+		StartTime:  r.StartTime.AsTime(),
+		EndTime:    r.EndTime.AsTime(),
+		Title:      r.Title,
+		CalendarID: r.CalendarId,
+		Descending: r.Order == pb.SortOrder_SORT_ORDER_START_TIME_DESC,
+		PageSize:   int(r.PageSize),
+	}
+	if r.HasNotification != nil {
+		hasNotification := r.HasNotification.Value
+		filter.HasNotification = &hasNotification
+	}
+	if r.PageToken != "" {
+		cursor, err := domain.ParseEventCursor(r.PageToken)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		filter.Cursor = cursor
+	}
+	return filter, nil
+}
+
+func (s *grpcEventService) convertToEvent(e *pb.Event) (*domain.Event, error) {
+	var endTime *time.Time
 	if e.EndTime != nil {
 		t := e.EndTime.AsTime()
 		endTime = &t
 	}
-	if e.NotifyTime != nil {
-		t := e.NotifyTime.AsTime()
-		notifyTime = &t
+	recurrence, err := s.convertToRecurrence(e.Recurrence)
+	if common.IsErr(err) {
+		return nil, err
 	}
 	return &domain.Event{
-		ID:          e.Id,
-		Title:       e.Title,
-		StartTime:   e.StartTime.AsTime(),
-		EndTime:     endTime,
-		NotifyTime:  notifyTime,
-		Description: e.Description,
-		UserID:      e.UserId,
+		ID:                 e.Id,
+		Title:              e.Title,
+		StartTime:          e.StartTime.AsTime(),
+		EndTime:            endTime,
+		Description:        e.Description,
+		UserID:             e.UserId,
+		Recurrence:         recurrence,
+		NotificationTarget: s.convertToNotificationTarget(e.NotificationTarget),
+		Version:            e.Version,
+		CalendarID:         e.CalendarId,
+		Reminders:          s.convertToReminders(e.Reminders),
+		TimeZone:           e.TimeZone,
+		AllDay:             e.AllDay,
+	}, nil
+}
+
+// unknownError returns the status of an unexpected error of the call, canceled and timed out calls
+// keep their codes.
+func unknownError(err error, format string, args ...interface{}) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Errorf(codes.Unknown, format, args...)
+}
+
+// setETag sends the ETag of the event version in the response header.
+func (s *grpcEventService) setETag(ctx context.Context, e *pb.Event) {
+	err := grpc.SetHeader(ctx, metadata.Pairs(presentation.ETagHeader, presentation.FormatETag(e.Version)))
+	if common.IsErr(err) {
+		common.LoggerCtx(ctx).Debug().Msgf("failed to set ETag: %v", err)
+	}
+}
+
+// expectedVersion returns the event version expected by the update: the If-Match one if it's set
+// or the one of the request.
+func (s *grpcEventService) expectedVersion(ctx context.Context, version int64) (int64, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, presentation.IfMatchHeader); len(values) > 0 {
+		ifMatch, err := presentation.ParseETag(values[0])
+		if common.IsErr(err) {
+			return 0, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		version = ifMatch
+	}
+	if version <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "event version is required")
+	}
+	return version, nil
+}
+
+// versionConflictError returns the status of an update of another event version, the gateway responds to it
+// with 412 Precondition Failed.
+func versionConflictError(err error) error {
+	st, detailsErr := status.New(codes.Aborted, err.Error()).WithDetails(
+		&errdetails.ErrorInfo{Reason: presentation.VersionConflictReason},
+	)
+	if common.IsErr(detailsErr) {
+		return status.Errorf(codes.Aborted, err.Error())
+	}
+	return st.Err()
+}
+
+// idempotentRequest is a write request which may be retried with the same request ID.
+type idempotentRequest interface {
+	proto.Message
+	GetRequestId() string
+}
+
+// idempotent calls the method for the request of the user once per request ID, retries of the request
+// get the response of the first successful call. Requests without a request ID are called every time.
+func idempotent[T proto.Message](
+	ctx context.Context,
+	s *grpcEventService,
+	userID int64,
+	method string,
+	request idempotentRequest,
+	call func(ctx context.Context) (T, error),
+) (T, error) {
+	var response T
+	if request.GetRequestId() == "" {
+		return call(ctx)
+	}
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if common.IsErr(err) {
+		return response, unknownError(err, "error encoding request: %v", err)
+	}
+	called := false
+	key := domain.NewIdempotentRequest(userID, request.GetRequestId(), method, payload)
+	data, err := s.idempotency.Do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		var err error
+		called = true
+		if response, err = call(ctx); common.IsErr(err) {
+			return nil, err
+		}
+		return proto.Marshal(response)
+	})
+	if common.IsErr(err) {
+		var empty T
+		if errors.Is(err, domain.ErrRequestIDReused) {
+			return empty, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrRequestInProgress) {
+			return empty, status.Errorf(codes.Aborted, err.Error())
+		}
+		if _, ok := status.FromError(err); ok {
+			return empty, err
+		}
+		return empty, unknownError(err, "error processing request: %v", err)
 	}
+	if called {
+		return response, nil
+	}
+	response = response.ProtoReflect().Type().New().Interface().(T)
+	if err := proto.Unmarshal(data, response); common.IsErr(err) {
+		return response, unknownError(err, "error decoding stored response: %v", err)
+	}
+	return response, nil
 }
 
 // GetEvent returns an event by ID.
 func (s *grpcEventService) GetEvent(
-	_ context.Context,
+	ctx context.Context,
 	eventID *pb.EventIDRequest,
 ) (*pb.EventResponse, error) {
 	err := eventID.ValidateAll()
 	if common.IsErr(err) {
 		return nil, err
 	}
-	event, err := s.service.Get(eventID.Id)
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	event, err := s.service.Get(ctx, userID, eventID.Id)
 	if common.IsErr(err) {
 		if errors.Is(err, domain.ErrEventNotExist) {
 			return nil, status.Errorf(codes.NotFound, "event not found")
 		}
-		return nil, status.Errorf(codes.Unknown, "error getting event: %v", err)
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		return nil, unknownError(err, "error getting event: %v", err)
 	}
-	return s.eventResponse(event), nil
+	response := s.eventResponse(event)
+	s.setETag(ctx, response.Event)
+	return response, nil
 }
 
-// CreateEvent adds a new event.
+// CreateEvent adds a new event, retries of the request return the created event.
 func (s *grpcEventService) CreateEvent(
-	_ context.Context,
+	ctx context.Context,
 	eventRequest *pb.EventRequest,
 ) (*pb.EventResponse, error) {
 	err := eventRequest.ValidateAll()
 	if common.IsErr(err) {
 		return nil, err
 	}
-	event := s.convertToEvent(eventRequest.Event)
-	err = s.service.Create(event)
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	event, err := s.convertToEvent(eventRequest.Event)
+	if common.IsErr(err) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	ctx = domain.WithRequestID(ctx, eventRequest.RequestId)
+	response, err := idempotent(
+		ctx, s, userID, pb.EventServiceV1_CreateEvent_FullMethodName, eventRequest,
+		func(ctx context.Context) (*pb.EventResponse, error) {
+			return s.createEvent(ctx, userID, event, eventRequest.AllowOverlap)
+		},
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	s.setETag(ctx, response.Event)
+	return response, nil
+}
+
+func (s *grpcEventService) createEvent(
+	ctx context.Context, userID int64, event *domain.Event, allowOverlap bool,
+) (*pb.EventResponse, error) {
+	err := s.service.Create(ctx, userID, event, allowOverlap)
 	if common.IsErr(err) {
-		for _, domainErr := range []error{domain.ErrEndTime, domain.ErrNotifyTime} {
+		if errors.Is(err, domain.ErrCalendarNotExist) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrDateBusy) {
+			return nil, status.Errorf(codes.AlreadyExists, err.Error())
+		}
+		for _, domainErr := range []error{
+			domain.ErrEndTime, domain.ErrReminder, domain.ErrRecurrenceRule, domain.ErrNotificationTarget,
+			domain.ErrTimeZone,
+		} {
 			if errors.Is(err, domainErr) {
 				return nil, status.Errorf(codes.InvalidArgument, err.Error())
 			}
 		}
-		return nil, status.Errorf(codes.Unknown, err.Error())
+		return nil, unknownError(err, err.Error())
 	}
 	return s.eventResponse(event), nil
 }
 
-// UpdateEvent updates an event.
+// UpdateEvent updates an event of the version of the request or the If-Match header,
+// retries of the request return the updated event.
 func (s *grpcEventService) UpdateEvent(
-	_ context.Context,
+	ctx context.Context,
 	eventRequest *pb.EventRequest,
 ) (*pb.EventResponse, error) {
 	err := eventRequest.ValidateAll()
 	if common.IsErr(err) {
 		return nil, err
 	}
-	event := s.convertToEvent(eventRequest.Event)
-	err = s.service.Update(event)
+	userID, err := s.userID(ctx)
 	if common.IsErr(err) {
-		if errors.Is(err, domain.ErrEventNotExist) {
+		return nil, err
+	}
+	event, err := s.convertToEvent(eventRequest.Event)
+	if common.IsErr(err) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	event.Version, err = s.expectedVersion(ctx, event.Version)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	ctx = domain.WithRequestID(ctx, eventRequest.RequestId)
+	response, err := idempotent(
+		ctx, s, userID, pb.EventServiceV1_UpdateEvent_FullMethodName, eventRequest,
+		func(ctx context.Context) (*pb.EventResponse, error) {
+			return s.updateEvent(ctx, userID, event, eventRequest.AllowOverlap)
+		},
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	s.setETag(ctx, response.Event)
+	return response, nil
+}
+
+func (s *grpcEventService) updateEvent(
+	ctx context.Context, userID int64, event *domain.Event, allowOverlap bool,
+) (*pb.EventResponse, error) {
+	err := s.service.Update(ctx, userID, event, allowOverlap)
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrEventNotExist) || errors.Is(err, domain.ErrCalendarNotExist) {
 			return nil, status.Errorf(codes.NotFound, err.Error())
 		}
-		if errors.Is(err, domain.ErrUUID) {
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrDateBusy) {
+			return nil, status.Errorf(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, versionConflictError(err)
+		}
+		for _, domainErr := range []error{
+			domain.ErrUUID, domain.ErrEndTime, domain.ErrReminder, domain.ErrRecurrenceRule,
+			domain.ErrNotificationTarget, domain.ErrTimeZone,
+		} {
+			if errors.Is(err, domainErr) {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
 		}
-		return nil, status.Errorf(codes.Unknown, "error updating event: %v", err)
+		return nil, unknownError(err, "error updating event: %v", err)
 	}
 	return s.eventResponse(event), nil
 }
 
-// DeleteEvent deletes an event by ID.
+// DeleteEvent moves an event to the trash or deletes it permanently, retries of the request succeed.
 func (s *grpcEventService) DeleteEvent(
-	_ context.Context,
-	eventIDRequest *pb.EventIDRequest,
+	ctx context.Context,
+	deleteRequest *pb.DeleteEventRequest,
 ) (*emptypb.Empty, error) {
-	err := eventIDRequest.ValidateAll()
+	err := deleteRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
 	if common.IsErr(err) {
 		return nil, err
 	}
-	err = s.service.Delete(eventIDRequest.Id)
+	ctx = domain.WithRequestID(ctx, deleteRequest.RequestId)
+	return idempotent(
+		ctx, s, userID, pb.EventServiceV1_DeleteEvent_FullMethodName, deleteRequest,
+		func(ctx context.Context) (*emptypb.Empty, error) {
+			return s.deleteEvent(ctx, userID, deleteRequest.Id, deleteRequest.Permanent)
+		},
+	)
+}
+
+func (s *grpcEventService) deleteEvent(
+	ctx context.Context, userID int64, eventID string, permanent bool,
+) (*emptypb.Empty, error) {
+	err := s.service.Delete(ctx, userID, eventID, permanent)
 	if common.IsErr(err) {
 		if errors.Is(err, domain.ErrEventNotExist) {
 			return nil, status.Errorf(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
 		if errors.Is(err, domain.ErrUUID) {
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
-		return nil, status.Errorf(codes.Unknown, "error deleting event: %v", err)
+		return nil, unknownError(err, "error deleting event: %v", err)
 	}
 	return new(emptypb.Empty), nil
 }
 
+// RestoreEvent restores an event from the trash, retries of the request return the restored event.
+func (s *grpcEventService) RestoreEvent(
+	ctx context.Context,
+	eventIDRequest *pb.EventIDRequest,
+) (*pb.EventResponse, error) {
+	err := eventIDRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	ctx = domain.WithRequestID(ctx, eventIDRequest.RequestId)
+	response, err := idempotent(
+		ctx, s, userID, pb.EventServiceV1_RestoreEvent_FullMethodName, eventIDRequest,
+		func(ctx context.Context) (*pb.EventResponse, error) {
+			return s.restoreEvent(ctx, userID, eventIDRequest.Id)
+		},
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	s.setETag(ctx, response.Event)
+	return response, nil
+}
+
+func (s *grpcEventService) restoreEvent(ctx context.Context, userID int64, eventID string) (*pb.EventResponse, error) {
+	event, err := s.service.Restore(ctx, userID, eventID)
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrEventNotExist) {
+			return nil, status.Errorf(codes.NotFound, "deleted event not found")
+		}
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrUUID) {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		return nil, unknownError(err, "error restoring event: %v", err)
+	}
+	return s.eventResponse(event), nil
+}
+
+// InviteAttendees invites users to an event, retries of the request return the event.
+func (s *grpcEventService) InviteAttendees(
+	ctx context.Context,
+	inviteRequest *pb.InviteAttendeesRequest,
+) (*pb.EventResponse, error) {
+	err := inviteRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	ctx = domain.WithRequestID(ctx, inviteRequest.RequestId)
+	response, err := idempotent(
+		ctx, s, userID, pb.EventServiceV1_InviteAttendees_FullMethodName, inviteRequest,
+		func(ctx context.Context) (*pb.EventResponse, error) {
+			event, err := s.service.Invite(ctx, userID, inviteRequest.Id, inviteRequest.UserIds)
+			if common.IsErr(err) {
+				return nil, attendeeError(err, "error inviting attendees: %v", err)
+			}
+			return s.eventResponse(event), nil
+		},
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	s.setETag(ctx, response.Event)
+	return response, nil
+}
+
+// RespondInvitation sets the response of the user to an invitation, retries of the request return the event.
+func (s *grpcEventService) RespondInvitation(
+	ctx context.Context,
+	respondRequest *pb.RespondInvitationRequest,
+) (*pb.EventResponse, error) {
+	err := respondRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	ctx = domain.WithRequestID(ctx, respondRequest.RequestId)
+	response, err := idempotent(
+		ctx, s, userID, pb.EventServiceV1_RespondInvitation_FullMethodName, respondRequest,
+		func(ctx context.Context) (*pb.EventResponse, error) {
+			event, err := s.service.Respond(
+				ctx, userID, respondRequest.Id, s.convertToAttendeeStatus(respondRequest.Status),
+			)
+			if common.IsErr(err) {
+				return nil, attendeeError(err, "error responding to invitation: %v", err)
+			}
+			return s.eventResponse(event), nil
+		},
+	)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	s.setETag(ctx, response.Event)
+	return response, nil
+}
+
+// attendeeError returns the status of an error of attendees changes.
+func attendeeError(err error, format string, args ...interface{}) error {
+	if errors.Is(err, domain.ErrEventNotExist) {
+		return status.Errorf(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, domain.ErrPermission) {
+		return status.Errorf(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, domain.ErrUUID) || errors.Is(err, domain.ErrAttendee) {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return unknownError(err, format, args...)
+}
+
+// ListInvitations returns a list of events the user is invited to.
+func (s *grpcEventService) ListInvitations(
+	ctx context.Context,
+	invitationsRequest *pb.ListInvitationsRequest,
+) (*pb.EventsResponse, error) {
+	err := invitationsRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	events, err := s.service.ListInvitations(ctx, userID, s.convertToAttendeeStatus(invitationsRequest.Status))
+	if common.IsErr(err) {
+		return nil, unknownError(err, "error listing invitations: %v", err)
+	}
+	return s.eventsResponse(events), nil
+}
+
+// GetEventHistory returns changes of an event.
+func (s *grpcEventService) GetEventHistory(
+	ctx context.Context,
+	eventIDRequest *pb.EventIDRequest,
+) (*pb.EventHistoryResponse, error) {
+	err := eventIDRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	changes, err := s.service.History(ctx, userID, eventIDRequest.Id)
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrEventNotExist) {
+			return nil, status.Errorf(codes.NotFound, "event history not found")
+		}
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		return nil, unknownError(err, "error getting event history: %v", err)
+	}
+	response := &pb.EventHistoryResponse{Changes: make([]*pb.EventChange, len(changes))}
+	for i, c := range changes {
+		response.Changes[i] = s.convertEventChange(c)
+	}
+	return response, nil
+}
+
+// ListDeletedEvents returns a list of events in the trash.
+func (s *grpcEventService) ListDeletedEvents(ctx context.Context, _ *emptypb.Empty) (*pb.EventsResponse, error) {
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	events, err := s.service.ListDeleted(ctx, userID)
+	if common.IsErr(err) {
+		return nil, unknownError(err, "error listing deleted events: %v", err)
+	}
+	return s.eventsResponse(events), nil
+}
+
 // GetEventsByPeriod returns a list of events for the specified period.
 func (s *grpcEventService) GetEventsByPeriod(
-	_ context.Context,
+	ctx context.Context,
 	timePeriodRequest *pb.TimePeriodRequest,
 ) (*pb.EventsResponse, error) {
 	err := timePeriodRequest.ValidateAll()
 	if common.IsErr(err) {
 		return nil, err
 	}
-	events, err := s.service.ListByPeriod(
-		timePeriodRequest.StartTime.AsTime(), timePeriodRequest.EndTime.AsTime(),
-	)
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	filter, err := s.convertToFilter(timePeriodRequest)
+	if common.IsErr(err) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.service.ListPage(ctx, userID, filter)
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrPermission) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		return nil, unknownError(err, "error getting events for period: %v", err)
+	}
+	response := s.eventsResponse(page.Events)
+	if page.NextCursor != nil {
+		response.NextPageToken = page.NextCursor.Token()
+	}
+	return response, nil
+}
+
+// StreamEventsByPeriod streams events for the specified period, slow clients block reading of the events.
+func (s *grpcEventService) StreamEventsByPeriod(
+	timePeriodRequest *pb.TimePeriodRequest,
+	stream pb.EventServiceV1_StreamEventsByPeriodServer,
+) error {
+	err := timePeriodRequest.ValidateAll()
+	if common.IsErr(err) {
+		return err
+	}
+	ctx := stream.Context()
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return err
+	}
+	filter, err := s.convertToFilter(timePeriodRequest)
+	if common.IsErr(err) {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	err = s.service.Stream(ctx, userID, filter, func(event *domain.Event) error {
+		return stream.Send(s.eventResponse(event))
+	})
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrPermission) {
+			return status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return unknownError(err, "error streaming events for period: %v", err)
+	}
+	return nil
+}
+
+// parseDate parses the request date in the request time zone.
+func (s *grpcEventService) parseDate(request *pb.DateRequest) (time.Time, error) {
+	location, err := time.LoadLocation(request.TimeZone)
+	if common.IsErr(err) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid time zone: %v", err)
+	}
+	date, err := time.ParseInLocation(time.DateOnly, request.Date, location)
 	if common.IsErr(err) {
-		return nil, status.Errorf(codes.Unknown, "error getting events for period: %v", err)
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid date: %v", err)
+	}
+	return date, nil
+}
+
+func (s *grpcEventService) listByDate(
+	ctx context.Context,
+	request *pb.DateRequest,
+	list func(ctx context.Context, userID int64, date time.Time) ([]*domain.Event, error),
+) (*pb.EventsResponse, error) {
+	err := request.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	date, err := s.parseDate(request)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	events, err := list(ctx, userID, date)
+	if common.IsErr(err) {
+		return nil, unknownError(err, "error getting events for date: %v", err)
 	}
 	return s.eventsResponse(events), nil
 }
+
+// ListDayEvents returns a list of events for the day.
+func (s *grpcEventService) ListDayEvents(
+	ctx context.Context,
+	dateRequest *pb.DateRequest,
+) (*pb.EventsResponse, error) {
+	return s.listByDate(ctx, dateRequest, s.service.ListByDay)
+}
+
+// ListWeekEvents returns a list of events for the week of the date.
+func (s *grpcEventService) ListWeekEvents(
+	ctx context.Context,
+	dateRequest *pb.DateRequest,
+) (*pb.EventsResponse, error) {
+	return s.listByDate(ctx, dateRequest, s.service.ListByWeek)
+}
+
+// ListMonthEvents returns a list of events for the month of the date.
+func (s *grpcEventService) ListMonthEvents(
+	ctx context.Context,
+	dateRequest *pb.DateRequest,
+) (*pb.EventsResponse, error) {
+	return s.listByDate(ctx, dateRequest, s.service.ListByMonth)
+}
+
+// SetNotificationTarget sets the default notification target of the user.
+func (s *grpcEventService) SetNotificationTarget(
+	ctx context.Context,
+	targetRequest *pb.NotificationTargetRequest,
+) (*pb.NotificationTargetResponse, error) {
+	err := targetRequest.ValidateAll()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	target := s.convertToNotificationTarget(targetRequest.Target)
+	err = s.notifications.SetUserTarget(ctx, userID, target)
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrNotificationTarget) {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		return nil, unknownError(err, "error setting notification target: %v", err)
+	}
+	return &pb.NotificationTargetResponse{Target: s.convertNotificationTarget(target)}, nil
+}
+
+// GetNotificationTarget returns the default notification target of the user.
+func (s *grpcEventService) GetNotificationTarget(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.NotificationTargetResponse, error) {
+	userID, err := s.userID(ctx)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	target, err := s.notifications.GetUserTarget(ctx, userID)
+	if common.IsErr(err) {
+		if errors.Is(err, domain.ErrNotificationTargetNotExist) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		return nil, unknownError(err, "error getting notification target: %v", err)
+	}
+	return &pb.NotificationTargetResponse{Target: s.convertNotificationTarget(target)}, nil
+}
@@ -3,25 +3,54 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/repository"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
 	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/freecache"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests/mocks"
 	"github.com/go-faker/faker/v4"
 	"github.com/go-faker/faker/v4/pkg/options"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+func userContext(userID int64) context.Context {
+	return presentation.WithUserID(context.Background(), userID)
+}
+
+// newTestService returns the service of the event repository keeping results of write requests in memory.
+func newTestService(events domain.EventRepository) *grpcEventService {
+	return &grpcEventService{
+		service: application.NewEventService(events, new(mocks.CalendarRepository)),
+		idempotency: application.NewIdempotencyService(
+			repository.NewIdempotencyCacheRepository(freecache.NewCacheDB(1024*1024)), time.Hour,
+		),
+	}
+}
+
 func TestGrpcEventService_ConvertEvent(t *testing.T) {
 	s := &grpcEventService{}
 	event := tests.GenerateTestEvent()
+	remindTime := event.StartTime.Add(-time.Hour)
+	event.Reminders = []*domain.Reminder{{Offset: -time.Minute}, {Time: &remindTime}}
+	event.TimeZone = "Europe/Berlin"
+	event.AllDay = true
 	result := s.eventResponse(event)
 
 	require.NotNil(t, result)
@@ -30,7 +59,13 @@ func TestGrpcEventService_ConvertEvent(t *testing.T) {
 	require.Equal(t, event.Title, result.Event.Title)
 	require.Equal(t, timestamppb.New(event.StartTime), result.Event.StartTime)
 	require.Equal(t, timestamppb.New(*event.EndTime), result.Event.EndTime)
-	require.Equal(t, timestamppb.New(*event.NotifyTime), result.Event.NotifyTime)
+	require.Equal(t, []*pb.Reminder{
+		{Offset: durationpb.New(-time.Minute)},
+		{Time: timestamppb.New(remindTime)},
+	}, result.Event.Reminders)
+	require.Equal(t, event.Reminders, s.convertToReminders(result.Event.Reminders))
+	require.Equal(t, "Europe/Berlin", result.Event.TimeZone)
+	require.True(t, result.Event.AllDay)
 	require.Equal(t, event.UserID, result.Event.UserId)
 	require.Equal(t, timestamppb.New(*event.CreatedTime), result.Event.CreatedTime)
 }
@@ -51,7 +86,6 @@ func TestGrpcEventService_ConvertEvents(t *testing.T) {
 	require.Equal(t, events[0].Title, result.Events[0].Title)
 	require.Equal(t, timestamppb.New(events[0].StartTime), result.Events[0].StartTime)
 	require.Equal(t, timestamppb.New(*events[0].EndTime), result.Events[0].EndTime)
-	require.Equal(t, timestamppb.New(*events[0].NotifyTime), result.Events[0].NotifyTime)
 	require.Equal(t, events[0].UserID, result.Events[0].UserId)
 	require.Equal(t, timestamppb.New(*events[0].CreatedTime), result.Events[0].CreatedTime)
 
@@ -59,7 +93,6 @@ func TestGrpcEventService_ConvertEvents(t *testing.T) {
 	require.Equal(t, events[1].Title, result.Events[1].Title)
 	require.Equal(t, timestamppb.New(events[1].StartTime), result.Events[1].StartTime)
 	require.Equal(t, timestamppb.New(*events[1].EndTime), result.Events[1].EndTime)
-	require.Equal(t, timestamppb.New(*events[1].NotifyTime), result.Events[1].NotifyTime)
 	require.Equal(t, events[1].UserID, result.Events[1].UserId)
 	require.Equal(t, timestamppb.New(*events[1].CreatedTime), result.Events[1].CreatedTime)
 }
@@ -67,9 +100,9 @@ func TestGrpcEventService_ConvertEvents(t *testing.T) {
 func TestGrpcEventService_GetEvent(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
-	mockRepo.On("Get", event.ID).Return(event, nil)
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
-	result, err := s.GetEvent(context.Background(), &pb.EventIDRequest{Id: event.ID})
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(event, nil)
+	s := newTestService(mockRepo)
+	result, err := s.GetEvent(userContext(event.UserID), &pb.EventIDRequest{Id: event.ID})
 
 	mockRepo.AssertExpectations(t)
 	require.NoError(t, err)
@@ -81,15 +114,16 @@ func TestGrpcEventService_AddEvent(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
 	event.CreatedTime = nil
-	mockRepo.On("Add", mock.Anything).Run(func(args mock.Arguments) {
-		e := args[0].(*domain.Event)
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		e := args[1].(*domain.Event)
 		createTime := time.Now().UTC()
 		e.CreatedTime = &createTime
 		e.NormalizeTime()
 	}).Return(nil)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
-	result, err := s.CreateEvent(context.Background(), tests.CreateTestEventRequest(event))
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
 
 	mockRepo.AssertExpectations(t)
 	require.NoError(t, err)
@@ -101,10 +135,11 @@ func TestGrpcEventService_AddEventError(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
 	event.CreatedTime = nil
-	mockRepo.On("Add", mock.Anything).Return(domain.ErrEventCreate)
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Add", mock.Anything, mock.Anything).Return(domain.ErrEventCreate)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
-	result, err := s.CreateEvent(context.Background(), tests.CreateTestEventRequest(event))
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
 
 	mockRepo.AssertExpectations(t)
 	require.Error(t, err)
@@ -115,15 +150,18 @@ func TestGrpcEventService_UpdateEvent(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
 	event.CreatedTime = nil
-	mockRepo.On("Update", event).Run(func(args mock.Arguments) {
-		e := args[0].(*domain.Event)
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).
+		Return([]*domain.Event{event}, nil)
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(event, nil)
+	mockRepo.On("Update", mock.Anything, event.UserID, event).Run(func(args mock.Arguments) {
+		e := args[2].(*domain.Event)
 		createTime := time.Now().UTC()
 		e.CreatedTime = &createTime
 		e.NormalizeTime()
 	}).Return(nil)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
-	result, err := s.UpdateEvent(context.Background(), tests.CreateTestEventRequest(event))
+	s := newTestService(mockRepo)
+	result, err := s.UpdateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
 
 	mockRepo.AssertExpectations(t)
 	require.NoError(t, err)
@@ -135,24 +173,99 @@ func TestGrpcEventService_UpdateEventError(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
 	event.CreatedTime = nil
-	mockRepo.On("Update", event).Return(domain.ErrEventNotExist)
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(event, nil)
+	mockRepo.On("Update", mock.Anything, event.UserID, event).Return(domain.ErrEventNotExist)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
-	result, err := s.UpdateEvent(context.Background(), tests.CreateTestEventRequest(event))
+	s := newTestService(mockRepo)
+	result, err := s.UpdateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
 
 	mockRepo.AssertExpectations(t)
 	require.Error(t, err)
 	require.Nil(t, result)
 }
 
+func TestGrpcEventService_UpdateEventEndTime(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.CreatedTime = nil
+	endTime := event.StartTime.Add(-time.Hour)
+	event.EndTime = &endTime
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(event, nil).Maybe()
+
+	s := newTestService(mockRepo)
+	result, err := s.UpdateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
+
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	require.Nil(t, result)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcEventService_UpdateEventVersionConflict(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.CreatedTime = nil
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(event, nil)
+	mockRepo.On("Update", mock.Anything, event.UserID, event).Return(domain.ErrVersionConflict)
+
+	s := newTestService(mockRepo)
+	result, err := s.UpdateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
+
+	mockRepo.AssertExpectations(t)
+	require.Nil(t, result)
+	st := status.Convert(err)
+	require.Equal(t, codes.Aborted, st.Code())
+	require.Len(t, st.Details(), 1)
+	require.Equal(t, presentation.VersionConflictReason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+func TestGrpcEventService_UpdateEventIfMatch(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.CreatedTime = nil
+	request := tests.CreateTestEventRequest(event)
+	event.Version = 3
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(event, nil)
+	mockRepo.On("Update", mock.Anything, event.UserID, event).Run(func(args mock.Arguments) {
+		createTime := time.Now().UTC()
+		args[2].(*domain.Event).CreatedTime = &createTime
+	}).Return(nil)
+
+	s := newTestService(mockRepo)
+	ctx := metadata.NewIncomingContext(userContext(event.UserID), metadata.Pairs(presentation.IfMatchHeader, `"3"`))
+	result, err := s.UpdateEvent(ctx, request)
+
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Event.Version)
+}
+
+func TestGrpcEventService_UpdateEventWithoutVersion(t *testing.T) {
+	event := tests.GenerateTestEvent()
+	request := tests.CreateTestEventRequest(event)
+	request.Event.Version = 0
+	s := newTestService(new(mocks.EventRepository))
+
+	for _, ctx := range []context.Context{
+		userContext(event.UserID),
+		metadata.NewIncomingContext(userContext(event.UserID), metadata.Pairs(presentation.IfMatchHeader, "3")),
+	} {
+		result, err := s.UpdateEvent(ctx, request)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Nil(t, result)
+	}
+}
+
 func TestGrpcEventService_DeleteEvent(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
 	event.CreatedTime = nil
-	mockRepo.On("Delete", event.ID).Return(nil)
+	mockRepo.On("Delete", mock.Anything, event.UserID, event.ID).Return(nil)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
-	result, err := s.DeleteEvent(context.Background(), &pb.EventIDRequest{Id: event.ID})
+	s := newTestService(mockRepo)
+	result, err := s.DeleteEvent(userContext(event.UserID), &pb.DeleteEventRequest{Id: event.ID})
 
 	mockRepo.AssertExpectations(t)
 	require.NoError(t, err)
@@ -163,31 +276,257 @@ func TestGrpcEventService_DeleteEventError(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	event := tests.GenerateTestEvent()
 	event.CreatedTime = nil
-	mockRepo.On("Delete", event.ID).Return(nil)
+	mockRepo.On("Delete", mock.Anything, event.UserID, event.ID).Return(nil)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
+	s := newTestService(mockRepo)
 	result, err := s.DeleteEvent(
-		context.Background(),
-		&pb.EventIDRequest{
+		userContext(event.UserID),
+		&pb.DeleteEventRequest{
 			Id:        event.ID,
 			RequestId: faker.UUIDDigit(options.WithGenerateUniqueValues(true)),
 		},
 	)
 
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(new(emptypb.Empty), result))
+}
+
+func TestGrpcEventService_DeleteEventPermanently(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	mockRepo.On("DeletePermanently", mock.Anything, event.UserID, event.ID).Return(nil)
+
+	s := newTestService(mockRepo)
+	result, err := s.DeleteEvent(userContext(event.UserID), &pb.DeleteEventRequest{Id: event.ID, Permanent: true})
+
 	mockRepo.AssertExpectations(t)
 	require.NoError(t, err)
 	require.Equal(t, new(emptypb.Empty), result)
 }
 
+func TestGrpcEventService_RestoreEvent(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	mockRepo.On("Restore", mock.Anything, event.UserID, event.ID).Return(event, nil)
+
+	s := newTestService(mockRepo)
+	result, err := s.RestoreEvent(userContext(event.UserID), &pb.EventIDRequest{Id: event.ID})
+
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Equal(t, event.ID, result.Event.Id)
+	require.Nil(t, result.Event.DeletedTime)
+}
+
+func TestGrpcEventService_RestoreEventNotDeleted(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	mockRepo.On("Restore", mock.Anything, event.UserID, event.ID).Return(nil, domain.ErrEventNotExist)
+
+	s := newTestService(mockRepo)
+	result, err := s.RestoreEvent(userContext(event.UserID), &pb.EventIDRequest{Id: event.ID})
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_DeleteEventChangeContext(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	changeContext := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.ActorFromContext(ctx) == event.UserID && domain.RequestIDFromContext(ctx) == "request-1"
+	})
+	mockRepo.On("Delete", changeContext, event.UserID, event.ID).Return(nil)
+
+	s := newTestService(mockRepo)
+	_, err := s.DeleteEvent(userContext(event.UserID), &pb.DeleteEventRequest{Id: event.ID, RequestId: "request-1"})
+
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+}
+
+func TestGrpcEventService_CreateEventRetry(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.CreatedTime = nil
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		e := args[1].(*domain.Event)
+		createTime := time.Now().UTC()
+		e.CreatedTime = &createTime
+	}).Return(nil).Once()
+
+	s := newTestService(mockRepo)
+	request := tests.CreateTestEventRequest(event)
+	request.RequestId = "request-1"
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+	require.NoError(t, err)
+	retry, err := s.CreateEvent(userContext(event.UserID), request)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(result, retry))
+
+	request.Event.Title += " changed"
+	_, err = s.CreateEvent(userContext(event.UserID), request)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.DeleteEvent(userContext(event.UserID), &pb.DeleteEventRequest{Id: event.ID, RequestId: "request-1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_DeleteEventRetryAfterError(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	mockRepo.On("Delete", mock.Anything, event.UserID, event.ID).Return(errors.New("connection reset")).Once()
+	mockRepo.On("Delete", mock.Anything, event.UserID, event.ID).Return(nil).Once()
+
+	s := newTestService(mockRepo)
+	request := &pb.DeleteEventRequest{Id: event.ID, RequestId: "request-1"}
+	_, err := s.DeleteEvent(userContext(event.UserID), request)
+	require.Equal(t, codes.Unknown, status.Code(err))
+	_, err = s.DeleteEvent(userContext(event.UserID), request)
+	require.NoError(t, err)
+	_, err = s.DeleteEvent(userContext(event.UserID), request)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_InviteAttendees(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	attendee := event.UserID + 1
+	invited := event.Copy()
+	invited.Attendees = []*domain.Attendee{{UserID: attendee, Status: domain.AttendeeInvited}}
+	mockRepo.On("InviteAttendees", mock.Anything, event.UserID, event.ID, []int64{attendee}).Return(invited, nil)
+	mockRepo.On("InviteAttendees", mock.Anything, event.UserID, event.ID, []int64{event.UserID}).
+		Return(nil, domain.ErrAttendee)
+	mockRepo.On("InviteAttendees", mock.Anything, attendee, event.ID, []int64{event.UserID}).
+		Return(nil, domain.ErrPermission)
+
+	s := newTestService(mockRepo)
+	result, err := s.InviteAttendees(
+		userContext(event.UserID), &pb.InviteAttendeesRequest{Id: event.ID, UserIds: []int64{attendee}},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*pb.Attendee{{UserId: attendee, Status: pb.AttendeeStatus_ATTENDEE_STATUS_INVITED}},
+		result.Event.Attendees,
+	)
+	_, err = s.InviteAttendees(
+		userContext(event.UserID), &pb.InviteAttendeesRequest{Id: event.ID, UserIds: []int64{event.UserID}},
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.InviteAttendees(
+		userContext(attendee), &pb.InviteAttendeesRequest{Id: event.ID, UserIds: []int64{event.UserID}},
+	)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.InviteAttendees(userContext(event.UserID), &pb.InviteAttendeesRequest{Id: event.ID})
+	require.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_RespondInvitation(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	attendee := event.UserID + 1
+	event.Attendees = []*domain.Attendee{{UserID: attendee, Status: domain.AttendeeTentative}}
+	mockRepo.On("RespondInvitation", mock.Anything, attendee, event.ID, domain.AttendeeTentative).Return(event, nil)
+	mockRepo.On("RespondInvitation", mock.Anything, event.UserID, event.ID, domain.AttendeeDeclined).
+		Return(nil, domain.ErrPermission)
+
+	s := newTestService(mockRepo)
+	result, err := s.RespondInvitation(
+		userContext(attendee),
+		&pb.RespondInvitationRequest{Id: event.ID, Status: pb.AttendeeStatus_ATTENDEE_STATUS_TENTATIVE},
+	)
+	require.NoError(t, err)
+	require.Equal(t, pb.AttendeeStatus_ATTENDEE_STATUS_TENTATIVE, result.Event.Attendees[0].Status)
+	_, err = s.RespondInvitation(
+		userContext(event.UserID),
+		&pb.RespondInvitationRequest{Id: event.ID, Status: pb.AttendeeStatus_ATTENDEE_STATUS_DECLINED},
+	)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.RespondInvitation(
+		userContext(attendee),
+		&pb.RespondInvitationRequest{Id: event.ID, Status: pb.AttendeeStatus_ATTENDEE_STATUS_INVITED},
+	)
+	require.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_ListInvitations(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	attendee := event.UserID + 1
+	event.Attendees = []*domain.Attendee{{UserID: attendee, Status: domain.AttendeeInvited}}
+	mockRepo.On("GetInvitations", mock.Anything, attendee, domain.AttendeeInvited).Return([]*domain.Event{event}, nil)
+	mockRepo.On("GetInvitations", mock.Anything, attendee, domain.AttendeeStatus("")).Return([]*domain.Event{event}, nil)
+
+	s := newTestService(mockRepo)
+	for _, request := range []*pb.ListInvitationsRequest{
+		{Status: pb.AttendeeStatus_ATTENDEE_STATUS_INVITED},
+		{},
+	} {
+		result, err := s.ListInvitations(userContext(attendee), request)
+		require.NoError(t, err)
+		require.Len(t, result.Events, 1)
+		require.Equal(t, event.ID, result.Events[0].Id)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_GetEventHistory(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	changes := []*domain.EventChange{
+		{ID: 1, EventID: event.ID, Action: domain.EventActionCreate, ActorID: event.UserID, After: event},
+		{ID: 2, EventID: event.ID, Action: domain.EventActionDeletePermanently, ActorID: event.UserID, Before: event},
+	}
+	mockRepo.On("GetHistory", mock.Anything, event.UserID, event.ID).Return(changes, nil)
+	mockRepo.On("GetHistory", mock.Anything, event.UserID+1, event.ID).Return(nil, domain.ErrPermission)
+
+	s := newTestService(mockRepo)
+	result, err := s.GetEventHistory(userContext(event.UserID), &pb.EventIDRequest{Id: event.ID})
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 2)
+	require.Nil(t, result.Changes[0].Before)
+	require.Equal(t, event.ID, result.Changes[0].After.Id)
+	require.Equal(t, domain.EventActionDeletePermanently, result.Changes[1].Action)
+	require.Nil(t, result.Changes[1].After)
+
+	_, err = s.GetEventHistory(userContext(event.UserID+1), &pb.EventIDRequest{Id: event.ID})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_ListDeletedEvents(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	deletedTime := time.Now().UTC()
+	event.DeletedTime = &deletedTime
+	mockRepo.On("GetDeletedEvents", mock.Anything, event.UserID).Return([]*domain.Event{event}, nil)
+
+	s := newTestService(mockRepo)
+	result, err := s.ListDeletedEvents(userContext(event.UserID), &emptypb.Empty{})
+
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	require.Equal(t, deletedTime, result.Events[0].DeletedTime.AsTime())
+}
+
 func TestGrpcEventService_GetEventsByPeriod(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	events := []*domain.Event{tests.GenerateTestEvent(), tests.GenerateTestEvent()}
 	startTime, endTime := tests.GetEventStartEndTime(events[0], events[1])
-	mockRepo.On("GetEventsByPeriod", startTime, endTime).Return(events, nil)
+	filter := &domain.EventFilter{UserID: events[0].UserID, StartTime: startTime, EndTime: endTime, PageSize: 100}
+	mockRepo.On("GetEventsPage", mock.Anything, filter).Return(&domain.EventPage{Events: events}, nil)
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
+	s := newTestService(mockRepo)
 	result, err := s.GetEventsByPeriod(
-		context.Background(),
+		userContext(events[0].UserID),
 		&pb.TimePeriodRequest{
 			StartTime: timestamppb.New(startTime),
 			EndTime:   timestamppb.New(endTime),
@@ -199,17 +538,18 @@ func TestGrpcEventService_GetEventsByPeriod(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	require.Len(t, result.Events, 2)
+	require.Empty(t, result.NextPageToken)
 }
 
 func TestGrpcEventService_GetEventsByPeriodError(t *testing.T) {
 	mockRepo := new(mocks.EventRepository)
 	events := []*domain.Event{tests.GenerateTestEvent(), tests.GenerateTestEvent()}
 	startTime, endTime := tests.GetEventStartEndTime(events[0], events[1])
-	mockRepo.On("GetEventsByPeriod", startTime, endTime).Return(nil, errors.New("error"))
+	mockRepo.On("GetEventsPage", mock.Anything, mock.Anything).Return(nil, errors.New("error"))
 
-	s := grpcEventService{service: application.NewEventService(mockRepo)}
+	s := newTestService(mockRepo)
 	result, err := s.GetEventsByPeriod(
-		context.Background(),
+		userContext(events[0].UserID),
 		&pb.TimePeriodRequest{
 			StartTime: timestamppb.New(startTime),
 			EndTime:   timestamppb.New(endTime),
@@ -221,3 +561,383 @@ func TestGrpcEventService_GetEventsByPeriodError(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, result)
 }
+
+func TestGrpcEventService_AddEventInvalidRecurrence(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	request := tests.CreateTestEventRequest(event)
+	request.Event.Recurrence = &pb.Recurrence{Rule: "FREQ=HOURLY"}
+
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_AddEventNotificationTarget(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.CreatedTime = nil
+	target := &domain.NotificationTarget{Channel: domain.ChannelWebhook, Address: "https://example.com/hook"}
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Add", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+		return *e.NotificationTarget == *target
+	})).Run(func(args mock.Arguments) {
+		e := args[1].(*domain.Event)
+		createTime := time.Now().UTC()
+		e.CreatedTime = &createTime
+	}).Return(nil)
+	request := tests.CreateTestEventRequest(event)
+	request.Event.NotificationTarget = &pb.NotificationTarget{Channel: target.Channel, Address: target.Address}
+
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Equal(t, target.Address, result.Event.NotificationTarget.Address)
+}
+
+func TestGrpcEventService_AddEventInvalidNotificationTarget(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	request := tests.CreateTestEventRequest(event)
+	request.Event.NotificationTarget = &pb.NotificationTarget{Channel: domain.ChannelEmail, Address: "not an email"}
+
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_AddEventInvalidTimeZone(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	request := tests.CreateTestEventRequest(event)
+	request.Event.TimeZone = "Mars/Olympus"
+
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_NotificationTarget(t *testing.T) {
+	mockRepo := new(mocks.NotificationTargetRepository)
+	target := &domain.NotificationTarget{Channel: domain.ChannelEmail, Address: "user@example.com"}
+	mockRepo.On("Get", mock.Anything, int64(7)).Return(nil, domain.ErrNotificationTargetNotExist).Once()
+	mockRepo.On("Set", mock.Anything, int64(7), target).Return(nil)
+	mockRepo.On("Get", mock.Anything, int64(7)).Return(target, nil)
+	s := grpcEventService{notifications: application.NewNotificationService(mockRepo)}
+
+	_, err := s.GetNotificationTarget(userContext(7), &emptypb.Empty{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.SetNotificationTarget(userContext(7), &pb.NotificationTargetRequest{
+		Target: &pb.NotificationTarget{Channel: domain.ChannelEmail, Address: "not an email"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.SetNotificationTarget(userContext(7), &pb.NotificationTargetRequest{
+		Target: &pb.NotificationTarget{Channel: "sms", Address: "+10000000000"},
+	})
+	require.Error(t, err)
+
+	result, err := s.SetNotificationTarget(userContext(7), &pb.NotificationTargetRequest{
+		Target: &pb.NotificationTarget{Channel: target.Channel, Address: target.Address},
+	})
+	require.NoError(t, err)
+	require.Equal(t, target.Address, result.Target.Address)
+
+	result, err = s.GetNotificationTarget(userContext(7), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, target.Channel, result.Target.Channel)
+	require.Equal(t, target.Address, result.Target.Address)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_ListDateEvents(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	cases := []struct {
+		name       string
+		list       func(s *grpcEventService, r *pb.DateRequest) (*pb.EventsResponse, error)
+		date       string
+		start, end time.Time
+	}{
+		{
+			name: "day with DST transition",
+			list: func(s *grpcEventService, r *pb.DateRequest) (*pb.EventsResponse, error) {
+				return s.ListDayEvents(userContext(1), r)
+			},
+			date:  "2024-03-31",
+			start: time.Date(2024, time.March, 30, 23, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, time.March, 31, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "week with DST transition",
+			list: func(s *grpcEventService, r *pb.DateRequest) (*pb.EventsResponse, error) {
+				return s.ListWeekEvents(userContext(1), r)
+			},
+			date:  "2024-10-24",
+			start: time.Date(2024, time.October, 20, 22, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, time.October, 27, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "month",
+			list: func(s *grpcEventService, r *pb.DateRequest) (*pb.EventsResponse, error) {
+				return s.ListMonthEvents(userContext(1), r)
+			},
+			date:  "2024-02-15",
+			start: time.Date(2024, time.January, 31, 23, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, time.February, 29, 23, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockRepo := new(mocks.EventRepository)
+			mockRepo.On("GetEventsByPeriod", mock.Anything, int64(1), mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					require.True(t, c.start.Equal(args[2].(time.Time)))
+					require.True(t, c.end.Equal(args[3].(time.Time)))
+					require.Equal(t, berlin, args[2].(time.Time).Location())
+				}).Return([]*domain.Event{tests.GenerateTestEvent()}, nil)
+
+			s := newTestService(mockRepo)
+			result, err := c.list(s, &pb.DateRequest{Date: c.date, TimeZone: "Europe/Berlin"})
+
+			mockRepo.AssertExpectations(t)
+			require.NoError(t, err)
+			require.Len(t, result.Events, 1)
+		})
+	}
+}
+
+func TestGrpcEventService_ListDayEventsInvalidTimeZone(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	s := newTestService(mockRepo)
+	result, err := s.ListDayEvents(
+		userContext(1), &pb.DateRequest{Date: "2024-03-31", TimeZone: "Mars/Olympus"},
+	)
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_GetEventWithoutUser(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	s := newTestService(mockRepo)
+	result, err := s.GetEvent(context.Background(), &pb.EventIDRequest{Id: faker.UUIDHyphenated()})
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_GetEventTimeout(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	queryErr := fmt.Errorf("%w: pq: canceling statement due to user request", context.DeadlineExceeded)
+	mockRepo.On("Get", mock.Anything, event.UserID, event.ID).Return(nil, queryErr)
+	s := newTestService(mockRepo)
+	result, err := s.GetEvent(userContext(event.UserID), &pb.EventIDRequest{Id: event.ID})
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_GetEventOfAnotherUser(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	mockRepo.On("Get", mock.Anything, event.UserID+1, event.ID).Return(nil, domain.ErrPermission)
+	s := newTestService(mockRepo)
+	result, err := s.GetEvent(userContext(event.UserID+1), &pb.EventIDRequest{Id: event.ID})
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_AddEventOfAnotherUser(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.UserID = 1
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(2), tests.CreateTestEventRequest(event))
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_AddEventOverlap(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	other := tests.GenerateTestEvent()
+	other.UserID = event.UserID
+	other.StartTime = event.StartTime.Add(-time.Minute)
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).
+		Return([]*domain.Event{other}, nil)
+
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), tests.CreateTestEventRequest(event))
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_AddEventAllowOverlap(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	mockRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		createTime := time.Now().UTC()
+		args[1].(*domain.Event).CreatedTime = &createTime
+	}).Return(nil)
+
+	s := newTestService(mockRepo)
+	request := tests.CreateTestEventRequest(event)
+	request.AllowOverlap = true
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetOverlappingEvents", mock.Anything, mock.Anything, mock.Anything)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+}
+
+func TestGrpcEventService_AddRecurringEventOverlap(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	request := tests.CreateTestEventRequest(event)
+	request.Event.Recurrence = &pb.Recurrence{Rule: "FREQ=WEEKLY"}
+	other := tests.GenerateTestEvent()
+	other.UserID = event.UserID
+	other.StartTime = event.StartTime.AddDate(0, 0, 14)
+	endTime := event.EndTime.AddDate(0, 0, 14)
+	other.EndTime = &endTime
+	mockRepo.On("GetOverlappingEvents", mock.Anything, event.UserID, mock.Anything, mock.Anything).
+		Return([]*domain.Event{other}, nil)
+
+	s := newTestService(mockRepo)
+	result, err := s.CreateEvent(userContext(event.UserID), request)
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.Nil(t, result)
+}
+
+func TestGrpcEventService_GetEventsByPeriodPage(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	cursor := &domain.EventCursor{StartTime: event.StartTime, ID: event.ID}
+	next := &domain.EventCursor{StartTime: event.StartTime.Add(time.Hour), ID: event.ID}
+	hasNotification := true
+	filter := &domain.EventFilter{
+		UserID:          event.UserID,
+		OwnerID:         event.UserID,
+		StartTime:       event.StartTime,
+		EndTime:         *event.EndTime,
+		Title:           "meeting",
+		HasNotification: &hasNotification,
+		Descending:      true,
+		PageSize:        1,
+		Cursor:          cursor,
+	}
+	mockRepo.On("GetEventsPage", mock.Anything, filter).
+		Return(&domain.EventPage{Events: []*domain.Event{event}, NextCursor: next}, nil)
+
+	s := newTestService(mockRepo)
+	result, err := s.GetEventsByPeriod(
+		userContext(event.UserID),
+		&pb.TimePeriodRequest{
+			StartTime:       timestamppb.New(event.StartTime),
+			EndTime:         timestamppb.New(*event.EndTime),
+			PageSize:        1,
+			PageToken:       cursor.Token(),
+			Title:           "meeting",
+			UserId:          event.UserID,
+			HasNotification: wrapperspb.Bool(true),
+			Order:           pb.SortOrder_SORT_ORDER_START_TIME_DESC,
+		},
+	)
+
+	mockRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	require.Equal(t, next.Token(), result.NextPageToken)
+}
+
+func TestGrpcEventService_GetEventsByPeriodInvalidRequest(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	s := newTestService(mockRepo)
+	request := &pb.TimePeriodRequest{
+		StartTime: timestamppb.Now(),
+		EndTime:   timestamppb.Now(),
+		PageToken: "invalid",
+	}
+	_, err := s.GetEventsByPeriod(userContext(1), request)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	request.PageToken = ""
+	request.UserId = 2
+	_, err = s.GetEventsByPeriod(userContext(1), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrpcEventService_StreamEventsByPeriod(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	events := []*domain.Event{tests.GenerateTestEvent(), tests.GenerateTestEvent()}
+	events[1].UserID = events[0].UserID
+	startTime, endTime := tests.GetEventStartEndTime(events[0], events[1])
+	ctx := userContext(events[0].UserID)
+	filter := &domain.EventFilter{UserID: events[0].UserID, StartTime: startTime, EndTime: endTime}
+	mockRepo.On("IterateEvents", mock.Anything, filter, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args[2].(func(e *domain.Event) error)
+		for _, e := range events {
+			require.NoError(t, fn(e))
+		}
+	}).Return(nil)
+	stream := new(mocks.EventServiceV1_StreamEventsByPeriodServer)
+	stream.On("Context").Return(ctx)
+	stream.On("Send", mock.Anything).Return(nil).Twice()
+
+	s := newTestService(mockRepo)
+	err := s.StreamEventsByPeriod(
+		&pb.TimePeriodRequest{StartTime: timestamppb.New(startTime), EndTime: timestamppb.New(endTime)},
+		stream,
+	)
+
+	mockRepo.AssertExpectations(t)
+	stream.AssertExpectations(t)
+	require.NoError(t, err)
+}
+
+func TestGrpcEventService_StreamEventsByPeriodCanceled(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	ctx, cancel := context.WithCancel(userContext(1))
+	cancel()
+	mockRepo.On("IterateEvents", mock.MatchedBy(func(c context.Context) bool {
+		return errors.Is(c.Err(), context.Canceled)
+	}), mock.Anything, mock.Anything).Return(context.Canceled)
+	stream := new(mocks.EventServiceV1_StreamEventsByPeriodServer)
+	stream.On("Context").Return(ctx)
+
+	s := newTestService(mockRepo)
+	err := s.StreamEventsByPeriod(
+		&pb.TimePeriodRequest{StartTime: timestamppb.Now(), EndTime: timestamppb.Now()},
+		stream,
+	)
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
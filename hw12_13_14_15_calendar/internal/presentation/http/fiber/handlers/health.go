@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
+	"github.com/gofiber/fiber/v3"
+)
+
+// ComponentsHealth is a response of the components health check.
+type ComponentsHealth struct {
+	Healthy    bool
+	Components []supervisor.Health
+}
+
+// HealthHandler is a handler of health statuses of service components and dependencies.
+type HealthHandler struct {
+	reporter  presentation.HealthReporter
+	readiness presentation.ReadinessChecker
+}
+
+// NewHealthHandler returns a new instance of the health handler.
+func NewHealthHandler(reporter presentation.HealthReporter, readiness presentation.ReadinessChecker) *HealthHandler {
+	return &HealthHandler{reporter: reporter, readiness: readiness}
+}
+
+// Components responds with health statuses of the components, the status is 503 if any of them isn't running.
+func (h *HealthHandler) Components(c fiber.Ctx) error {
+	health := ComponentsHealth{Healthy: h.reporter.Healthy(), Components: h.reporter.Health()}
+	status := fiber.StatusOK
+	if !health.Healthy {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(health)
+}
+
+// Ready responds with results of the readiness checks, the status is 503 if any of them fails.
+func (h *HealthHandler) Ready(c fiber.Ctx) error {
+	report := h.readiness.Check(c.UserContext())
+	status := fiber.StatusOK
+	if !report.Ready {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(report)
+}
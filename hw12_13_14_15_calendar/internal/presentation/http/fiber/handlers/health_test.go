@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/health"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/require"
+)
+
+type testHealthReporter []supervisor.Health
+
+func (r testHealthReporter) Health() []supervisor.Health {
+	return r
+}
+
+func (r testHealthReporter) Healthy() bool {
+	for _, h := range r {
+		if h.Status != supervisor.StatusRunning {
+			return false
+		}
+	}
+	return true
+}
+
+func TestHealthHandler_Components(t *testing.T) {
+	cases := []struct {
+		name     string
+		reporter testHealthReporter
+		status   int
+	}{
+		{
+			name: "healthy",
+			reporter: testHealthReporter{
+				{Name: "http", Status: supervisor.StatusRunning},
+				{Name: "sender", Status: supervisor.StatusRunning},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "failed",
+			reporter: testHealthReporter{
+				{Name: "http", Status: supervisor.StatusRunning},
+				{Name: "sender", Status: supervisor.StatusFailed, Error: "connection refused"},
+			},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", NewHealthHandler(c.reporter, nil).Components)
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, c.status, resp.StatusCode)
+
+			var health ComponentsHealth
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+			require.Equal(t, c.status == http.StatusOK, health.Healthy)
+			require.Equal(t, []supervisor.Health(c.reporter), health.Components)
+		})
+	}
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	var dbErr error
+	readiness := health.NewChecker(time.Second)
+	readiness.Add("db", func(context.Context) error { return dbErr })
+	app := fiber.New()
+	app.Get("/", NewHealthHandler(nil, readiness).Ready)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	dbErr = errors.New("connection refused")
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	var report health.Report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	require.Equal(t, health.Report{
+		Checks: []health.Result{{Name: "db", Status: health.StatusDown, Error: "connection refused"}},
+	}, report)
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/ical"
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	icalProductID = "-//hw_go//calendar//EN"
+	// icalExportPeriod is a default period before and after the current time of the exported feed.
+	icalExportPeriod = 366 * 24 * time.Hour
+	icalFileField    = "file"
+)
+
+var errICalEvent = errors.New("invalid VEVENT")
+
+// ICalHandler is a handler of the iCalendar feed export and import.
+type ICalHandler struct {
+	service *application.EventService
+}
+
+// NewICalHandler returns a new instance of the iCalendar handler.
+func NewICalHandler(service *application.EventService) *ICalHandler {
+	return &ICalHandler{service: service}
+}
+
+// ICalImportItem is a result of an imported VEVENT.
+type ICalImportItem struct {
+	Index int    `json:"index"`
+	UID   string `json:"uid,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ICalImportResult is a result of the iCalendar import.
+type ICalImportResult struct {
+	Imported int               `json:"imported"`
+	Items    []*ICalImportItem `json:"items"`
+}
+
+// Export renders the user events of the period ("start" and "end" RFC 3339 query params) as an iCalendar feed.
+func (h *ICalHandler) Export(c fiber.Ctx) error {
+	userID, err := presentation.UserIDFromContext(c.UserContext())
+	if common.IsErr(err) {
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+	now := time.Now().UTC()
+	startTime, err := queryTime(c, "start", now.Add(-icalExportPeriod))
+	if common.IsErr(err) {
+		return err
+	}
+	endTime, err := queryTime(c, "end", now.Add(icalExportPeriod))
+	if common.IsErr(err) {
+		return err
+	}
+	calendar := newCalendar()
+	filter := &domain.EventFilter{StartTime: startTime, EndTime: endTime, Series: true}
+	err = h.service.Stream(c.UserContext(), userID, filter, func(e *domain.Event) error {
+		calendar.Components = append(calendar.Components, eventComponent(e, now))
+		return nil
+	})
+	if common.IsErr(err) {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, calendar); common.IsErr(err) {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, ical.ContentType)
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// Import creates the user events from an uploaded iCalendar file (multipart "file" field or the request body),
+// overlapping events are rejected unless "allow_overlap" query param is set.
+func (h *ICalHandler) Import(c fiber.Ctx) error {
+	userID, err := presentation.UserIDFromContext(c.UserContext())
+	if common.IsErr(err) {
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+	allowOverlap := false
+	if value := c.Query("allow_overlap"); value != "" {
+		allowOverlap, err = strconv.ParseBool(value)
+		if common.IsErr(err) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid allow_overlap")
+		}
+	}
+	data, err := uploadedFile(c)
+	if common.IsErr(err) {
+		return err
+	}
+	calendar, err := ical.Decode(data)
+	if common.IsErr(err) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if calendar.Name != "VCALENDAR" {
+		return fiber.NewError(fiber.StatusBadRequest, "VCALENDAR is expected")
+	}
+	result := &ICalImportResult{Items: []*ICalImportItem{}}
+	for i, component := range calendar.Children("VEVENT") {
+		item := &ICalImportItem{Index: i}
+		if uid := component.Get("UID"); uid != nil {
+			item.UID = uid.Text()
+		}
+		event, err := componentEvent(component)
+		if !common.IsErr(err) {
+			err = h.service.Create(c.UserContext(), userID, event, allowOverlap)
+		}
+		if common.IsErr(err) {
+			item.Error = err.Error()
+		} else {
+			item.ID = event.ID
+			result.Imported++
+		}
+		result.Items = append(result.Items, item)
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+func queryTime(c fiber.Ctx, key string, defaultValue time.Time) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if common.IsErr(err) {
+		return time.Time{}, fiber.NewError(fiber.StatusBadRequest, "invalid "+key+" time")
+	}
+	return t, nil
+}
+
+// uploadedFile returns the multipart file or the request body.
+func uploadedFile(c fiber.Ctx) (io.Reader, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return bytes.NewReader(c.Body()), nil
+	}
+	header, err := c.FormFile(icalFileField)
+	if common.IsErr(err) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "multipart field "+icalFileField+" is required")
+	}
+	file, err := header.Open()
+	if common.IsErr(err) {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+func newCalendar() *ical.Component {
+	calendar := ical.NewComponent("VCALENDAR")
+	calendar.Add("VERSION", "2.0")
+	calendar.Add("PRODID", icalProductID)
+	calendar.Add("CALSCALE", "GREGORIAN")
+	return calendar
+}
+
+// eventComponent returns VEVENT of the event, reminders are rendered as VALARM. All-day events are rendered
+// with dates of their time zone.
+func eventComponent(e *domain.Event, now time.Time) *ical.Component {
+	component := ical.NewComponent("VEVENT")
+	component.AddText("UID", e.ID)
+	component.Add("DTSTAMP", ical.FormatTime(now))
+	if e.AllDay {
+		dateParams := map[string]string{"VALUE": "DATE"}
+		component.Add("DTSTART", ical.FormatDate(e.StartTime.In(e.Location()))).Params = dateParams
+		if e.EndTime != nil {
+			component.Add("DTEND", ical.FormatDate(e.EndTime.In(e.Location()))).Params = dateParams
+		}
+	} else {
+		component.Add("DTSTART", ical.FormatTime(e.StartTime))
+		if e.EndTime != nil {
+			component.Add("DTEND", ical.FormatTime(*e.EndTime))
+		}
+	}
+	component.AddText("SUMMARY", e.Title)
+	if e.Description != "" {
+		component.AddText("DESCRIPTION", e.Description)
+	}
+	if e.CreatedTime != nil {
+		component.Add("CREATED", ical.FormatTime(*e.CreatedTime))
+	}
+	if e.Recurrence != nil {
+		component.Add("RRULE", e.Recurrence.Rule())
+		if len(e.Recurrence.Exceptions) > 0 {
+			addExceptions(component, e)
+		}
+	}
+	for _, r := range e.Reminders {
+		alarm := ical.NewComponent("VALARM")
+		alarm.Add("ACTION", "DISPLAY")
+		alarm.AddText("DESCRIPTION", e.Title)
+		if r.Time != nil {
+			alarm.Add("TRIGGER", ical.FormatTime(*r.Time)).Params = map[string]string{"VALUE": "DATE-TIME"}
+		} else {
+			alarm.Add("TRIGGER", ical.FormatDuration(r.Offset))
+		}
+		component.Components = append(component.Components, alarm)
+	}
+	return component
+}
+
+// addExceptions adds EXDATE of the recurring event, exceptions of all-day events are dates
+// since EXDATE must have the value type of DTSTART.
+func addExceptions(component *ical.Component, e *domain.Event) {
+	if !e.AllDay {
+		component.Add("EXDATE", domain.FormatRecurrenceTimes(e.Recurrence.Exceptions))
+		return
+	}
+	dates := make([]string, len(e.Recurrence.Exceptions))
+	for i, t := range e.Recurrence.Exceptions {
+		dates[i] = ical.FormatDate(t.In(e.Location()))
+	}
+	component.Add("EXDATE", strings.Join(dates, ",")).Params = map[string]string{"VALUE": "DATE"}
+}
+
+// componentEvent returns an event of VEVENT, VALARM triggers are used as reminders. DTSTART date makes
+// an all-day event and its TZID is used as the event time zone.
+func componentEvent(component *ical.Component) (*domain.Event, error) {
+	event := &domain.Event{}
+	if p := component.Get("SUMMARY"); p != nil {
+		event.Title = p.Text()
+	}
+	if p := component.Get("DESCRIPTION"); p != nil {
+		event.Description = p.Text()
+	}
+	start := component.Get("DTSTART")
+	if start == nil {
+		return nil, fmt.Errorf("%w: DTSTART is required", errICalEvent)
+	}
+	var err error
+	if event.StartTime, err = start.Time(); common.IsErr(err) {
+		return nil, err
+	}
+	event.TimeZone = start.Params["TZID"]
+	event.AllDay = start.IsDate()
+	if err := setEndTime(component, event); common.IsErr(err) {
+		return nil, err
+	}
+	if err := setRecurrence(component, event); common.IsErr(err) {
+		return nil, err
+	}
+	for _, alarm := range component.Children("VALARM") {
+		reminder, err := triggerReminder(alarm, event)
+		if common.IsErr(err) {
+			return nil, err
+		}
+		event.Reminders = append(event.Reminders, reminder)
+	}
+	event.NormalizeTime()
+	return event, nil
+}
+
+func setEndTime(component *ical.Component, event *domain.Event) error {
+	if p := component.Get("DTEND"); p != nil {
+		end, err := p.Time()
+		if common.IsErr(err) {
+			return err
+		}
+		event.EndTime = &end
+	} else if p := component.Get("DURATION"); p != nil {
+		d, err := ical.ParseDuration(p.Value)
+		if common.IsErr(err) {
+			return err
+		}
+		end := event.StartTime.Add(d)
+		event.EndTime = &end
+	}
+	return nil
+}
+
+func setRecurrence(component *ical.Component, event *domain.Event) error {
+	rule := component.Get("RRULE")
+	if rule == nil {
+		return nil
+	}
+	recurrence, err := domain.ParseRecurrenceRule(rule.Value)
+	if common.IsErr(err) {
+		return err
+	}
+	for _, p := range component.GetAll("EXDATE") {
+		for _, value := range strings.Split(p.Value, ",") {
+			t, err := (&ical.Property{Name: p.Name, Params: p.Params, Value: value}).Time()
+			if common.IsErr(err) {
+				return err
+			}
+			recurrence.Exceptions = append(recurrence.Exceptions, t)
+		}
+	}
+	event.Recurrence = recurrence
+	return nil
+}
+
+// triggerReminder returns a reminder of VALARM, a relative trigger is related to the event start (or end),
+// absolute triggers of recurring events become offsets.
+func triggerReminder(alarm *ical.Component, event *domain.Event) (*domain.Reminder, error) {
+	trigger := alarm.Get("TRIGGER")
+	if trigger == nil {
+		return nil, fmt.Errorf("%w: VALARM without TRIGGER", errICalEvent)
+	}
+	if trigger.Params["VALUE"] == "DATE-TIME" {
+		t, err := trigger.Time()
+		if common.IsErr(err) {
+			return nil, err
+		}
+		if event.Recurrence != nil {
+			return &domain.Reminder{Offset: t.Sub(event.StartTime)}, nil
+		}
+		return &domain.Reminder{Time: &t}, nil
+	}
+	d, err := ical.ParseDuration(trigger.Value)
+	if common.IsErr(err) {
+		return nil, err
+	}
+	if trigger.Params["RELATED"] == "END" && event.EndTime != nil {
+		d += event.EndTime.Sub(event.StartTime)
+	}
+	return &domain.Reminder{Offset: d}, nil
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/ical"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests/mocks"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID int64 = 42
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:first@example.com\r\n" +
+	"SUMMARY:Standup\\, daily\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240101T100000\r\n" +
+	"DURATION:PT15M\r\n" +
+	"RRULE:FREQ=DAILY;COUNT=5\r\n" +
+	"EXDATE;TZID=Europe/Berlin:20240102T100000,20240103T100000\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER;RELATED=END:PT5M\r\n" +
+	"END:VALARM\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER;VALUE=DATE-TIME:20240101T084500Z\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:second@example.com\r\n" +
+	"SUMMARY:No start\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:third@example.com\r\n" +
+	"SUMMARY:Busy\r\n" +
+	"DTSTART:20240105T120000Z\r\n" +
+	"DTEND:20240105T130000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func newTestHandler(repository domain.EventRepository) *ICalHandler {
+	return NewICalHandler(application.NewEventService(repository, new(mocks.CalendarRepository)))
+}
+
+func newTestApp(handler *ICalHandler, userID int64) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if userID != 0 {
+			c.SetUserContext(presentation.WithUserID(c.UserContext(), userID))
+		}
+		return c.Next()
+	})
+	app.Get("/calendar.ics", handler.Export)
+	app.Post("/calendar.ics", handler.Import)
+	return app
+}
+
+func TestICalHandler_Export(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.UserID = testUserID
+	remindTime := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+	event.Reminders = []*domain.Reminder{{Offset: -15 * time.Minute}, {Time: &remindTime}}
+	series := tests.GenerateTestEvent()
+	series.UserID = testUserID
+	series.Recurrence = &domain.Recurrence{
+		Frequency: domain.FrequencyWeekly, Interval: 1, Exceptions: []time.Time{series.StartTime.AddDate(0, 0, 7)},
+	}
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	filter := &domain.EventFilter{UserID: testUserID, StartTime: start, EndTime: end, Series: true}
+	mockRepo.On("IterateEvents", mock.Anything, filter, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args[2].(func(e *domain.Event) error)
+		require.NoError(t, fn(event))
+		require.NoError(t, fn(series))
+	}).Return(nil)
+	app := newTestApp(newTestHandler(mockRepo), testUserID)
+
+	resp, err := app.Test(httptest.NewRequest(
+		http.MethodGet, "/calendar.ics?start=2024-01-01T00:00:00Z&end=2025-01-01T00:00:00Z", nil,
+	))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, ical.ContentType, resp.Header.Get(fiber.HeaderContentType))
+	calendar, err := ical.Decode(resp.Body)
+	require.NoError(t, err)
+	components := calendar.Children("VEVENT")
+	require.Len(t, components, 2)
+	require.Equal(t, event.ID, components[0].Get("UID").Text())
+	require.Equal(t, event.Title, components[0].Get("SUMMARY").Text())
+	startTime, err := components[0].Get("DTSTART").Time()
+	require.NoError(t, err)
+	require.Equal(t, event.StartTime.Truncate(time.Second), startTime)
+	alarms := components[0].Children("VALARM")
+	require.Len(t, alarms, 2)
+	require.Equal(t, "-PT15M", alarms[0].Get("TRIGGER").Value)
+	require.Equal(t, "20240101T080000Z", alarms[1].Get("TRIGGER").Value)
+	require.Equal(t, "DATE-TIME", alarms[1].Get("TRIGGER").Params["VALUE"])
+	require.Equal(t, "FREQ=WEEKLY", components[1].Get("RRULE").Value)
+	require.Equal(t, domain.FormatRecurrenceTimes(series.Recurrence.Exceptions), components[1].Get("EXDATE").Value)
+	require.Empty(t, components[1].Children("VALARM"))
+}
+
+func TestICalHandler_ExportInvalidPeriod(t *testing.T) {
+	app := newTestApp(newTestHandler(new(mocks.EventRepository)), testUserID)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/calendar.ics?start=yesterday", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestICalHandler_WithoutUser(t *testing.T) {
+	app := newTestApp(newTestHandler(new(mocks.EventRepository)), 0)
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		resp, err := app.Test(httptest.NewRequest(method, "/calendar.ics", nil))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestICalHandler_Import(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	mockRepo.On("GetOverlappingEvents", mock.Anything, testUserID, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	busy := tests.GenerateTestEvent()
+	busy.StartTime = time.Date(2024, time.January, 5, 12, 30, 0, 0, time.UTC)
+	busy.EndTime = nil
+	mockRepo.On("GetOverlappingEvents", mock.Anything, testUserID, mock.Anything, mock.Anything).
+		Return([]*domain.Event{busy}, nil).Once()
+	var added *domain.Event
+	mockRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		added = args[1].(*domain.Event)
+	}).Return(nil).Once()
+	app := newTestApp(newTestHandler(mockRepo), testUserID)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "calendar.ics")
+	require.NoError(t, err)
+	_, err = io.WriteString(part, testCalendar)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	req := httptest.NewRequest(http.MethodPost, "/calendar.ics", &body)
+	req.Header.Set(fiber.HeaderContentType, writer.FormDataContentType())
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result ICalImportResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, 1, result.Imported)
+	require.Len(t, result.Items, 3)
+	require.Equal(t, &ICalImportItem{Index: 0, UID: "first@example.com", ID: added.ID}, result.Items[0])
+	require.Equal(t, "second@example.com", result.Items[1].UID)
+	require.Contains(t, result.Items[1].Error, "DTSTART")
+	require.Equal(t, domain.ErrDateBusy.Error(), result.Items[2].Error)
+
+	require.Equal(t, testUserID, added.UserID)
+	require.Equal(t, "Standup, daily", added.Title)
+	require.Equal(t, "Europe/Berlin", added.TimeZone)
+	require.Equal(t, time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC), added.StartTime)
+	require.Equal(t, added.StartTime.Add(15*time.Minute), *added.EndTime)
+	// The absolute trigger of the recurring event is converted to an offset.
+	require.Equal(t, []*domain.Reminder{{Offset: -15 * time.Minute}, {Offset: 20 * time.Minute}}, added.Reminders)
+	require.Equal(t, "FREQ=DAILY;COUNT=5", added.Recurrence.Rule())
+	require.Equal(t, []time.Time{
+		time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 3, 9, 0, 0, 0, time.UTC),
+	}, added.Recurrence.Exceptions)
+}
+
+func TestICalHandler_ImportBody(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	mockRepo.On("Add", mock.Anything, mock.Anything).Return(nil).Times(3)
+	app := newTestApp(newTestHandler(mockRepo), testUserID)
+	data := strings.ReplaceAll(testCalendar, "SUMMARY:No start\r\n", "SUMMARY:No start\r\nDTSTART:20240105T120000Z\r\n")
+
+	req := httptest.NewRequest(http.MethodPost, "/calendar.ics?allow_overlap=true", strings.NewReader(data))
+	req.Header.Set(fiber.HeaderContentType, ical.ContentType)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result ICalImportResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, 3, result.Imported)
+}
+
+func TestICalHandler_AllDay(t *testing.T) {
+	mockRepo := new(mocks.EventRepository)
+	event := tests.GenerateTestEvent()
+	event.UserID = testUserID
+	event.StartTime = time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)
+	event.EndTime = nil
+	event.TimeZone = "Europe/Berlin"
+	event.AllDay = true
+	event.Recurrence = &domain.Recurrence{Frequency: domain.FrequencyDaily, Interval: 1, Count: 3}
+	event.NormalizeTime()
+	event.Recurrence.Exceptions = []time.Time{event.StartTime.AddDate(0, 0, 1)}
+	mockRepo.On("IterateEvents", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		require.NoError(t, args[2].(func(e *domain.Event) error)(event))
+	}).Return(nil)
+	var added *domain.Event
+	mockRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		added = args[1].(*domain.Event)
+	}).Return(nil).Once()
+	app := newTestApp(newTestHandler(mockRepo), testUserID)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	// Dates of the all-day event are rendered in its time zone.
+	require.Contains(t, string(data), "DTSTART;VALUE=DATE:20241225\r\n")
+	require.Contains(t, string(data), "DTEND;VALUE=DATE:20241226\r\n")
+	require.Contains(t, string(data), "EXDATE;VALUE=DATE:20241226\r\n")
+
+	req := httptest.NewRequest(http.MethodPost, "/calendar.ics?allow_overlap=true", bytes.NewReader(data))
+	req.Header.Set(fiber.HeaderContentType, ical.ContentType)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	mockRepo.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.True(t, added.AllDay)
+	require.Equal(t, time.UTC.String(), added.TimeZone)
+	require.Equal(t, time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC), added.StartTime)
+	require.Equal(t, time.Date(2024, time.December, 26, 0, 0, 0, 0, time.UTC), *added.EndTime)
+	require.Equal(t, []time.Time{time.Date(2024, time.December, 26, 0, 0, 0, 0, time.UTC)}, added.Recurrence.Exceptions)
+}
+
+func TestICalHandler_ImportInvalid(t *testing.T) {
+	app := newTestApp(newTestHandler(new(mocks.EventRepository)), testUserID)
+
+	for _, data := range []string{"", "BEGIN:VCALENDAR\r\n", "BEGIN:VEVENT\r\nEND:VEVENT\r\n"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/calendar.ics", strings.NewReader(data)))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, data)
+	}
+}
@@ -0,0 +1,59 @@
+package fiber
+
+import (
+	"strings"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// userIDMiddleware puts ID of the user making the request to the user context, the user is identified
+// by the header only as in gRPC calls.
+func userIDMiddleware(c fiber.Ctx) error {
+	userID, err := presentation.ParseUserID(c.Get(presentation.UserIDHeader))
+	if common.IsErr(err) {
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+	c.SetUserContext(presentation.WithUserID(c.UserContext(), userID))
+	return c.Next()
+}
+
+// headerCarrier adapts request headers to the trace context extraction.
+type headerCarrier struct {
+	c fiber.Ctx
+}
+
+// Get returns the value of the request header.
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+// Set does nothing, request headers are read-only.
+func (h headerCarrier) Set(string, string) {}
+
+// Keys returns nil, the propagators read known keys only.
+func (h headerCarrier) Keys() []string {
+	return nil
+}
+
+// tracingMiddleware traces the request continuing the trace of the request headers,
+// values of the request are copied since fiber reuses their buffers.
+func tracingMiddleware(c fiber.Ctx) error {
+	method, path := strings.Clone(c.Method()), strings.Clone(c.Path())
+	ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c: c})
+	ctx, span := common.Tracer.Start(
+		ctx,
+		method+" "+path,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPMethod(method), semconv.URLPath(path)),
+	)
+	defer span.End()
+	c.SetUserContext(ctx)
+	err := c.Next()
+	span.SetAttributes(semconv.HTTPStatusCode(c.Response().StatusCode()))
+	return err
+}
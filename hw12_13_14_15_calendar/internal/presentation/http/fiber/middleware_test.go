@@ -0,0 +1,48 @@
+package fiber
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIDMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c fiber.Ctx) error {
+		userID, err := presentation.UserIDFromContext(c.UserContext())
+		if err != nil {
+			return err
+		}
+		return c.SendString(strconv.FormatInt(userID, 10))
+	}, userIDMiddleware)
+
+	cases := []struct {
+		name   string
+		header string
+		query  string
+		status int
+	}{
+		{name: "header", header: "7", status: http.StatusOK},
+		// The user ID query param isn't trusted, it'd expose calendars of other users.
+		{name: "query", query: "?user_id=7", status: http.StatusUnauthorized},
+		{name: "missing", status: http.StatusUnauthorized},
+		{name: "invalid", header: "-7", status: http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+c.query, nil)
+			if c.header != "" {
+				req.Header.Set(presentation.UserIDHeader, c.header)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, c.status, resp.StatusCode)
+		})
+	}
+}
@@ -2,24 +2,30 @@ package fiber
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/application"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/http/fiber/handlers"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
 	"github.com/gofiber/fiber/v3/middleware/logger"
 	"github.com/gofiber/fiber/v3/middleware/pprof"
 	"github.com/gofiber/fiber/v3/middleware/recover"
 )
 
-// NewServer returns a new instance of a server.
-func NewServer() presentation.Server {
-	return &server{}
+// NewServer returns a new instance of a server reporting health of the components and the dependencies.
+func NewServer(health presentation.HealthReporter, readiness presentation.ReadinessChecker) presentation.Server {
+	return &server{health: health, readiness: readiness}
 }
 
 type server struct {
-	app *fiber.App
+	app       *fiber.App
+	health    presentation.HealthReporter
+	readiness presentation.ReadinessChecker
 }
 
 // Start starts the HTTP server.
@@ -45,22 +51,38 @@ func (s *server) Start(ctx context.Context) error {
 
 	// Routes
 	app.Get("/", handlers.HelloWorld)
-	api := app.Group("/api/v1")
+	app.Get("/metrics", adaptor.HTTPHandler(common.MetricsHandler()))
+	api := app.Group("/api/v1", tracingMiddleware)
+	healthHandler := handlers.NewHealthHandler(s.health, s.readiness)
 	api.Get("/health/", handlers.HealthCheck)
+	api.Get("/health/live", handlers.HealthCheck)
+	api.Get("/health/ready", healthHandler.Ready)
+	api.Get("/health/components", healthHandler.Components)
+	iCal := handlers.NewICalHandler(application.EventApplicationService)
+	api.Get("/calendar.ics", iCal.Export, userIDMiddleware)
+	api.Post("/calendar.ics", iCal.Import, userIDMiddleware)
+	app.Hooks().OnListen(func(fiber.ListenData) error {
+		supervisor.Ready(ctx)
+		return nil
+	})
+	errCh := make(chan error, 1)
 	go func() {
-		if err := app.Listen(common.GetServerAddr(common.Config.Server.Host, common.Config.Server.Port)); common.IsErr(
-			err,
-		) {
-			common.Logger.Fatal().Msg("fiber Listen(): " + err.Error())
-		}
+		errCh <- app.Listen(common.GetServerAddr(common.Config.Server.Host, common.Config.Server.Port))
 	}()
 	common.Logger.Info().Msg("fiber service started")
-	<-ctx.Done()
-	return nil
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		return fmt.Errorf("fiber Listen(): %w", err)
+	}
 }
 
 // Stop stops the HTTP server.
 func (s *server) Stop(ctx context.Context) error {
 	common.Logger.Info().Msg("fiber service is stopping...")
+	if s.app == nil {
+		return nil
+	}
 	return s.app.ShutdownWithContext(ctx)
 }
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
+)
+
+// NewServer returns a new instance of a server exporting metrics on /metrics of the address,
+// it serves the liveness and readiness probes on /health/live and /health/ready as well.
+func NewServer(addr string, readiness presentation.ReadinessChecker) presentation.Server {
+	return &server{addr: addr, readiness: readiness}
+}
+
+type server struct {
+	addr       string
+	httpServer *http.Server
+	readiness  presentation.ReadinessChecker
+}
+
+// live responds ok while the process is running.
+func live(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("ok"))
+}
+
+// ready responds with results of the readiness checks, the status is 503 if any of them fails.
+func (s *server) ready(w http.ResponseWriter, r *http.Request) {
+	report := s.readiness.Check(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); common.IsErr(err) {
+		common.Logger.Error().Msgf("failed to write the readiness report: %v", err)
+	}
+}
+
+// Start starts the metrics server.
+func (s *server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", common.MetricsHandler())
+	mux.HandleFunc("/health/live", live)
+	mux.HandleFunc("/health/ready", s.ready)
+	s.httpServer = &http.Server{
+		Addr:              s.addr,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Duration(common.Config.Server.ReadHeaderTimeout) * time.Second,
+		ReadTimeout:       time.Duration(common.Config.Server.ReadTimeout) * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("metrics ListenAndServe(): %w", err)
+		}
+	}()
+	common.Logger.Info().Msgf("metrics server started on %s", s.addr)
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Stop stops the metrics server.
+func (s *server) Stop(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Shutdown(ctx)
+}
@@ -1,9 +1,25 @@
 package presentation
 
-import "context"
+import (
+	"context"
+
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/health"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/pkg/supervisor"
+)
 
 // Server is an interface for HTTP server.
 type Server interface {
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
 }
+
+// HealthReporter reports health statuses of service components.
+type HealthReporter interface {
+	Health() []supervisor.Health
+	Healthy() bool
+}
+
+// ReadinessChecker checks whether dependencies of the service are available.
+type ReadinessChecker interface {
+	Check(ctx context.Context) health.Report
+}
@@ -0,0 +1,38 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// UserIDHeader is a request metadata key (HTTP header) with ID of the user.
+const UserIDHeader = "x-user-id"
+
+// ErrUserID is returned for a missing or malformed user ID.
+var ErrUserID = errors.New("valid " + UserIDHeader + " is required")
+
+type userIDKey struct{}
+
+// ParseUserID parses a user ID from the request metadata value.
+func ParseUserID(value string) (int64, error) {
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, ErrUserID
+	}
+	return userID, nil
+}
+
+// WithUserID returns a copy of the context with the user ID.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID stored in the context.
+func UserIDFromContext(ctx context.Context) (int64, error) {
+	userID, ok := ctx.Value(userIDKey{}).(int64)
+	if !ok {
+		return 0, ErrUserID
+	}
+	return userID, nil
+}
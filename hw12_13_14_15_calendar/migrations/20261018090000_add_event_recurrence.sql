@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE event
+    ADD COLUMN recurrence_rule       text,
+    ADD COLUMN recurrence_exceptions timestamp[],
+    ADD COLUMN recurrence_end        timestamp;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event
+    DROP COLUMN recurrence_rule,
+    DROP COLUMN recurrence_exceptions,
+    DROP COLUMN recurrence_end;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE notification_outbox
+(
+    id                bigserial primary key,
+    event_id          uuid      not null references event (id) on delete cascade,
+    payload           jsonb     not null,
+    notify_time       timestamp not null,
+    attempts          int       not null default 0,
+    next_attempt_time timestamp not null,
+    last_error        text,
+    sent_time         timestamp,
+    failed_time       timestamp,
+    created_time      timestamp not null default now()
+);
+CREATE INDEX notification_outbox_pending_idx ON notification_outbox (next_attempt_time, id)
+    WHERE sent_time IS NULL AND failed_time IS NULL;
+CREATE INDEX notification_outbox_event_id_idx ON notification_outbox (event_id);
+-- Upcoming notifications of single events, recurring events are enqueued on their next update.
+INSERT INTO notification_outbox (event_id, payload, notify_time, next_attempt_time)
+SELECT id,
+       json_build_object('EventID', id, 'EventTitle', title,
+                         'EventDate', to_char(start_time, 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'), 'UserToSend', user_id),
+       notify_time,
+       notify_time
+FROM event
+WHERE recurrence_rule IS NULL
+  AND notify_time >= now() AT TIME ZONE 'UTC';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_outbox;
+-- +goose StatementEnd
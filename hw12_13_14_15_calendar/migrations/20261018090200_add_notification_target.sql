@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE event
+    ADD COLUMN notification_channel text,
+    ADD COLUMN notification_address text;
+CREATE TABLE user_notification_target
+(
+    user_id      bigint primary key,
+    channel      text      not null,
+    address      text      not null,
+    updated_time timestamp not null default now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_notification_target;
+ALTER TABLE event
+    DROP COLUMN notification_channel,
+    DROP COLUMN notification_address;
+-- +goose StatementEnd
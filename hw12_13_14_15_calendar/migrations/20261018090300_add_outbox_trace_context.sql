@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notification_outbox
+    ADD COLUMN trace_context text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notification_outbox
+    DROP COLUMN trace_context;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE event
+    ADD COLUMN deleted_time timestamp;
+CREATE INDEX event_deleted_time_idx ON event (deleted_time) WHERE deleted_time IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX event_deleted_time_idx;
+ALTER TABLE event
+    DROP COLUMN deleted_time;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- History is kept after events are deleted, so it doesn't reference the event table.
+CREATE TABLE event_history
+(
+    id           bigserial primary key,
+    event_id     uuid      not null,
+    owner_id     bigint    not null,
+    actor_id     bigint    not null,
+    request_id   text      not null default '',
+    action       text      not null,
+    before       jsonb,
+    after        jsonb,
+    changed_time timestamp not null
+);
+CREATE INDEX event_history_event_id_idx ON event_history (event_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE event_history;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE idempotency_key
+(
+    user_id        bigint    not null,
+    request_id     text      not null,
+    request_hash   text      not null,
+    response       bytea,
+    created_time   timestamp not null,
+    completed_time timestamp,
+    expires_time   timestamp not null,
+    primary key (user_id, request_id)
+);
+CREATE INDEX idempotency_key_expires_time_idx ON idempotency_key (expires_time);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE idempotency_key;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE event_attendee
+(
+    event_id     uuid      not null references event (id) on delete cascade,
+    user_id      bigint    not null,
+    status       text      not null default 'invited',
+    created_time timestamp not null default now(),
+    updated_time timestamp not null,
+    primary key (event_id, user_id)
+);
+CREATE INDEX event_attendee_user_id_idx ON event_attendee (user_id, status);
+-- Outbox messages keep a list of notifications of the owner and the attendees.
+UPDATE notification_outbox
+SET payload = jsonb_build_array(payload)
+WHERE jsonb_typeof(payload) = 'object';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE notification_outbox
+SET payload = payload -> 0
+WHERE jsonb_typeof(payload) = 'array';
+DROP TABLE event_attendee;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE calendar
+(
+    id           uuid primary key,
+    user_id      bigint    not null,
+    name         text      not null,
+    color        text      not null default '',
+    time_zone    text      not null default 'UTC',
+    is_default   boolean   not null default false,
+    created_time timestamp not null default now(),
+    updated_time timestamp not null
+);
+CREATE INDEX calendar_user_id_idx ON calendar (user_id);
+CREATE UNIQUE INDEX calendar_default_idx ON calendar (user_id) WHERE is_default;
+CREATE TABLE calendar_share
+(
+    calendar_id  uuid      not null references calendar (id) on delete cascade,
+    user_id      bigint    not null,
+    access       text      not null,
+    created_time timestamp not null default now(),
+    updated_time timestamp not null,
+    primary key (calendar_id, user_id)
+);
+CREATE INDEX calendar_share_user_id_idx ON calendar_share (user_id);
+-- Existing events are moved to default calendars of their owners.
+INSERT INTO calendar (id, user_id, name, is_default, updated_time)
+SELECT gen_random_uuid(), user_id, 'Default', true, now()
+FROM event
+GROUP BY user_id;
+ALTER TABLE event ADD COLUMN calendar_id uuid references calendar (id);
+UPDATE event
+SET calendar_id = calendar.id
+FROM calendar
+WHERE calendar.user_id = event.user_id;
+ALTER TABLE event ALTER COLUMN calendar_id SET NOT NULL;
+CREATE INDEX event_calendar_id_idx ON event (calendar_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event DROP COLUMN calendar_id;
+DROP TABLE calendar_share;
+DROP TABLE calendar;
+-- +goose StatementEnd
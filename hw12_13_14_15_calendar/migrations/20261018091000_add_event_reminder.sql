@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE event_reminder
+(
+    id          bigserial primary key,
+    event_id    uuid   not null references event (id) on delete cascade,
+    offset_ms   bigint not null default 0,
+    remind_time timestamp
+);
+CREATE INDEX event_reminder_event_id_idx ON event_reminder (event_id);
+-- Notify times become offsets from the start time, so reminders follow moved events.
+INSERT INTO event_reminder (event_id, offset_ms)
+SELECT id, (EXTRACT(EPOCH FROM notify_time - start_time) * 1000)::bigint
+FROM event
+WHERE notify_time IS NOT NULL;
+ALTER TABLE event DROP COLUMN notify_time;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event ADD COLUMN notify_time timestamp;
+UPDATE event
+SET notify_time = COALESCE(r.remind_time, event.start_time + r.offset_ms * interval '1 millisecond')
+FROM (SELECT DISTINCT ON (event_id) event_id, offset_ms, remind_time FROM event_reminder ORDER BY event_id, id) r
+WHERE r.event_id = event.id;
+DROP TABLE event_reminder;
+-- +goose StatementEnd
@@ -0,0 +1,84 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Times were stored in UTC without a zone. All-day events keep zoned bounds at midnights of their time zone
+-- rather than dates, so the overlap and period queries compare a single pair of columns for all events.
+ALTER TABLE event
+    ALTER COLUMN start_time TYPE timestamptz USING start_time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE timestamptz USING end_time AT TIME ZONE 'UTC',
+    ALTER COLUMN created_time TYPE timestamptz USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamptz USING updated_time AT TIME ZONE 'UTC',
+    ALTER COLUMN deleted_time TYPE timestamptz USING deleted_time AT TIME ZONE 'UTC',
+    ALTER COLUMN recurrence_end TYPE timestamptz USING recurrence_end AT TIME ZONE 'UTC',
+    ADD COLUMN time_zone text not null default 'UTC',
+    ADD COLUMN all_day boolean not null default false;
+ALTER TABLE event_reminder
+    ALTER COLUMN remind_time TYPE timestamptz USING remind_time AT TIME ZONE 'UTC';
+ALTER TABLE notification_outbox
+    ALTER COLUMN notify_time TYPE timestamptz USING notify_time AT TIME ZONE 'UTC',
+    ALTER COLUMN next_attempt_time TYPE timestamptz USING next_attempt_time AT TIME ZONE 'UTC',
+    ALTER COLUMN sent_time TYPE timestamptz USING sent_time AT TIME ZONE 'UTC',
+    ALTER COLUMN failed_time TYPE timestamptz USING failed_time AT TIME ZONE 'UTC',
+    ALTER COLUMN created_time TYPE timestamptz USING created_time AT TIME ZONE 'UTC';
+ALTER TABLE user_notification_target
+    ALTER COLUMN updated_time TYPE timestamptz USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE event_history
+    ALTER COLUMN changed_time TYPE timestamptz USING changed_time AT TIME ZONE 'UTC';
+ALTER TABLE idempotency_key
+    ALTER COLUMN created_time TYPE timestamptz USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN completed_time TYPE timestamptz USING completed_time AT TIME ZONE 'UTC',
+    ALTER COLUMN expires_time TYPE timestamptz USING expires_time AT TIME ZONE 'UTC';
+ALTER TABLE event_attendee
+    ALTER COLUMN created_time TYPE timestamptz USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamptz USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE calendar
+    ALTER COLUMN created_time TYPE timestamptz USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamptz USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE calendar_share
+    ALTER COLUMN created_time TYPE timestamptz USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamptz USING updated_time AT TIME ZONE 'UTC';
+-- Array elements are cast in the session time zone since the conversion can't use subqueries.
+SET LOCAL TIME ZONE 'UTC';
+ALTER TABLE event
+    ALTER COLUMN recurrence_exceptions TYPE timestamptz[] USING recurrence_exceptions::timestamptz[];
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET LOCAL TIME ZONE 'UTC';
+ALTER TABLE event
+    ALTER COLUMN recurrence_exceptions TYPE timestamp[] USING recurrence_exceptions::timestamp[];
+ALTER TABLE calendar_share
+    ALTER COLUMN created_time TYPE timestamp USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamp USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE calendar
+    ALTER COLUMN created_time TYPE timestamp USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamp USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE event_attendee
+    ALTER COLUMN created_time TYPE timestamp USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamp USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE idempotency_key
+    ALTER COLUMN created_time TYPE timestamp USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN completed_time TYPE timestamp USING completed_time AT TIME ZONE 'UTC',
+    ALTER COLUMN expires_time TYPE timestamp USING expires_time AT TIME ZONE 'UTC';
+ALTER TABLE event_history
+    ALTER COLUMN changed_time TYPE timestamp USING changed_time AT TIME ZONE 'UTC';
+ALTER TABLE user_notification_target
+    ALTER COLUMN updated_time TYPE timestamp USING updated_time AT TIME ZONE 'UTC';
+ALTER TABLE notification_outbox
+    ALTER COLUMN notify_time TYPE timestamp USING notify_time AT TIME ZONE 'UTC',
+    ALTER COLUMN next_attempt_time TYPE timestamp USING next_attempt_time AT TIME ZONE 'UTC',
+    ALTER COLUMN sent_time TYPE timestamp USING sent_time AT TIME ZONE 'UTC',
+    ALTER COLUMN failed_time TYPE timestamp USING failed_time AT TIME ZONE 'UTC',
+    ALTER COLUMN created_time TYPE timestamp USING created_time AT TIME ZONE 'UTC';
+ALTER TABLE event_reminder
+    ALTER COLUMN remind_time TYPE timestamp USING remind_time AT TIME ZONE 'UTC';
+ALTER TABLE event
+    DROP COLUMN all_day,
+    DROP COLUMN time_zone,
+    ALTER COLUMN start_time TYPE timestamp USING start_time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE timestamp USING end_time AT TIME ZONE 'UTC',
+    ALTER COLUMN created_time TYPE timestamp USING created_time AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_time TYPE timestamp USING updated_time AT TIME ZONE 'UTC',
+    ALTER COLUMN deleted_time TYPE timestamp USING deleted_time AT TIME ZONE 'UTC',
+    ALTER COLUMN recurrence_end TYPE timestamp USING recurrence_end AT TIME ZONE 'UTC';
+-- +goose StatementEnd
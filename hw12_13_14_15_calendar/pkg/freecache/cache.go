@@ -44,6 +44,7 @@ func (db *CacheDB) Set(key, val []byte, expireIn int) error {
 func (db *CacheDB) Del(key []byte) (affected bool) {
 	db.mx.Lock()
 	defer db.mx.Unlock()
+	delete(db.keys, xxhash.Sum64(key))
 	return db.cache.Del(key)
 }
 
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Statuses of checks.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check checks a dependency of the service, it returns an error if the dependency isn't available.
+type Check func(ctx context.Context) error
+
+// Result is a result of a check.
+type Result struct {
+	Name   string
+	Status string
+	Error  string `json:",omitempty"`
+}
+
+// Report is a result of all checks, the service is ready if all of them are up.
+type Report struct {
+	Ready  bool
+	Checks []Result
+}
+
+type check struct {
+	name  string
+	check Check
+}
+
+// Checker runs checks of the service dependencies.
+type Checker struct {
+	mu      sync.RWMutex
+	checks  []check
+	timeout time.Duration
+}
+
+// NewChecker returns a new instance of the checker, every check is canceled after the timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add adds the check, checks may be added while the checker is in use.
+func (c *Checker) Add(name string, fn Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, check: fn})
+}
+
+// Check runs the checks concurrently and returns their results in the order they were added.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	report := Report{Ready: true, Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		i, ch := i, ch
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = Result{Name: ch.name, Status: StatusUp}
+			if err := ch.check(ctx); err != nil {
+				report.Checks[i].Status, report.Checks[i].Error = StatusDown, err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+	for _, r := range report.Checks {
+		if r.Status != StatusUp {
+			report.Ready = false
+		}
+	}
+	return report
+}
@@ -0,0 +1,35 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	checker := NewChecker(time.Second)
+	require.Equal(t, Report{Ready: true, Checks: []Result{}}, checker.Check(context.Background()))
+
+	checker.Add("db", func(context.Context) error { return nil })
+	checker.Add("broker", func(context.Context) error { return errors.New("connection refused") })
+	report := checker.Check(context.Background())
+	require.False(t, report.Ready)
+	require.Equal(t, []Result{
+		{Name: "db", Status: StatusUp},
+		{Name: "broker", Status: StatusDown, Error: "connection refused"},
+	}, report.Checks)
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Add("db", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	report := checker.Check(context.Background())
+	require.False(t, report.Ready)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+}
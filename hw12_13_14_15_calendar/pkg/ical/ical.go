@@ -0,0 +1,410 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ContentType is a MIME type of iCalendar data.
+	ContentType = "text/calendar; charset=utf-8"
+
+	timeFormat      = "20060102T150405Z"
+	localTimeFormat = "20060102T150405"
+	dateFormat      = "20060102"
+	// maxLineLength is a maximal length of a content line in octets without CRLF.
+	maxLineLength = 75
+)
+
+// ErrSyntax is returned for malformed iCalendar data.
+var ErrSyntax = errors.New("invalid iCalendar data")
+
+// Property is a content line of a component, e.g. "DTSTART;TZID=Europe/Berlin:20240101T100000".
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Component is an iCalendar component, e.g. VCALENDAR, VEVENT or VALARM.
+type Component struct {
+	Name       string
+	Properties []*Property
+	Components []*Component
+}
+
+// NewComponent returns a new component.
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add adds a property to the component.
+func (c *Component) Add(name, value string) *Property {
+	p := &Property{Name: name, Value: value}
+	c.Properties = append(c.Properties, p)
+	return p
+}
+
+// AddText adds a property with an escaped text value.
+func (c *Component) AddText(name, value string) *Property {
+	return c.Add(name, EscapeText(value))
+}
+
+// Get returns the first property with the name or nil.
+func (c *Component) Get(name string) *Property {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// GetAll returns all properties with the name.
+func (c *Component) GetAll(name string) []*Property {
+	var result []*Property
+	for _, p := range c.Properties {
+		if p.Name == name {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Children returns subcomponents with the name.
+func (c *Component) Children(name string) []*Component {
+	var result []*Component
+	for _, child := range c.Components {
+		if child.Name == name {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// Text returns the unescaped text value of the property.
+func (p *Property) Text() string {
+	return UnescapeText(p.Value)
+}
+
+// Time returns the date-time (or date) value of the property,
+// floating time is parsed in the TZID location or UTC.
+func (p *Property) Time() (time.Time, error) {
+	location := time.UTC
+	if tzid, ok := p.Params["TZID"]; ok {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s: %w", ErrSyntax, p.Name, err)
+		}
+		location = l
+	}
+	var (
+		t   time.Time
+		err error
+	)
+	switch {
+	case p.IsDate():
+		t, err = time.ParseInLocation(dateFormat, p.Value, location)
+	case strings.HasSuffix(p.Value, "Z"):
+		t, err = time.Parse(timeFormat, p.Value)
+	default:
+		t, err = time.ParseInLocation(localTimeFormat, p.Value, location)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s: %w", ErrSyntax, p.Name, err)
+	}
+	return t, nil
+}
+
+// FormatTime formats the time as an UTC date-time value.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// FormatDate formats the date of the time in its location as a date value.
+func FormatDate(t time.Time) string {
+	return t.Format(dateFormat)
+}
+
+// IsDate reports whether the property has a date value.
+func (p *Property) IsDate() bool {
+	return p.Params["VALUE"] == "DATE" || len(p.Value) == len(dateFormat)
+}
+
+// FormatDuration formats the duration as a duration value, e.g. "-PT1H30M".
+func FormatDuration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteByte('P')
+	if days := d / (24 * time.Hour); days > 0 {
+		b.WriteString(strconv.FormatInt(int64(days), 10) + "D")
+		d -= days * 24 * time.Hour
+	}
+	if d > 0 || b.Len() <= 2 {
+		b.WriteByte('T')
+		for _, unit := range []struct {
+			d      time.Duration
+			suffix string
+		}{{time.Hour, "H"}, {time.Minute, "M"}, {time.Second, "S"}} {
+			if n := d / unit.d; n > 0 {
+				b.WriteString(strconv.FormatInt(int64(n), 10) + unit.suffix)
+				d -= n * unit.d
+			}
+		}
+		if b.String()[b.Len()-1] == 'T' {
+			b.WriteString("0S")
+		}
+	}
+	return b.String()
+}
+
+// ParseDuration parses a duration value, e.g. "-P1DT15M".
+func ParseDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("%w: duration %q", ErrSyntax, value)
+	}
+	var d time.Duration
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	number := ""
+	for i := 1; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch >= '0' && ch <= '9':
+			number += string(ch)
+		case ch == 'T':
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		default:
+			unit, ok := units[ch]
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, fmt.Errorf("%w: duration %q", ErrSyntax, value)
+			}
+			d += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("%w: duration %q", ErrSyntax, value)
+	}
+	return sign * d, nil
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// EscapeText escapes a text value.
+func EscapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// UnescapeText unescapes a text value.
+func UnescapeText(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		if escaped {
+			if r == 'n' || r == 'N' {
+				r = '\n'
+			}
+			b.WriteRune(r)
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Encode writes the component with CRLF line endings and lines folded at 75 octets.
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	if err := encodeComponent(bw, c); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func encodeComponent(w *bufio.Writer, c *Component) error {
+	if err := writeLine(w, "BEGIN:"+c.Name); err != nil {
+		return err
+	}
+	for _, p := range c.Properties {
+		if err := writeLine(w, p.String()); err != nil {
+			return err
+		}
+	}
+	for _, child := range c.Components {
+		if err := encodeComponent(w, child); err != nil {
+			return err
+		}
+	}
+	return writeLine(w, "END:"+c.Name)
+}
+
+// String returns the content line of the property.
+func (p *Property) String() string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+	names := make([]string, 0, len(p.Params))
+	for name := range p.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := p.Params[name]
+		if strings.ContainsAny(value, ":;,") {
+			value = `"` + value + `"`
+		}
+		b.WriteString(";" + name + "=" + value)
+	}
+	b.WriteString(":" + p.Value)
+	return b.String()
+}
+
+// writeLine writes the content line folding it without splitting UTF-8 characters.
+func writeLine(w *bufio.Writer, line string) error {
+	limit := maxLineLength
+	for len(line) > limit {
+		n := limit
+		for n > 0 && line[n]&0xC0 == 0x80 {
+			n--
+		}
+		if _, err := w.WriteString(line[:n] + "\r\n "); err != nil {
+			return err
+		}
+		line = line[n:]
+		// The leading space of a continuation line is counted.
+		limit = maxLineLength - 1
+	}
+	_, err := w.WriteString(line + "\r\n")
+	return err
+}
+
+// Decode reads the first component of the data.
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		stack []*Component
+		root  *Component
+	)
+	for _, line := range lines {
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch p.Name {
+		case "BEGIN":
+			c := NewComponent(strings.ToUpper(p.Value))
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("%w: unexpected END:%s", ErrSyntax, p.Value)
+			}
+			root = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return root, nil
+			}
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: property %s outside of a component", ErrSyntax, p.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+	}
+	return nil, fmt.Errorf("%w: unterminated component", ErrSyntax)
+}
+
+// unfold returns content lines joining folded ones.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseLine parses a content line "NAME;PARAM=VALUE:VALUE".
+func parseLine(line string) (*Property, error) {
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, fmt.Errorf("%w: malformed line %q", ErrSyntax, line)
+	}
+	p := &Property{Name: strings.ToUpper(line[:i])}
+	rest := line[i:]
+	for rest[0] == ';' {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("%w: malformed parameter of %s", ErrSyntax, p.Name)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated parameter of %s", ErrSyntax, p.Name)
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: malformed parameter of %s", ErrSyntax, p.Name)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		if rest == "" {
+			return nil, fmt.Errorf("%w: property %s without value", ErrSyntax, p.Name)
+		}
+		if p.Params == nil {
+			p.Params = make(map[string]string)
+		}
+		p.Params[name] = value
+	}
+	if rest[0] != ':' {
+		return nil, fmt.Errorf("%w: malformed parameter of %s", ErrSyntax, p.Name)
+	}
+	p.Value = rest[1:]
+	return p, nil
+}
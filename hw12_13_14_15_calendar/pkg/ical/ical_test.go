@@ -0,0 +1,111 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	calendar := NewComponent("VCALENDAR")
+	calendar.Add("VERSION", "2.0")
+	event := NewComponent("VEVENT")
+	event.AddText("SUMMARY", "Meeting; room 1, floor 2")
+	event.AddText("DESCRIPTION", strings.Repeat("Длинное описание встречи. ", 10)+"\nLast line")
+	start := event.Add("DTSTART", "20240101T100000")
+	start.Params = map[string]string{"TZID": "Europe/Berlin"}
+	calendar.Components = append(calendar.Components, event)
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, calendar))
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+
+	decoded, err := Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, calendar, decoded)
+	events := decoded.Children("VEVENT")
+	require.Len(t, events, 1)
+	require.Equal(t, "Meeting; room 1, floor 2", events[0].Get("SUMMARY").Text())
+	require.Equal(t, event.Get("DESCRIPTION").Text(), events[0].Get("DESCRIPTION").Text())
+	startTime, err := events[0].Get("DTSTART").Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC), startTime.UTC())
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"BEGIN:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nEND:VEVENT\r\n",
+		"SUMMARY:x\r\n",
+		"BEGIN:VCALENDAR\r\nDTSTART;TZID\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nDTSTART;TZID=\"x:20240101\r\nEND:VCALENDAR\r\n",
+	} {
+		_, err := Decode(strings.NewReader(data))
+		require.ErrorIs(t, err, ErrSyntax, data)
+	}
+}
+
+func TestPropertyParams(t *testing.T) {
+	p, err := parseLine(`ATTENDEE;CN="Doe; John";ROLE=REQ-PARTICIPANT:mailto:john@example.com`)
+	require.NoError(t, err)
+	require.Equal(t, "ATTENDEE", p.Name)
+	require.Equal(t, map[string]string{"CN": "Doe; John", "ROLE": "REQ-PARTICIPANT"}, p.Params)
+	require.Equal(t, "mailto:john@example.com", p.Value)
+	require.Equal(t, `ATTENDEE;CN="Doe; John";ROLE=REQ-PARTICIPANT:mailto:john@example.com`, p.String())
+}
+
+func TestPropertyTime(t *testing.T) {
+	cases := []struct {
+		property Property
+		expected time.Time
+	}{
+		{Property{Value: "20240101T100000Z"}, time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)},
+		{Property{Value: "20240101T100000"}, time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)},
+		{
+			Property{Value: "20240101", Params: map[string]string{"VALUE": "DATE"}},
+			time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, c := range cases {
+		result, err := c.property.Time()
+		require.NoError(t, err)
+		require.Equal(t, c.expected, result)
+	}
+	require.Equal(t, "20240101", FormatDate(cases[2].expected))
+	require.True(t, cases[2].property.IsDate())
+	require.False(t, cases[0].property.IsDate())
+	_, err := (&Property{Value: "20240101T100000", Params: map[string]string{"TZID": "Mars/Olympus"}}).Time()
+	require.ErrorIs(t, err, ErrSyntax)
+}
+
+func TestDuration(t *testing.T) {
+	cases := []struct {
+		value    string
+		duration time.Duration
+	}{
+		{"PT0S", 0},
+		{"PT15M", 15 * time.Minute},
+		{"-PT1H30M", -90 * time.Minute},
+		{"P1DT2H", 26 * time.Hour},
+		{"P2D", 48 * time.Hour},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.value, FormatDuration(c.duration))
+		d, err := ParseDuration(c.value)
+		require.NoError(t, err)
+		require.Equal(t, c.duration, d)
+	}
+	d, err := ParseDuration("P1W")
+	require.NoError(t, err)
+	require.Equal(t, 7*24*time.Hour, d)
+	for _, value := range []string{"", "P", "PT", "15M", "PT15", "P1H"} {
+		_, err := ParseDuration(value)
+		require.ErrorIs(t, err, ErrSyntax, value)
+	}
+}
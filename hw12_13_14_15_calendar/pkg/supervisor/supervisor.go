@@ -0,0 +1,197 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Statuses of components.
+const (
+	StatusStarting = "starting"
+	StatusRunning  = "running"
+	StatusStopping = "stopping"
+	StatusStopped  = "stopped"
+	StatusFailed   = "failed"
+)
+
+// ErrStoppedUnexpectedly is a failure of a component which stopped before the shutdown.
+var ErrStoppedUnexpectedly = errors.New("component stopped unexpectedly")
+
+// Component is a long-running part of the service. Start blocks until the context is done or the component fails
+// and calls Ready with its context once the component is serving, Stop stops the component gracefully until
+// the context is done.
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// RunFunc is a component without graceful stop, it stops when the context of Start is done.
+type RunFunc func(ctx context.Context) error
+
+// Start runs the function.
+func (f RunFunc) Start(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Stop does nothing.
+func (f RunFunc) Stop(context.Context) error {
+	return nil
+}
+
+type readyKey struct{}
+
+// Ready reports that the component started with the context is running.
+func Ready(ctx context.Context) {
+	if ready, ok := ctx.Value(readyKey{}).(func()); ok {
+		ready()
+	}
+}
+
+// Health is a health status of a component.
+type Health struct {
+	Name   string
+	Status string
+	Error  string `json:",omitempty"`
+	// Since is a time of the last status change.
+	Since time.Time
+}
+
+type component struct {
+	name      string
+	component Component
+	health    Health
+}
+
+// Supervisor runs components with a shared context, all of them are stopped when any of them fails.
+type Supervisor struct {
+	mu         sync.RWMutex
+	components []*component
+}
+
+// New returns a new instance of the supervisor.
+func New() *Supervisor {
+	return &Supervisor{}
+}
+
+// Add adds the component, components must be added before Run.
+func (s *Supervisor) Add(name string, c Component) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.components = append(s.components, &component{
+		name:      name,
+		component: c,
+		health:    Health{Name: name, Status: StatusStarting, Since: time.Now().UTC()},
+	})
+}
+
+func (s *Supervisor) setStatus(c *component, status string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.health.Status == StatusFailed {
+		return
+	}
+	c.health.Status, c.health.Since = status, time.Now().UTC()
+	if err != nil {
+		c.health.Error = err.Error()
+	}
+}
+
+// setRunning sets the running status of the component unless it's already stopping or failed.
+func (s *Supervisor) setRunning(c *component) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.health.Status == StatusStarting {
+		c.health.Status, c.health.Since = StatusRunning, time.Now().UTC()
+	}
+}
+
+// Health returns health statuses of the components in the order they were added.
+func (s *Supervisor) Health() []Health {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	health := make([]Health, len(s.components))
+	for i, c := range s.components {
+		health[i] = c.health
+	}
+	return health
+}
+
+// Healthy reports whether all components are running.
+func (s *Supervisor) Healthy() bool {
+	for _, h := range s.Health() {
+		if h.Status != StatusRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// Run starts the components and blocks until the context is done or any component fails, then it stops
+// the components waiting for them up to the shutdown timeout. It returns errors of the failed components.
+func (s *Supervisor) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.mu.RLock()
+	components := s.components
+	s.mu.RUnlock()
+
+	var (
+		errMu sync.Mutex
+		errs  []error
+	)
+	addErr := func(name string, err error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+	exited := make([]chan struct{}, len(components))
+	for i, c := range components {
+		c, done := c, make(chan struct{})
+		exited[i] = done
+		s.setStatus(c, StatusStarting, nil)
+		startCtx := context.WithValue(ctx, readyKey{}, func() { s.setRunning(c) })
+		go func() {
+			defer close(done)
+			err := c.component.Start(startCtx)
+			if ctx.Err() == nil && err == nil {
+				err = ErrStoppedUnexpectedly
+			}
+			if err != nil {
+				s.setStatus(c, StatusFailed, err)
+				addErr(c.name, err)
+				cancel()
+			}
+		}()
+	}
+	<-ctx.Done()
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer stopCancel()
+	var wg sync.WaitGroup
+	for i, c := range components {
+		c, done := c, exited[i]
+		s.setStatus(c, StatusStopping, nil)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.component.Stop(stopCtx); err != nil {
+				s.setStatus(c, StatusFailed, err)
+				addErr(c.name, err)
+				return
+			}
+			select {
+			case <-done:
+				s.setStatus(c, StatusStopped, nil)
+			case <-stopCtx.Done():
+				addErr(c.name, fmt.Errorf("shutdown timed out: %w", stopCtx.Err()))
+			}
+		}()
+	}
+	wg.Wait()
+	errMu.Lock()
+	defer errMu.Unlock()
+	return errors.Join(errs...)
+}
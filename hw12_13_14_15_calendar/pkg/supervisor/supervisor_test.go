@@ -0,0 +1,151 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testComponent struct {
+	started chan struct{}
+	stopped atomic.Bool
+	// block makes Start ignore the context.
+	block bool
+}
+
+func newTestComponent() *testComponent {
+	return &testComponent{started: make(chan struct{})}
+}
+
+func (c *testComponent) Start(ctx context.Context) error {
+	Ready(ctx)
+	close(c.started)
+	if c.block {
+		select {}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (c *testComponent) Stop(context.Context) error {
+	c.stopped.Store(true)
+	return nil
+}
+
+func statuses(s *Supervisor) map[string]string {
+	result := map[string]string{}
+	for _, h := range s.Health() {
+		result[h.Name] = h.Status
+	}
+	return result
+}
+
+func TestSupervisor_Shutdown(t *testing.T) {
+	s := New()
+	first, second := newTestComponent(), newTestComponent()
+	s.Add("first", first)
+	s.Add("second", second)
+	funcStarted := make(chan struct{})
+	s.Add("func", RunFunc(func(ctx context.Context) error {
+		Ready(ctx)
+		close(funcStarted)
+		<-ctx.Done()
+		return nil
+	}))
+	require.False(t, s.Healthy())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.Run(ctx, time.Second)
+	}()
+	<-first.started
+	<-second.started
+	<-funcStarted
+	require.True(t, s.Healthy())
+	require.Equal(t, map[string]string{
+		"first": StatusRunning, "second": StatusRunning, "func": StatusRunning,
+	}, statuses(s))
+
+	cancel()
+	require.NoError(t, <-errCh)
+	require.True(t, first.stopped.Load())
+	require.True(t, second.stopped.Load())
+	require.Equal(t, map[string]string{
+		"first": StatusStopped, "second": StatusStopped, "func": StatusStopped,
+	}, statuses(s))
+}
+
+func TestSupervisor_Failure(t *testing.T) {
+	s := New()
+	healthy := newTestComponent()
+	failErr := errors.New("address already in use")
+	s.Add("healthy", healthy)
+	s.Add("failed", RunFunc(func(ctx context.Context) error {
+		// It fails on the shutdown caused by the exited component, so the exit is always unexpected.
+		<-ctx.Done()
+		return failErr
+	}))
+	s.Add("exited", RunFunc(func(ctx context.Context) error {
+		return nil
+	}))
+
+	err := s.Run(context.Background(), time.Second)
+	require.ErrorIs(t, err, failErr)
+	require.ErrorIs(t, err, ErrStoppedUnexpectedly)
+	require.ErrorContains(t, err, "failed: address already in use")
+	require.True(t, healthy.stopped.Load())
+	require.Equal(t, map[string]string{
+		"healthy": StatusStopped, "failed": StatusFailed, "exited": StatusFailed,
+	}, statuses(s))
+	require.Equal(t, "address already in use", s.Health()[1].Error)
+}
+
+func TestSupervisor_StartFailure(t *testing.T) {
+	s := New()
+	healthy := newTestComponent()
+	started, fail := make(chan struct{}), make(chan struct{})
+	startErr := errors.New("connection refused")
+	s.Add("healthy", healthy)
+	s.Add("failing", RunFunc(func(ctx context.Context) error {
+		close(started)
+		<-fail
+		return startErr
+	}))
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.Run(context.Background(), time.Second)
+	}()
+	<-healthy.started
+	<-started
+	require.False(t, s.Healthy())
+	require.Equal(t, map[string]string{"healthy": StatusRunning, "failing": StatusStarting}, statuses(s))
+
+	close(fail)
+	require.ErrorIs(t, <-errCh, startErr)
+	require.Equal(t, map[string]string{"healthy": StatusStopped, "failing": StatusFailed}, statuses(s))
+}
+
+func TestSupervisor_ShutdownTimeout(t *testing.T) {
+	s := New()
+	blocked := newTestComponent()
+	blocked.block = true
+	s.Add("blocked", blocked)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.Run(ctx, 10*time.Millisecond)
+	}()
+	<-blocked.started
+	cancel()
+	err := <-errCh
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "blocked: shutdown timed out")
+	require.Equal(t, StatusStopping, s.Health()[0].Status)
+}
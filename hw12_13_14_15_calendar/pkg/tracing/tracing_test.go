@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitStdout(t *testing.T) {
+	var buf bytes.Buffer
+	shutdown, err := Init(context.Background(), Config{
+		ServiceName: "calendar-test",
+		Exporter:    ExporterStdout,
+		SampleRatio: 1,
+		Writer:      &buf,
+	})
+	require.NoError(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	require.Contains(t, carrier.Get("traceparent"), parent.SpanContext().TraceID().String())
+
+	remote := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+	_, child := otel.Tracer("test").Start(remote, "child")
+	require.Equal(t, parent.SpanContext().TraceID(), child.SpanContext().TraceID())
+	child.End()
+	parent.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	require.Contains(t, buf.String(), `"Name":"child"`)
+	require.Contains(t, buf.String(), `"Name":"parent"`)
+	require.Contains(t, buf.String(), "calendar-test")
+}
+
+func TestInitNone(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	require.NotEmpty(t, carrier.Get("traceparent"))
+}
+
+func TestInitUnknownExporter(t *testing.T) {
+	_, err := Init(context.Background(), Config{Exporter: "jaeger"})
+	require.ErrorIs(t, err, ErrExporter)
+}
@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"math"
+	"math/rand"
 	"time"
 
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
@@ -8,6 +10,7 @@ import (
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
 	"github.com/go-faker/faker/v4"
 	"github.com/go-faker/faker/v4/pkg/options"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -21,6 +24,18 @@ func GenerateTestEvent() *domain.Event {
 	endTime := e.StartTime.Add(time.Hour)
 	e.EndTime = &endTime
 	e.ID = faker.UUIDHyphenated(options.WithGenerateUniqueValues(true))
+	// Users are identified by a positive ID.
+	e.UserID = rand.Int63n(math.MaxInt32) + 1 //nolint:gosec
+	e.Recurrence = nil
+	e.RecurrenceID = nil
+	e.NotificationTarget = nil
+	e.DeletedTime = nil
+	e.Attendees = nil
+	e.Reminders = nil
+	e.TimeZone = ""
+	e.AllDay = false
+	e.CalendarID = ""
+	e.Version = 1
 	e.NormalizeTime()
 	return e
 }
@@ -41,15 +56,24 @@ func GetEventStartEndTime(e1, e2 *domain.Event) (time.Time, time.Time) {
 }
 
 func CreateTestEventRequest(event *domain.Event) *pb.EventRequest {
-	var (
-		endTime    *timestamppb.Timestamp
-		notifyTime *timestamppb.Timestamp
-	)
+	var endTime *timestamppb.Timestamp
 	if event.EndTime != nil {
 		endTime = timestamppb.New(*event.EndTime)
 	}
-	if event.NotifyTime != nil {
-		notifyTime = timestamppb.New(*event.NotifyTime)
+	var reminders []*pb.Reminder
+	for _, r := range event.Reminders {
+		reminder := &pb.Reminder{Offset: durationpb.New(r.Offset)}
+		if r.Time != nil {
+			reminder = &pb.Reminder{Time: timestamppb.New(*r.Time)}
+		}
+		reminders = append(reminders, reminder)
+	}
+	var recurrence *pb.Recurrence
+	if event.Recurrence != nil {
+		recurrence = &pb.Recurrence{Rule: event.Recurrence.Rule()}
+		for _, t := range event.Recurrence.Exceptions {
+			recurrence.Exceptions = append(recurrence.Exceptions, timestamppb.New(t))
+		}
 	}
 	return &pb.EventRequest{
 		Event: &pb.Event{
@@ -57,9 +81,13 @@ func CreateTestEventRequest(event *domain.Event) *pb.EventRequest {
 			Title:       event.Title,
 			StartTime:   timestamppb.New(event.StartTime),
 			EndTime:     endTime,
-			NotifyTime:  notifyTime,
 			Description: event.Description,
 			UserId:      event.UserID,
+			Recurrence:  recurrence,
+			Version:     event.Version,
+			Reminders:   reminders,
+			TimeZone:    event.TimeZone,
+			AllDay:      event.AllDay,
 		},
 		RequestId: faker.UUIDDigit(options.WithGenerateUniqueValues(true)),
 	}
@@ -2,6 +2,8 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
@@ -9,12 +11,18 @@ import (
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/common"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
 	mq "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/infrastructure/event"
+	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation"
 	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
 	"github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/tests"
 	. "github.com/onsi/ginkgo" //nolint: revive
 	. "github.com/onsi/gomega" //nolint: revive
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -34,16 +42,20 @@ var _ = Describe("Calendar API", func() {
 	conn, err := grpc.Dial(grpcEndpoint, opts...)
 	Expect(err).ToNot(HaveOccurred())
 	grpcClient := pb.NewEventServiceV1Client(conn)
+	calendarClient := pb.NewCalendarServiceV1Client(conn)
 	var (
 		ctx   context.Context
 		event *domain.Event
 	)
 	BeforeEach(func() {
-		ctx = context.Background()
 		event = tests.GenerateTestEvent()
+		ctx = metadata.AppendToOutgoingContext(
+			context.Background(), presentation.UserIDHeader, strconv.FormatInt(event.UserID, 10),
+		)
 	})
 	Describe("Create Event", func() {
 		It("creating an event", func() {
+			event.Reminders = []*domain.Reminder{{Offset: -time.Hour}, {Offset: time.Minute}}
 			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
 			Expect(err).ToNot(HaveOccurred())
 			Expect(e).ToNot(BeNil())
@@ -52,9 +64,25 @@ var _ = Describe("Calendar API", func() {
 			Expect(e.Event.Title).To(Equal(event.Title))
 			Expect(e.Event.StartTime.AsTime()).To(Equal(event.StartTime))
 			Expect(e.Event.EndTime.AsTime()).To(Equal(*event.EndTime))
-			Expect(e.Event.NotifyTime.AsTime()).To(Equal(*event.NotifyTime))
+			Expect(e.Event.Reminders).To(HaveLen(2))
+			Expect(e.Event.Reminders[0].Offset.AsDuration()).To(Equal(-time.Hour))
+			Expect(e.Event.Reminders[1].Offset.AsDuration()).To(Equal(time.Minute))
 			Expect(e.Event.UserId).To(Equal(event.UserID))
 		})
+		It("retrying creating an event", func() {
+			request := tests.CreateTestEventRequest(event)
+			e, err := grpcClient.CreateEvent(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			retry, err := grpcClient.CreateEvent(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retry.Event.Id).To(Equal(e.Event.Id))
+			request.Event.Title += " changed"
+			_, err = grpcClient.CreateEvent(ctx, request)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(
+				Equal("rpc error: code = InvalidArgument desc = request ID is already used by another request"),
+			)
+		})
 		It("error creating an event(end time less start time)", func() {
 			event.StartTime = event.EndTime.Add(time.Hour)
 			_, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
@@ -63,14 +91,34 @@ var _ = Describe("Calendar API", func() {
 				err.Error(),
 			).To(Equal("rpc error: code = InvalidArgument desc = end time must be greater than start time"))
 		})
-		It("error creating an event(notify time less start time)", func() {
-			t := event.StartTime.Add(-time.Hour)
-			event.NotifyTime = &t
+		It("error creating an event(repeated reminders)", func() {
+			event.Reminders = []*domain.Reminder{{Offset: -time.Hour}, {Offset: -time.Hour}}
 			_, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).To(Equal("rpc error: code = InvalidArgument desc = notify time must be greater than start time"))
+			).To(Equal("rpc error: code = InvalidArgument desc = invalid reminder: reminders must not repeat"))
+		})
+		It("error creating an event(unknown time zone)", func() {
+			event.TimeZone = "Mars/Olympus"
+			_, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).Should(HaveOccurred())
+			Expect(
+				err.Error(),
+			).To(Equal(`rpc error: code = InvalidArgument desc = invalid time zone: unknown time zone "Mars/Olympus"`))
+		})
+		It("error creating an overlapping event", func() {
+			_, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).Should(HaveOccurred())
+			Expect(
+				err.Error(),
+			).To(Equal("rpc error: code = AlreadyExists desc = event overlaps another event of the user"))
+			request := tests.CreateTestEventRequest(event)
+			request.AllowOverlap = true
+			_, err = grpcClient.CreateEvent(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
 		})
 		It("creating an empty event", func() {
 			event := &domain.Event{}
@@ -95,10 +143,20 @@ var _ = Describe("Calendar API", func() {
 			Expect(e.Event.Title).To(Equal(event.Title))
 			Expect(e.Event.StartTime.AsTime()).To(Equal(event.StartTime))
 			Expect(e.Event.EndTime.AsTime()).To(Equal(*event.EndTime))
-			Expect(e.Event.NotifyTime.AsTime()).To(Equal(*event.NotifyTime))
 			Expect(e.Event.UserId).To(Equal(event.UserID))
 			Expect(e.Event.CreatedTime.AsTime()).To(Equal(*event.CreatedTime))
 		})
+		It("updating a stale version of an event", func() {
+			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			event.ID = e.Event.Id
+			e, err = grpcClient.UpdateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(e.Event.Version).To(Equal(event.Version + 1))
+			_, err = grpcClient.UpdateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).Should(HaveOccurred())
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+		})
 		It("updating an event that doesn't exist", func() {
 			_, err := grpcClient.UpdateEvent(ctx, tests.CreateTestEventRequest(event))
 			Expect(err).Should(HaveOccurred())
@@ -110,21 +168,60 @@ var _ = Describe("Calendar API", func() {
 			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(e).ToNot(BeNil())
-			_, err = grpcClient.DeleteEvent(ctx, &pb.EventIDRequest{Id: e.Event.Id})
+			_, err = grpcClient.DeleteEvent(ctx, &pb.DeleteEventRequest{Id: e.Event.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			deleted, err := grpcClient.ListDeletedEvents(ctx, &emptypb.Empty{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(len(deleted.Events)).To(Equal(1))
+			Expect(deleted.Events[0].DeletedTime).ToNot(BeNil())
+		})
+		It("restoring a deleted event", func() {
+			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = grpcClient.DeleteEvent(ctx, &pb.DeleteEventRequest{Id: e.Event.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = grpcClient.GetEvent(ctx, &pb.EventIDRequest{Id: e.Event.Id})
+			Expect(err).Should(HaveOccurred())
+			restored, err := grpcClient.RestoreEvent(ctx, &pb.EventIDRequest{Id: e.Event.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(restored.Event.DeletedTime).To(BeNil())
+			_, err = grpcClient.GetEvent(ctx, &pb.EventIDRequest{Id: e.Event.Id})
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+		It("getting the history of a deleted event", func() {
+			request := tests.CreateTestEventRequest(event)
+			e, err := grpcClient.CreateEvent(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = grpcClient.DeleteEvent(ctx, &pb.DeleteEventRequest{Id: e.Event.Id, Permanent: true})
+			Expect(err).ShouldNot(HaveOccurred())
+			history, err := grpcClient.GetEventHistory(ctx, &pb.EventIDRequest{Id: e.Event.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(len(history.Changes)).To(Equal(2))
+			Expect(history.Changes[0].Action).To(Equal(domain.EventActionCreate))
+			Expect(history.Changes[0].ActorId).To(Equal(event.UserID))
+			Expect(history.Changes[0].RequestId).To(Equal(request.RequestId))
+			Expect(history.Changes[1].Action).To(Equal(domain.EventActionDeletePermanently))
+		})
+		It("deleting an event permanently", func() {
+			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = grpcClient.DeleteEvent(ctx, &pb.DeleteEventRequest{Id: e.Event.Id, Permanent: true})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = grpcClient.RestoreEvent(ctx, &pb.EventIDRequest{Id: e.Event.Id})
+			Expect(err).Should(HaveOccurred())
+		})
 		It("deleting an event with incorrect id", func() {
-			_, err := grpcClient.DeleteEvent(ctx, &pb.EventIDRequest{Id: "wrong"})
+			_, err := grpcClient.DeleteEvent(ctx, &pb.DeleteEventRequest{Id: "wrong"})
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(
 				Equal(
-					"rpc error: code = Unknown desc = invalid EventIDRequest.Id: " +
+					"rpc error: code = Unknown desc = invalid DeleteEventRequest.Id: " +
 						"value must be a valid UUID | caused by: invalid uuid format",
 				),
 			)
 		})
 		It("deleting a non-existent element", func() {
-			_, err := grpcClient.DeleteEvent(ctx, &pb.EventIDRequest{Id: event.ID})
+			_, err := grpcClient.DeleteEvent(ctx, &pb.DeleteEventRequest{Id: event.ID})
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(Equal("rpc error: code = NotFound desc = event doesn't exist"))
 		})
@@ -142,21 +239,171 @@ var _ = Describe("Calendar API", func() {
 			Expect(events.Events).ToNot(BeNil())
 			Expect(len(events.Events)).To(Equal(1))
 		})
+		It("getting all-day events by the date in another time zone", func() {
+			berlin, err := time.LoadLocation("Europe/Berlin")
+			Expect(err).ShouldNot(HaveOccurred())
+			year, month, day := event.StartTime.In(berlin).Date()
+			event.EndTime = nil
+			event.TimeZone = berlin.String()
+			event.AllDay = true
+			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(e.Event.StartTime.AsTime()).To(Equal(time.Date(year, month, day, 0, 0, 0, 0, berlin).UTC()))
+			Expect(e.Event.EndTime.AsTime()).To(Equal(time.Date(year, month, day+1, 0, 0, 0, 0, berlin).UTC()))
+			Expect(e.Event.TimeZone).To(Equal(berlin.String()))
+			date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			events, err := grpcClient.ListDayEvents(ctx, &pb.DateRequest{
+				Date: date.Format(time.DateOnly), TimeZone: "America/New_York",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(events.Events).To(HaveLen(1))
+			Expect(events.Events[0].AllDay).To(BeTrue())
+			events, err = grpcClient.ListDayEvents(ctx, &pb.DateRequest{
+				Date: date.AddDate(0, 0, -1).Format(time.DateOnly), TimeZone: "America/New_York",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(events.Events).To(BeEmpty())
+		})
+	})
+	Describe("Attendees", func() {
+		It("inviting an attendee and responding to the invitation", func() {
+			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			attendee := event.UserID + 1
+			attendeeCtx := metadata.AppendToOutgoingContext(
+				context.Background(), presentation.UserIDHeader, strconv.FormatInt(attendee, 10),
+			)
+			invited, err := grpcClient.InviteAttendees(
+				ctx, &pb.InviteAttendeesRequest{Id: e.Event.Id, UserIds: []int64{attendee}},
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(invited.Event.Version).To(Equal(e.Event.Version + 1))
+			invitations, err := grpcClient.ListInvitations(
+				attendeeCtx, &pb.ListInvitationsRequest{Status: pb.AttendeeStatus_ATTENDEE_STATUS_INVITED},
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(len(invitations.Events)).To(Equal(1))
+			_, err = grpcClient.RespondInvitation(
+				attendeeCtx,
+				&pb.RespondInvitationRequest{Id: e.Event.Id, Status: pb.AttendeeStatus_ATTENDEE_STATUS_DECLINED},
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			events, err := grpcClient.GetEventsByPeriod(attendeeCtx, &pb.TimePeriodRequest{
+				StartTime: timestamppb.New(event.StartTime),
+				EndTime:   timestamppb.New(*event.EndTime),
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(events.Events).To(BeEmpty())
+			_, err = grpcClient.GetEvent(attendeeCtx, &pb.EventIDRequest{Id: e.Event.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+	Describe("Calendars", func() {
+		It("creating an event in a shared calendar", func() {
+			writer, reader := event.UserID+1, event.UserID+2
+			userCtx := func(userID int64) context.Context {
+				return metadata.AppendToOutgoingContext(
+					context.Background(), presentation.UserIDHeader, strconv.FormatInt(userID, 10),
+				)
+			}
+			c, err := calendarClient.CreateCalendar(
+				ctx, &pb.CalendarRequest{Calendar: &pb.Calendar{Name: "Team", Color: "#1a73e8"}},
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			for userID, access := range map[int64]pb.CalendarAccess{
+				writer: pb.CalendarAccess_CALENDAR_ACCESS_WRITE,
+				reader: pb.CalendarAccess_CALENDAR_ACCESS_READ,
+			} {
+				_, err = calendarClient.ShareCalendar(
+					ctx, &pb.ShareCalendarRequest{Id: c.Calendar.Id, UserId: userID, Access: access},
+				)
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			calendars, err := calendarClient.ListCalendars(userCtx(writer), new(emptypb.Empty))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(len(calendars.Calendars)).To(Equal(2))
+
+			request := tests.CreateTestEventRequest(event)
+			request.Event.UserId = 0
+			request.Event.CalendarId = c.Calendar.Id
+			_, err = grpcClient.CreateEvent(userCtx(reader), request)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			e, err := grpcClient.CreateEvent(userCtx(writer), request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(e.Event.UserId).To(Equal(event.UserID))
+			events, err := grpcClient.GetEventsByPeriod(userCtx(reader), &pb.TimePeriodRequest{
+				StartTime:  timestamppb.New(event.StartTime),
+				EndTime:    timestamppb.New(*event.EndTime),
+				CalendarId: c.Calendar.Id,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(len(events.Events)).To(Equal(1))
+
+			_, err = calendarClient.DeleteCalendar(ctx, &pb.CalendarIDRequest{Id: c.Calendar.Id})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			_, err = grpcClient.DeleteEvent(userCtx(writer), &pb.DeleteEventRequest{Id: e.Event.Id, Permanent: true})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = calendarClient.DeleteCalendar(ctx, &pb.CalendarIDRequest{Id: c.Calendar.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("querying free/busy of a shared calendar", func() {
+			viewer := event.UserID + 3
+			viewerCtx := metadata.AppendToOutgoingContext(
+				context.Background(), presentation.UserIDHeader, strconv.FormatInt(viewer, 10),
+			)
+			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
+			Expect(err).ShouldNot(HaveOccurred())
+			request := &pb.FreeBusyRequest{
+				UserIds:   []int64{event.UserID},
+				StartTime: timestamppb.New(event.StartTime.Add(-time.Hour)),
+				EndTime:   timestamppb.New(event.EndTime.Add(time.Hour)),
+			}
+			freeBusy, err := calendarClient.QueryFreeBusy(viewerCtx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(freeBusy.Users[0].Busy).To(BeEmpty())
+
+			_, err = calendarClient.ShareCalendar(ctx, &pb.ShareCalendarRequest{
+				Id: e.Event.CalendarId, UserId: viewer, Access: pb.CalendarAccess_CALENDAR_ACCESS_FREE_BUSY,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			freeBusy, err = calendarClient.QueryFreeBusy(viewerCtx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(len(freeBusy.Users[0].Busy)).To(Equal(1))
+			Expect(freeBusy.Users[0].Busy[0].StartTime.AsTime()).To(Equal(event.StartTime))
+
+			slots, err := calendarClient.FindMeetingSlots(viewerCtx, &pb.MeetingSlotsRequest{
+				UserIds:           []int64{event.UserID},
+				StartTime:         request.StartTime,
+				EndTime:           request.EndTime,
+				Duration:          durationpb.New(30 * time.Minute),
+				IncludeWeekends:   true,
+				WorkingHoursEnd:   "24:00",
+				WorkingHoursStart: "00:00",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, slot := range slots.Slots {
+				overlaps := slot.StartTime.AsTime().Before(*event.EndTime) && slot.EndTime.AsTime().After(event.StartTime)
+				Expect(overlaps).To(BeFalse())
+			}
+		})
 	})
 	Describe("Test notification", func() {
 		It("testing notification", func() {
-			t := time.Now()
-			event.NotifyTime = &t
+			event.Reminders = []*domain.Reminder{{}}
 			e, err := grpcClient.CreateEvent(ctx, tests.CreateTestEventRequest(event))
 			Expect(err).ShouldNot(HaveOccurred())
 			time.Sleep(time.Duration(common.Config.Scheduler.PublishPeriodTime) * time.Second)
 			client := mq.NewRabbitClient()
 			ch, err := client.Consume(application.EventResultQueueName)
 			Expect(err).ShouldNot(HaveOccurred())
-			msg, ok := <-ch
+			d, ok := <-ch
 			Expect(ok).To(BeTrue())
-			Expect(msg).ToNot(BeNil())
-			Expect(msg).To(Equal([]byte(e.Event.Id)))
+			Expect(d.Ack()).To(Succeed())
+			var result domain.DeliveryResult
+			Expect(json.Unmarshal(d.Body(), &result)).To(Succeed())
+			Expect(result.EventID).To(Equal(e.Event.Id))
+			Expect(result.Channel).To(Equal(domain.ChannelLog))
+			Expect(result.Delivered).To(BeTrue())
 		})
 	})
 })
@@ -0,0 +1,209 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CalendarRepository is an autogenerated mock type for the CalendarRepository type
+type CalendarRepository struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: ctx, calendar
+func (_m *CalendarRepository) Add(ctx context.Context, calendar *domain.Calendar) error {
+	ret := _m.Called(ctx, calendar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Calendar) error); ok {
+		r0 = rf(ctx, calendar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, userID, calendarID
+func (_m *CalendarRepository) Delete(ctx context.Context, userID int64, calendarID string) error {
+	ret := _m.Called(ctx, userID, calendarID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, calendarID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID, calendarID
+func (_m *CalendarRepository) Get(ctx context.Context, userID int64, calendarID string) (*domain.Calendar, error) {
+	ret := _m.Called(ctx, userID, calendarID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Calendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (*domain.Calendar, error)); ok {
+		return rf(ctx, userID, calendarID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Calendar); ok {
+		r0 = rf(ctx, userID, calendarID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Calendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, calendarID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCalendars provides a mock function with given fields: ctx, userID
+func (_m *CalendarRepository) GetCalendars(ctx context.Context, userID int64) ([]*domain.Calendar, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendars")
+	}
+
+	var r0 []*domain.Calendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.Calendar, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*domain.Calendar); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Calendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShares provides a mock function with given fields: ctx, ownerID, calendarID
+func (_m *CalendarRepository) GetShares(ctx context.Context, ownerID int64, calendarID string) ([]*domain.CalendarShare, error) {
+	ret := _m.Called(ctx, ownerID, calendarID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShares")
+	}
+
+	var r0 []*domain.CalendarShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]*domain.CalendarShare, error)); ok {
+		return rf(ctx, ownerID, calendarID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []*domain.CalendarShare); ok {
+		r0 = rf(ctx, ownerID, calendarID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CalendarShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, ownerID, calendarID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Share provides a mock function with given fields: ctx, ownerID, calendarID, share
+func (_m *CalendarRepository) Share(ctx context.Context, ownerID int64, calendarID string, share *domain.CalendarShare) error {
+	ret := _m.Called(ctx, ownerID, calendarID, share)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Share")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *domain.CalendarShare) error); ok {
+		r0 = rf(ctx, ownerID, calendarID, share)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unshare provides a mock function with given fields: ctx, ownerID, calendarID, userID
+func (_m *CalendarRepository) Unshare(ctx context.Context, ownerID int64, calendarID string, userID int64) error {
+	ret := _m.Called(ctx, ownerID, calendarID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unshare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) error); ok {
+		r0 = rf(ctx, ownerID, calendarID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, calendar
+func (_m *CalendarRepository) Update(ctx context.Context, calendar *domain.Calendar) error {
+	ret := _m.Called(ctx, calendar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Calendar) error); ok {
+		r0 = rf(ctx, calendar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCalendarRepository creates a new instance of CalendarRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCalendarRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CalendarRepository {
+	mock := &CalendarRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,403 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+)
+
+// CalendarServiceV1Client is an autogenerated mock type for the CalendarServiceV1Client type
+type CalendarServiceV1Client struct {
+	mock.Mock
+}
+
+// CreateCalendar provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) CreateCalendar(ctx context.Context, in *pb.CalendarRequest, opts ...grpc.CallOption) (*pb.CalendarResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCalendar")
+	}
+
+	var r0 *pb.CalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest, ...grpc.CallOption) (*pb.CalendarResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest, ...grpc.CallOption) *pb.CalendarResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCalendar provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) DeleteCalendar(ctx context.Context, in *pb.CalendarIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCalendar")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) (*emptypb.Empty, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) *emptypb.Empty); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMeetingSlots provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) FindMeetingSlots(ctx context.Context, in *pb.MeetingSlotsRequest, opts ...grpc.CallOption) (*pb.MeetingSlotsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMeetingSlots")
+	}
+
+	var r0 *pb.MeetingSlotsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.MeetingSlotsRequest, ...grpc.CallOption) (*pb.MeetingSlotsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.MeetingSlotsRequest, ...grpc.CallOption) *pb.MeetingSlotsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.MeetingSlotsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.MeetingSlotsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCalendar provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) GetCalendar(ctx context.Context, in *pb.CalendarIDRequest, opts ...grpc.CallOption) (*pb.CalendarResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendar")
+	}
+
+	var r0 *pb.CalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) (*pb.CalendarResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) *pb.CalendarResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCalendarShares provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) ListCalendarShares(ctx context.Context, in *pb.CalendarIDRequest, opts ...grpc.CallOption) (*pb.CalendarSharesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCalendarShares")
+	}
+
+	var r0 *pb.CalendarSharesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) (*pb.CalendarSharesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) *pb.CalendarSharesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarSharesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCalendars provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) ListCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*pb.CalendarsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCalendars")
+	}
+
+	var r0 *pb.CalendarsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*pb.CalendarsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *pb.CalendarsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryFreeBusy provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) QueryFreeBusy(ctx context.Context, in *pb.FreeBusyRequest, opts ...grpc.CallOption) (*pb.FreeBusyResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryFreeBusy")
+	}
+
+	var r0 *pb.FreeBusyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.FreeBusyRequest, ...grpc.CallOption) (*pb.FreeBusyResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.FreeBusyRequest, ...grpc.CallOption) *pb.FreeBusyResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.FreeBusyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.FreeBusyRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareCalendar provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) ShareCalendar(ctx context.Context, in *pb.ShareCalendarRequest, opts ...grpc.CallOption) (*pb.CalendarSharesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareCalendar")
+	}
+
+	var r0 *pb.CalendarSharesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ShareCalendarRequest, ...grpc.CallOption) (*pb.CalendarSharesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ShareCalendarRequest, ...grpc.CallOption) *pb.CalendarSharesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarSharesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.ShareCalendarRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnshareCalendar provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) UnshareCalendar(ctx context.Context, in *pb.UnshareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnshareCalendar")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.UnshareCalendarRequest, ...grpc.CallOption) (*emptypb.Empty, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.UnshareCalendarRequest, ...grpc.CallOption) *emptypb.Empty); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.UnshareCalendarRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCalendar provides a mock function with given fields: ctx, in, opts
+func (_m *CalendarServiceV1Client) UpdateCalendar(ctx context.Context, in *pb.CalendarRequest, opts ...grpc.CallOption) (*pb.CalendarResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCalendar")
+	}
+
+	var r0 *pb.CalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest, ...grpc.CallOption) (*pb.CalendarResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest, ...grpc.CallOption) *pb.CalendarResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCalendarServiceV1Client creates a new instance of CalendarServiceV1Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCalendarServiceV1Client(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CalendarServiceV1Client {
+	mock := &CalendarServiceV1Client{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
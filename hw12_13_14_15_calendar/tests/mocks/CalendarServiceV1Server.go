@@ -0,0 +1,336 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/presentation/grpc/api/v1"
+)
+
+// CalendarServiceV1Server is an autogenerated mock type for the CalendarServiceV1Server type
+type CalendarServiceV1Server struct {
+	mock.Mock
+}
+
+// CreateCalendar provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) CreateCalendar(_a0 context.Context, _a1 *pb.CalendarRequest) (*pb.CalendarResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCalendar")
+	}
+
+	var r0 *pb.CalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest) (*pb.CalendarResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest) *pb.CalendarResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCalendar provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) DeleteCalendar(_a0 context.Context, _a1 *pb.CalendarIDRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCalendar")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest) (*emptypb.Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMeetingSlots provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) FindMeetingSlots(_a0 context.Context, _a1 *pb.MeetingSlotsRequest) (*pb.MeetingSlotsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMeetingSlots")
+	}
+
+	var r0 *pb.MeetingSlotsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.MeetingSlotsRequest) (*pb.MeetingSlotsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.MeetingSlotsRequest) *pb.MeetingSlotsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.MeetingSlotsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.MeetingSlotsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCalendar provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) GetCalendar(_a0 context.Context, _a1 *pb.CalendarIDRequest) (*pb.CalendarResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendar")
+	}
+
+	var r0 *pb.CalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest) (*pb.CalendarResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest) *pb.CalendarResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCalendarShares provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) ListCalendarShares(_a0 context.Context, _a1 *pb.CalendarIDRequest) (*pb.CalendarSharesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCalendarShares")
+	}
+
+	var r0 *pb.CalendarSharesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest) (*pb.CalendarSharesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarIDRequest) *pb.CalendarSharesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarSharesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCalendars provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) ListCalendars(_a0 context.Context, _a1 *emptypb.Empty) (*pb.CalendarsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCalendars")
+	}
+
+	var r0 *pb.CalendarsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) (*pb.CalendarsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *pb.CalendarsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryFreeBusy provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) QueryFreeBusy(_a0 context.Context, _a1 *pb.FreeBusyRequest) (*pb.FreeBusyResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryFreeBusy")
+	}
+
+	var r0 *pb.FreeBusyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.FreeBusyRequest) (*pb.FreeBusyResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.FreeBusyRequest) *pb.FreeBusyResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.FreeBusyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.FreeBusyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareCalendar provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) ShareCalendar(_a0 context.Context, _a1 *pb.ShareCalendarRequest) (*pb.CalendarSharesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareCalendar")
+	}
+
+	var r0 *pb.CalendarSharesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ShareCalendarRequest) (*pb.CalendarSharesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ShareCalendarRequest) *pb.CalendarSharesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarSharesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.ShareCalendarRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnshareCalendar provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) UnshareCalendar(_a0 context.Context, _a1 *pb.UnshareCalendarRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnshareCalendar")
+	}
+
+	var r0 *emptypb.Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.UnshareCalendarRequest) (*emptypb.Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.UnshareCalendarRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.UnshareCalendarRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCalendar provides a mock function with given fields: _a0, _a1
+func (_m *CalendarServiceV1Server) UpdateCalendar(_a0 context.Context, _a1 *pb.CalendarRequest) (*pb.CalendarResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCalendar")
+	}
+
+	var r0 *pb.CalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest) (*pb.CalendarResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.CalendarRequest) *pb.CalendarResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.CalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.CalendarRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mustEmbedUnimplementedCalendarServiceV1Server provides a mock function with given fields:
+func (_m *CalendarServiceV1Server) mustEmbedUnimplementedCalendarServiceV1Server() {
+	_m.Called()
+}
+
+// NewCalendarServiceV1Server creates a new instance of CalendarServiceV1Server. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCalendarServiceV1Server(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CalendarServiceV1Server {
+	mock := &CalendarServiceV1Server{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
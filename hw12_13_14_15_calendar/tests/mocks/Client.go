@@ -0,0 +1,172 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Client is an autogenerated mock type for the Client type
+type Client struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *Client) Check(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Close provides a mock function with given fields:
+func (_m *Client) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Consume provides a mock function with given fields: name
+func (_m *Client) Consume(name string) (<-chan domain.Delivery, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 <-chan domain.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (<-chan domain.Delivery, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) <-chan domain.Delivery); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeadLetters provides a mock function with given fields: queue, limit
+func (_m *Client) DeadLetters(queue string, limit int) ([]*domain.DeadLetter, error) {
+	ret := _m.Called(queue, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeadLetters")
+	}
+
+	var r0 []*domain.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]*domain.DeadLetter, error)); ok {
+		return rf(queue, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []*domain.DeadLetter); ok {
+		r0 = rf(queue, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(queue, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Publish provides a mock function with given fields: ctx, queueName, data
+func (_m *Client) Publish(ctx context.Context, queueName string, data []byte) error {
+	ret := _m.Called(ctx, queueName, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, queueName, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Replay provides a mock function with given fields: ctx, queue, limit
+func (_m *Client) Replay(ctx context.Context, queue string, limit int) (int, error) {
+	ret := _m.Called(ctx, queue, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (int, error)); ok {
+		return rf(ctx, queue, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) int); ok {
+		r0 = rf(ctx, queue, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, queue, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
+	mock := &Client{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeadLetterQueue is an autogenerated mock type for the DeadLetterQueue type
+type DeadLetterQueue struct {
+	mock.Mock
+}
+
+// DeadLetters provides a mock function with given fields: queue, limit
+func (_m *DeadLetterQueue) DeadLetters(queue string, limit int) ([]*domain.DeadLetter, error) {
+	ret := _m.Called(queue, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeadLetters")
+	}
+
+	var r0 []*domain.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]*domain.DeadLetter, error)); ok {
+		return rf(queue, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []*domain.DeadLetter); ok {
+		r0 = rf(queue, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(queue, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replay provides a mock function with given fields: ctx, queue, limit
+func (_m *DeadLetterQueue) Replay(ctx context.Context, queue string, limit int) (int, error) {
+	ret := _m.Called(ctx, queue, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (int, error)); ok {
+		return rf(ctx, queue, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) int); ok {
+		r0 = rf(ctx, queue, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, queue, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDeadLetterQueue creates a new instance of DeadLetterQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeadLetterQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeadLetterQueue {
+	mock := &DeadLetterQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
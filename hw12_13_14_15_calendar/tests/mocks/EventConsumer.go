@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // EventConsumer is an autogenerated mock type for the EventConsumer type
 type EventConsumer struct {
@@ -28,23 +31,23 @@ func (_m *EventConsumer) Close() error {
 }
 
 // Consume provides a mock function with given fields: name
-func (_m *EventConsumer) Consume(name string) (<-chan []byte, error) {
+func (_m *EventConsumer) Consume(name string) (<-chan domain.Delivery, error) {
 	ret := _m.Called(name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Consume")
 	}
 
-	var r0 <-chan []byte
+	var r0 <-chan domain.Delivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (<-chan []byte, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (<-chan domain.Delivery, error)); ok {
 		return rf(name)
 	}
-	if rf, ok := ret.Get(0).(func(string) <-chan []byte); ok {
+	if rf, ok := ret.Get(0).(func(string) <-chan domain.Delivery); ok {
 		r0 = rf(name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(<-chan []byte)
+			r0 = ret.Get(0).(<-chan domain.Delivery)
 		}
 	}
 
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,17 +16,17 @@ type EventRepository struct {
 	mock.Mock
 }
 
-// Add provides a mock function with given fields: event
-func (_m *EventRepository) Add(event *domain.Event) error {
-	ret := _m.Called(event)
+// Add provides a mock function with given fields: ctx, event
+func (_m *EventRepository) Add(ctx context.Context, event *domain.Event) error {
+	ret := _m.Called(ctx, event)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Add")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.Event) error); ok {
-		r0 = rf(event)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Event) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,17 +34,17 @@ func (_m *EventRepository) Add(event *domain.Event) error {
 	return r0
 }
 
-// Delete provides a mock function with given fields: eventID
-func (_m *EventRepository) Delete(eventID string) error {
-	ret := _m.Called(eventID)
+// Delete provides a mock function with given fields: ctx, userID, eventID
+func (_m *EventRepository) Delete(ctx context.Context, userID int64, eventID string) error {
+	ret := _m.Called(ctx, userID, eventID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, eventID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -50,17 +52,17 @@ func (_m *EventRepository) Delete(eventID string) error {
 	return r0
 }
 
-// DeleteEventBeforeDate provides a mock function with given fields: date
-func (_m *EventRepository) DeleteEventBeforeDate(date time.Time) error {
-	ret := _m.Called(date)
+// DeletePermanently provides a mock function with given fields: ctx, userID, eventID
+func (_m *EventRepository) DeletePermanently(ctx context.Context, userID int64, eventID string) error {
+	ret := _m.Called(ctx, userID, eventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteEventBeforeDate")
+		panic("no return value specified for DeletePermanently")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
-		r0 = rf(date)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, eventID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,9 +70,9 @@ func (_m *EventRepository) DeleteEventBeforeDate(date time.Time) error {
 	return r0
 }
 
-// Get provides a mock function with given fields: eventID
-func (_m *EventRepository) Get(eventID string) (*domain.Event, error) {
-	ret := _m.Called(eventID)
+// Get provides a mock function with given fields: ctx, userID, eventID
+func (_m *EventRepository) Get(ctx context.Context, userID int64, eventID string) (*domain.Event, error) {
+	ret := _m.Called(ctx, userID, eventID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
@@ -78,19 +80,19 @@ func (_m *EventRepository) Get(eventID string) (*domain.Event, error) {
 
 	var r0 *domain.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Event, error)); ok {
-		return rf(eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (*domain.Event, error)); ok {
+		return rf(ctx, userID, eventID)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Event); ok {
-		r0 = rf(eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Event); ok {
+		r0 = rf(ctx, userID, eventID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(eventID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, eventID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,29 +100,29 @@ func (_m *EventRepository) Get(eventID string) (*domain.Event, error) {
 	return r0, r1
 }
 
-// GetEventsByNotifyTime provides a mock function with given fields: startTime, endTime
-func (_m *EventRepository) GetEventsByNotifyTime(startTime time.Time, endTime time.Time) ([]*domain.Event, error) {
-	ret := _m.Called(startTime, endTime)
+// GetDeletedEvents provides a mock function with given fields: ctx, userID
+func (_m *EventRepository) GetDeletedEvents(ctx context.Context, userID int64) ([]*domain.Event, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsByNotifyTime")
+		panic("no return value specified for GetDeletedEvents")
 	}
 
 	var r0 []*domain.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) ([]*domain.Event, error)); ok {
-		return rf(startTime, endTime)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.Event, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*domain.Event); ok {
-		r0 = rf(startTime, endTime)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*domain.Event); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
-		r1 = rf(startTime, endTime)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,9 +130,9 @@ func (_m *EventRepository) GetEventsByNotifyTime(startTime time.Time, endTime ti
 	return r0, r1
 }
 
-// GetEventsByPeriod provides a mock function with given fields: startTime, endTime
-func (_m *EventRepository) GetEventsByPeriod(startTime time.Time, endTime time.Time) ([]*domain.Event, error) {
-	ret := _m.Called(startTime, endTime)
+// GetEventsByPeriod provides a mock function with given fields: ctx, userID, startTime, endTime
+func (_m *EventRepository) GetEventsByPeriod(ctx context.Context, userID int64, startTime time.Time, endTime time.Time) ([]*domain.Event, error) {
+	ret := _m.Called(ctx, userID, startTime, endTime)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetEventsByPeriod")
@@ -138,19 +140,265 @@ func (_m *EventRepository) GetEventsByPeriod(startTime time.Time, endTime time.T
 
 	var r0 []*domain.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) ([]*domain.Event, error)); ok {
-		return rf(startTime, endTime)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*domain.Event, error)); ok {
+		return rf(ctx, userID, startTime, endTime)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*domain.Event); ok {
-		r0 = rf(startTime, endTime)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*domain.Event); ok {
+		r0 = rf(ctx, userID, startTime, endTime)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
-		r1 = rf(startTime, endTime)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, startTime, endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventsPage provides a mock function with given fields: ctx, filter
+func (_m *EventRepository) GetEventsPage(ctx context.Context, filter *domain.EventFilter) (*domain.EventPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsPage")
+	}
+
+	var r0 *domain.EventPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.EventFilter) (*domain.EventPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.EventFilter) *domain.EventPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EventPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.EventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, userID, eventID
+func (_m *EventRepository) GetHistory(ctx context.Context, userID int64, eventID string) ([]*domain.EventChange, error) {
+	ret := _m.Called(ctx, userID, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []*domain.EventChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]*domain.EventChange, error)); ok {
+		return rf(ctx, userID, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []*domain.EventChange); ok {
+		r0 = rf(ctx, userID, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.EventChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: ctx, userID, status
+func (_m *EventRepository) GetInvitations(ctx context.Context, userID int64, status domain.AttendeeStatus) ([]*domain.Event, error) {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitations")
+	}
+
+	var r0 []*domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.AttendeeStatus) ([]*domain.Event, error)); ok {
+		return rf(ctx, userID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.AttendeeStatus) []*domain.Event); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.AttendeeStatus) error); ok {
+		r1 = rf(ctx, userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverlappingEvents provides a mock function with given fields: ctx, userID, startTime, endTime
+func (_m *EventRepository) GetOverlappingEvents(ctx context.Context, userID int64, startTime time.Time, endTime time.Time) ([]*domain.Event, error) {
+	ret := _m.Called(ctx, userID, startTime, endTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverlappingEvents")
+	}
+
+	var r0 []*domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*domain.Event, error)); ok {
+		return rf(ctx, userID, startTime, endTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*domain.Event); ok {
+		r0 = rf(ctx, userID, startTime, endTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, startTime, endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteAttendees provides a mock function with given fields: ctx, userID, eventID, userIDs
+func (_m *EventRepository) InviteAttendees(ctx context.Context, userID int64, eventID string, userIDs []int64) (*domain.Event, error) {
+	ret := _m.Called(ctx, userID, eventID, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteAttendees")
+	}
+
+	var r0 *domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []int64) (*domain.Event, error)); ok {
+		return rf(ctx, userID, eventID, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []int64) *domain.Event); ok {
+		r0 = rf(ctx, userID, eventID, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, []int64) error); ok {
+		r1 = rf(ctx, userID, eventID, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IterateEvents provides a mock function with given fields: ctx, filter, fn
+func (_m *EventRepository) IterateEvents(ctx context.Context, filter *domain.EventFilter, fn func(*domain.Event) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IterateEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.EventFilter, func(*domain.Event) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeEvents provides a mock function with given fields: ctx, expiredBefore, deletedBefore
+func (_m *EventRepository) PurgeEvents(ctx context.Context, expiredBefore time.Time, deletedBefore time.Time) error {
+	ret := _m.Called(ctx, expiredBefore, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, expiredBefore, deletedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RespondInvitation provides a mock function with given fields: ctx, userID, eventID, status
+func (_m *EventRepository) RespondInvitation(ctx context.Context, userID int64, eventID string, status domain.AttendeeStatus) (*domain.Event, error) {
+	ret := _m.Called(ctx, userID, eventID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RespondInvitation")
+	}
+
+	var r0 *domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, domain.AttendeeStatus) (*domain.Event, error)); ok {
+		return rf(ctx, userID, eventID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, domain.AttendeeStatus) *domain.Event); ok {
+		r0 = rf(ctx, userID, eventID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, domain.AttendeeStatus) error); ok {
+		r1 = rf(ctx, userID, eventID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, userID, eventID
+func (_m *EventRepository) Restore(ctx context.Context, userID int64, eventID string) (*domain.Event, error) {
+	ret := _m.Called(ctx, userID, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (*domain.Event, error)); ok {
+		return rf(ctx, userID, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Event); ok {
+		r0 = rf(ctx, userID, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, eventID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -158,17 +406,17 @@ func (_m *EventRepository) GetEventsByPeriod(startTime time.Time, endTime time.T
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: event
-func (_m *EventRepository) Update(event *domain.Event) error {
-	ret := _m.Called(event)
+// Update provides a mock function with given fields: ctx, userID, event
+func (_m *EventRepository) Update(ctx context.Context, userID int64, event *domain.Event) error {
+	ret := _m.Called(ctx, userID, event)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.Event) error); ok {
-		r0 = rf(event)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.Event) error); ok {
+		r0 = rf(ctx, userID, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -56,7 +56,7 @@ func (_m *EventServiceV1Client) CreateEvent(ctx context.Context, in *pb.EventReq
 }
 
 // DeleteEvent provides a mock function with given fields: ctx, in, opts
-func (_m *EventServiceV1Client) DeleteEvent(ctx context.Context, in *pb.EventIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (_m *EventServiceV1Client) DeleteEvent(ctx context.Context, in *pb.DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -72,10 +72,10 @@ func (_m *EventServiceV1Client) DeleteEvent(ctx context.Context, in *pb.EventIDR
 
 	var r0 *emptypb.Empty
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) (*emptypb.Empty, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DeleteEventRequest, ...grpc.CallOption) (*emptypb.Empty, error)); ok {
 		return rf(ctx, in, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) *emptypb.Empty); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DeleteEventRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
@@ -83,7 +83,7 @@ func (_m *EventServiceV1Client) DeleteEvent(ctx context.Context, in *pb.EventIDR
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DeleteEventRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -129,6 +129,43 @@ func (_m *EventServiceV1Client) GetEvent(ctx context.Context, in *pb.EventIDRequ
 	return r0, r1
 }
 
+// GetEventHistory provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) GetEventHistory(ctx context.Context, in *pb.EventIDRequest, opts ...grpc.CallOption) (*pb.EventHistoryResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventHistory")
+	}
+
+	var r0 *pb.EventHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) (*pb.EventHistoryResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) *pb.EventHistoryResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsByPeriod provides a mock function with given fields: ctx, in, opts
 func (_m *EventServiceV1Client) GetEventsByPeriod(ctx context.Context, in *pb.TimePeriodRequest, opts ...grpc.CallOption) (*pb.EventsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -166,6 +203,413 @@ func (_m *EventServiceV1Client) GetEventsByPeriod(ctx context.Context, in *pb.Ti
 	return r0, r1
 }
 
+// GetNotificationTarget provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) GetNotificationTarget(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*pb.NotificationTargetResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationTarget")
+	}
+
+	var r0 *pb.NotificationTargetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*pb.NotificationTargetResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *pb.NotificationTargetResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.NotificationTargetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteAttendees provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) InviteAttendees(ctx context.Context, in *pb.InviteAttendeesRequest, opts ...grpc.CallOption) (*pb.EventResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteAttendees")
+	}
+
+	var r0 *pb.EventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.InviteAttendeesRequest, ...grpc.CallOption) (*pb.EventResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.InviteAttendeesRequest, ...grpc.CallOption) *pb.EventResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.InviteAttendeesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDayEvents provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) ListDayEvents(ctx context.Context, in *pb.DateRequest, opts ...grpc.CallOption) (*pb.EventsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDayEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) (*pb.EventsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) *pb.EventsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeletedEvents provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) ListDeletedEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*pb.EventsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*pb.EventsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *pb.EventsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvitations provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) ListInvitations(ctx context.Context, in *pb.ListInvitationsRequest, opts ...grpc.CallOption) (*pb.EventsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ListInvitationsRequest, ...grpc.CallOption) (*pb.EventsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ListInvitationsRequest, ...grpc.CallOption) *pb.EventsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.ListInvitationsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMonthEvents provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) ListMonthEvents(ctx context.Context, in *pb.DateRequest, opts ...grpc.CallOption) (*pb.EventsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMonthEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) (*pb.EventsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) *pb.EventsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeekEvents provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) ListWeekEvents(ctx context.Context, in *pb.DateRequest, opts ...grpc.CallOption) (*pb.EventsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeekEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) (*pb.EventsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) *pb.EventsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RespondInvitation provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) RespondInvitation(ctx context.Context, in *pb.RespondInvitationRequest, opts ...grpc.CallOption) (*pb.EventResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RespondInvitation")
+	}
+
+	var r0 *pb.EventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.RespondInvitationRequest, ...grpc.CallOption) (*pb.EventResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.RespondInvitationRequest, ...grpc.CallOption) *pb.EventResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.RespondInvitationRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreEvent provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) RestoreEvent(ctx context.Context, in *pb.EventIDRequest, opts ...grpc.CallOption) (*pb.EventResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEvent")
+	}
+
+	var r0 *pb.EventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) (*pb.EventResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) *pb.EventResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.EventIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetNotificationTarget provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) SetNotificationTarget(ctx context.Context, in *pb.NotificationTargetRequest, opts ...grpc.CallOption) (*pb.NotificationTargetResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNotificationTarget")
+	}
+
+	var r0 *pb.NotificationTargetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.NotificationTargetRequest, ...grpc.CallOption) (*pb.NotificationTargetResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.NotificationTargetRequest, ...grpc.CallOption) *pb.NotificationTargetResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.NotificationTargetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.NotificationTargetRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamEventsByPeriod provides a mock function with given fields: ctx, in, opts
+func (_m *EventServiceV1Client) StreamEventsByPeriod(ctx context.Context, in *pb.TimePeriodRequest, opts ...grpc.CallOption) (pb.EventServiceV1_StreamEventsByPeriodClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamEventsByPeriod")
+	}
+
+	var r0 pb.EventServiceV1_StreamEventsByPeriodClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.TimePeriodRequest, ...grpc.CallOption) (pb.EventServiceV1_StreamEventsByPeriodClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.TimePeriodRequest, ...grpc.CallOption) pb.EventServiceV1_StreamEventsByPeriodClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pb.EventServiceV1_StreamEventsByPeriodClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.TimePeriodRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateEvent provides a mock function with given fields: ctx, in, opts
 func (_m *EventServiceV1Client) UpdateEvent(ctx context.Context, in *pb.EventRequest, opts ...grpc.CallOption) (*pb.EventResponse, error) {
 	_va := make([]interface{}, len(opts))
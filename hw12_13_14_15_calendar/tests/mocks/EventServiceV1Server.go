@@ -47,7 +47,7 @@ func (_m *EventServiceV1Server) CreateEvent(_a0 context.Context, _a1 *pb.EventRe
 }
 
 // DeleteEvent provides a mock function with given fields: _a0, _a1
-func (_m *EventServiceV1Server) DeleteEvent(_a0 context.Context, _a1 *pb.EventIDRequest) (*emptypb.Empty, error) {
+func (_m *EventServiceV1Server) DeleteEvent(_a0 context.Context, _a1 *pb.DeleteEventRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
@@ -56,10 +56,10 @@ func (_m *EventServiceV1Server) DeleteEvent(_a0 context.Context, _a1 *pb.EventID
 
 	var r0 *emptypb.Empty
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest) (*emptypb.Empty, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DeleteEventRequest) (*emptypb.Empty, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest) *emptypb.Empty); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DeleteEventRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
@@ -67,7 +67,7 @@ func (_m *EventServiceV1Server) DeleteEvent(_a0 context.Context, _a1 *pb.EventID
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *pb.EventIDRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DeleteEventRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -106,6 +106,36 @@ func (_m *EventServiceV1Server) GetEvent(_a0 context.Context, _a1 *pb.EventIDReq
 	return r0, r1
 }
 
+// GetEventHistory provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) GetEventHistory(_a0 context.Context, _a1 *pb.EventIDRequest) (*pb.EventHistoryResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventHistory")
+	}
+
+	var r0 *pb.EventHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest) (*pb.EventHistoryResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest) *pb.EventHistoryResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.EventIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsByPeriod provides a mock function with given fields: _a0, _a1
 func (_m *EventServiceV1Server) GetEventsByPeriod(_a0 context.Context, _a1 *pb.TimePeriodRequest) (*pb.EventsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -136,6 +166,324 @@ func (_m *EventServiceV1Server) GetEventsByPeriod(_a0 context.Context, _a1 *pb.T
 	return r0, r1
 }
 
+// GetNotificationTarget provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) GetNotificationTarget(_a0 context.Context, _a1 *emptypb.Empty) (*pb.NotificationTargetResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationTarget")
+	}
+
+	var r0 *pb.NotificationTargetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) (*pb.NotificationTargetResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *pb.NotificationTargetResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.NotificationTargetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteAttendees provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) InviteAttendees(_a0 context.Context, _a1 *pb.InviteAttendeesRequest) (*pb.EventResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteAttendees")
+	}
+
+	var r0 *pb.EventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.InviteAttendeesRequest) (*pb.EventResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.InviteAttendeesRequest) *pb.EventResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.InviteAttendeesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDayEvents provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) ListDayEvents(_a0 context.Context, _a1 *pb.DateRequest) (*pb.EventsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDayEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest) (*pb.EventsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest) *pb.EventsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DateRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeletedEvents provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) ListDeletedEvents(_a0 context.Context, _a1 *emptypb.Empty) (*pb.EventsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) (*pb.EventsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *pb.EventsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvitations provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) ListInvitations(_a0 context.Context, _a1 *pb.ListInvitationsRequest) (*pb.EventsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ListInvitationsRequest) (*pb.EventsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ListInvitationsRequest) *pb.EventsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.ListInvitationsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMonthEvents provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) ListMonthEvents(_a0 context.Context, _a1 *pb.DateRequest) (*pb.EventsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMonthEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest) (*pb.EventsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest) *pb.EventsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DateRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeekEvents provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) ListWeekEvents(_a0 context.Context, _a1 *pb.DateRequest) (*pb.EventsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeekEvents")
+	}
+
+	var r0 *pb.EventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest) (*pb.EventsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DateRequest) *pb.EventsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DateRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RespondInvitation provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) RespondInvitation(_a0 context.Context, _a1 *pb.RespondInvitationRequest) (*pb.EventResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RespondInvitation")
+	}
+
+	var r0 *pb.EventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.RespondInvitationRequest) (*pb.EventResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.RespondInvitationRequest) *pb.EventResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.RespondInvitationRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreEvent provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) RestoreEvent(_a0 context.Context, _a1 *pb.EventIDRequest) (*pb.EventResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEvent")
+	}
+
+	var r0 *pb.EventResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest) (*pb.EventResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.EventIDRequest) *pb.EventResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.EventResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.EventIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetNotificationTarget provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) SetNotificationTarget(_a0 context.Context, _a1 *pb.NotificationTargetRequest) (*pb.NotificationTargetResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNotificationTarget")
+	}
+
+	var r0 *pb.NotificationTargetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.NotificationTargetRequest) (*pb.NotificationTargetResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.NotificationTargetRequest) *pb.NotificationTargetResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.NotificationTargetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.NotificationTargetRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamEventsByPeriod provides a mock function with given fields: _a0, _a1
+func (_m *EventServiceV1Server) StreamEventsByPeriod(_a0 *pb.TimePeriodRequest, _a1 pb.EventServiceV1_StreamEventsByPeriodServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamEventsByPeriod")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pb.TimePeriodRequest, pb.EventServiceV1_StreamEventsByPeriodServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateEvent provides a mock function with given fields: _a0, _a1
 func (_m *EventServiceV1Server) UpdateEvent(_a0 context.Context, _a1 *pb.EventRequest) (*pb.EventResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,115 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: ctx, request, response, ttl
+func (_m *IdempotencyRepository) Complete(ctx context.Context, request *domain.IdempotentRequest, response []byte, ttl time.Duration) error {
+	ret := _m.Called(ctx, request, response, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest, []byte, time.Duration) error); ok {
+		r0 = rf(ctx, request, response, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Purge provides a mock function with given fields: ctx, now
+func (_m *IdempotencyRepository) Purge(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: ctx, request
+func (_m *IdempotencyRepository) Release(ctx context.Context, request *domain.IdempotentRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: ctx, request, ttl
+func (_m *IdempotencyRepository) Reserve(ctx context.Context, request *domain.IdempotentRequest, ttl time.Duration) (*domain.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, request, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 *domain.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest, time.Duration) (*domain.IdempotencyRecord, error)); ok {
+		return rf(ctx, request, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotentRequest, time.Duration) *domain.IdempotencyRecord); ok {
+		r0 = rf(ctx, request, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.IdempotentRequest, time.Duration) error); ok {
+		r1 = rf(ctx, request, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotificationChannel is an autogenerated mock type for the NotificationChannel type
+type NotificationChannel struct {
+	mock.Mock
+}
+
+// Name provides a mock function with given fields:
+func (_m *NotificationChannel) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Send provides a mock function with given fields: ctx, notification, address
+func (_m *NotificationChannel) Send(ctx context.Context, notification *domain.Notification, address string) error {
+	ret := _m.Called(ctx, notification, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Notification, string) error); ok {
+		r0 = rf(ctx, notification, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotificationChannel creates a new instance of NotificationChannel. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationChannel(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationChannel {
+	mock := &NotificationChannel{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
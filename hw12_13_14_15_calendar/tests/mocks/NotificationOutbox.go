@@ -0,0 +1,59 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// NotificationOutbox is an autogenerated mock type for the NotificationOutbox type
+type NotificationOutbox struct {
+	mock.Mock
+}
+
+// ProcessNotifications provides a mock function with given fields: ctx, now, limit, backoff, fn
+func (_m *NotificationOutbox) ProcessNotifications(ctx context.Context, now time.Time, limit int, backoff domain.Backoff, fn func(*domain.OutboxMessage) error) (int, error) {
+	ret := _m.Called(ctx, now, limit, backoff, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessNotifications")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, domain.Backoff, func(*domain.OutboxMessage) error) (int, error)); ok {
+		return rf(ctx, now, limit, backoff, fn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, domain.Backoff, func(*domain.OutboxMessage) error) int); ok {
+		r0 = rf(ctx, now, limit, backoff, fn)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, domain.Backoff, func(*domain.OutboxMessage) error) error); ok {
+		r1 = rf(ctx, now, limit, backoff, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNotificationOutbox creates a new instance of NotificationOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationOutbox {
+	mock := &NotificationOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
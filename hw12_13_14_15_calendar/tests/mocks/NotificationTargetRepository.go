@@ -0,0 +1,77 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/dmitrii-a/hw_go/hw12_13_14_15_calendar/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotificationTargetRepository is an autogenerated mock type for the NotificationTargetRepository type
+type NotificationTargetRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, userID
+func (_m *NotificationTargetRepository) Get(ctx context.Context, userID int64) (*domain.NotificationTarget, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.NotificationTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.NotificationTarget, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.NotificationTarget); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NotificationTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Set provides a mock function with given fields: ctx, userID, target
+func (_m *NotificationTargetRepository) Set(ctx context.Context, userID int64, target *domain.NotificationTarget) error {
+	ret := _m.Called(ctx, userID, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.NotificationTarget) error); ok {
+		r0 = rf(ctx, userID, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotificationTargetRepository creates a new instance of NotificationTargetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationTargetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationTargetRepository {
+	mock := &NotificationTargetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,29 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// UnsafeCalendarServiceV1Server is an autogenerated mock type for the UnsafeCalendarServiceV1Server type
+type UnsafeCalendarServiceV1Server struct {
+	mock.Mock
+}
+
+// mustEmbedUnimplementedCalendarServiceV1Server provides a mock function with given fields:
+func (_m *UnsafeCalendarServiceV1Server) mustEmbedUnimplementedCalendarServiceV1Server() {
+	_m.Called()
+}
+
+// NewUnsafeCalendarServiceV1Server creates a new instance of UnsafeCalendarServiceV1Server. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsafeCalendarServiceV1Server(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsafeCalendarServiceV1Server {
+	mock := &UnsafeCalendarServiceV1Server{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}